@@ -0,0 +1,105 @@
+package wireless
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// scopeIDs generates the identifiers of the child scopes.
+var scopeIDs atomic.Int64
+
+// ScopeInfo describes the active child scope.
+type ScopeInfo struct {
+	// ID is the identifier of the scope, unique within the process.
+	ID   int64
+	Kind string
+	// Depth is the number of the ancestors of the scope.
+	Depth   int
+	Created time.Time
+	Age     time.Duration
+}
+
+// ActiveScopes returns the descriptions of the child scopes of the injector and of their descendants, which are
+// not cleaned yet, ordered by their creation.
+func (i *Injector) ActiveScopes() []ScopeInfo {
+	now := time.Now()
+	var scopes []ScopeInfo
+	var collect func(s *Injector, depth int)
+	collect = func(s *Injector, depth int) {
+		for _, c := range s.childScopes() {
+			scopes = append(scopes, ScopeInfo{ID: c.scopeID, Kind: c.kind, Depth: depth, Created: c.created, Age: now.Sub(c.created)})
+			collect(c, depth+1)
+		}
+	}
+	collect(i, 1)
+	sort.SliceStable(scopes, func(j, k int) bool {
+		return scopes[j].Created.Before(scopes[k].Created)
+	})
+	return scopes
+}
+
+// CleanScope cleans the active descendant scope with the identifier reported by ActiveScopes, e.g. the leaked
+// request scope found by the operator during the incident.
+func (i *Injector) CleanScope(id int64) error {
+	var find func(s *Injector) *Injector
+	find = func(s *Injector) *Injector {
+		for _, c := range s.childScopes() {
+			if c.scopeID == id {
+				return c
+			}
+			if d := find(c); d != nil {
+				return d
+			}
+		}
+		return nil
+	}
+	c := find(i)
+	if c == nil {
+		return fmt.Errorf("active scope: %d not found", id)
+	}
+	c.Clean()
+	return nil
+}
+
+// WithScopeLeakDetection makes the child scopes emit the EventScopeLeaked event when they are not cleaned within
+// the duration after their creation, so that the leaked scopes, e.g. of the requests never cleaned, are reported.
+func WithScopeLeakDetection(after time.Duration) Option {
+	return func(i *Injector) {
+		i.leakAfter = after
+	}
+}
+
+// childScopes returns the active child scopes of the injector.
+func (i *Injector) childScopes() []*Injector {
+	i.childrenLock.Lock()
+	defer i.childrenLock.Unlock()
+	children := make([]*Injector, 0, len(i.children))
+	for c := range i.children {
+		children = append(children, c)
+	}
+	return children
+}
+
+// addChild tracks the active child scope and starts its leak detection.
+func (i *Injector) addChild(c *Injector) {
+	i.childrenLock.Lock()
+	defer i.childrenLock.Unlock()
+	if i.children == nil {
+		i.children = map[*Injector]struct{}{}
+	}
+	i.children[c] = struct{}{}
+	if c.leakAfter > 0 {
+		c.leakTimer = time.AfterFunc(c.leakAfter, func() {
+			c.emit(Event{Kind: EventScopeLeaked, Age: time.Since(c.created)})
+		})
+	}
+}
+
+// removeChild stops tracking the cleaned child scope.
+func (i *Injector) removeChild(c *Injector) {
+	i.childrenLock.Lock()
+	defer i.childrenLock.Unlock()
+	delete(i.children, c)
+}
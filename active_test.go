@@ -0,0 +1,99 @@
+package wireless
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActiveScopes(t *testing.T) {
+	i := New()
+	request := i.NewScope(ScopeRequest)
+	job := request.NewScope(ScopeJob)
+	scopes := i.ActiveScopes()
+	if len(scopes) != 2 {
+		t.Fatalf("Expected %v, got %v", 2, len(scopes))
+	}
+	if scopes[0].Kind != ScopeRequest || scopes[0].Depth != 1 || scopes[1].Kind != ScopeJob || scopes[1].Depth != 2 {
+		t.Errorf("Expected request and job scopes, got %v", scopes)
+	}
+
+	job.Clean()
+	if scopes = i.ActiveScopes(); len(scopes) != 1 || scopes[0].Kind != ScopeRequest {
+		t.Errorf("Expected the request scope only, got %v", scopes)
+	}
+	request.Clean()
+	if scopes = i.ActiveScopes(); len(scopes) != 0 {
+		t.Errorf("Expected no active scopes, got %v", scopes)
+	}
+}
+
+func TestScopeLeakDetection(t *testing.T) {
+	leaked := make(chan Event, 2)
+	i := New(WithScopeLeakDetection(10*time.Millisecond), WithEventHandler(func(e Event) {
+		if e.Kind == EventScopeLeaked {
+			leaked <- e
+		}
+	}))
+	cleaned := i.NewScope(ScopeRequest)
+	cleaned.Clean()
+	i.NewScope(ScopeJob)
+
+	select {
+	case e := <-leaked:
+		if e.Scope != ScopeJob || e.Age < 10*time.Millisecond {
+			t.Errorf("Expected leaked job scope, got %v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the leaked scope event")
+	}
+	select {
+	case e := <-leaked:
+		t.Errorf("Expected the cleaned scope not to be reported, got %v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCleanScope(t *testing.T) {
+	i := New()
+	request := i.NewScope(ScopeRequest)
+	job := request.NewScope(ScopeJob)
+
+	scopes := i.ActiveScopes()
+	if len(scopes) != 2 || scopes[1].Kind != ScopeJob || scopes[0].ID == scopes[1].ID {
+		t.Fatalf("Expected the request and job scopes, got %v", scopes)
+	}
+	if err := i.CleanScope(scopes[1].ID); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if job.State() != StateCleaned || request.State() == StateCleaned {
+		t.Errorf("Expected only the job scope cleaned, got %v and %v", job.State(), request.State())
+	}
+	if err := i.CleanScope(scopes[1].ID); err == nil {
+		t.Error("Expected error for the cleaned scope, got nil")
+	}
+
+	t.Run("session", func(t *testing.T) {
+		i := New()
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		s, err := i.Scope("tenant")
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.CleanScope(i.ActiveScopes()[0].ID); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		again, err := i.Scope("tenant")
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if again == s || again.State() == StateCleaned {
+			t.Error("Expected the new session scope after the cleaned one")
+		}
+		var key ScopeKey
+		if err := again.InjectAs(&key); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrAmbiguousProvider is matched by the AmbiguousProviderError.
+var ErrAmbiguousProvider = errors.New("ambiguous provider")
+
+// Candidate is the provider matching the type which requires exactly one provider.
+type Candidate struct {
+	Type reflect.Type
+	Site ProviderSite
+}
+
+// AmbiguousProviderError is returned when multiple providers match the type which requires exactly one of them,
+// e.g. the interface type bound automatically with WithAutoBind, or the group injected into the single value.
+type AmbiguousProviderError struct {
+	Type reflect.Type
+	// Group or Name is the name of the group or named providers the candidates were found in, if any.
+	Group      string
+	Name       string
+	Candidates []Candidate
+}
+
+// Error implements error interface.
+func (e *AmbiguousProviderError) Error() string {
+	candidates := make([]string, len(e.Candidates))
+	for j, c := range e.Candidates {
+		candidates[j] = fmt.Sprintf("%s (%s)", c.Type, c.Site)
+	}
+	in := ""
+	switch {
+	case e.Group != "":
+		in = fmt.Sprintf(" in the group: %s", e.Group)
+	case e.Name != "":
+		in = fmt.Sprintf(" with the name: %q", e.Name)
+	}
+	return fmt.Sprintf("ambiguous providers of type: %s%s, candidates: %s; use wireless.Bind to select the implementation, "+
+		"wireless.Named to distinguish the candidates, or inject the group into a slice", e.Type, in, strings.Join(candidates, ", "))
+}
+
+// Is matches the ErrAmbiguousProvider.
+func (e *AmbiguousProviderError) Is(target error) bool {
+	return target == ErrAmbiguousProvider
+}
+
+// WithAutoBind makes the injector bind the interface types which are not provided explicitly to the single provided
+// type implementing them, failing with the AmbiguousProviderError if there are multiple such types.
+// The interfaces provided by the ancestors of the child scope and the empty interfaces are not bound automatically.
+func WithAutoBind() Option {
+	return func(i *Injector) {
+		i.autoBind = true
+	}
+}
+
+// autoBound returns the single provided type implementing the interface type, or nil if there is none.
+func (i *Injector) autoBound(t reflect.Type) (reflect.Type, error) {
+	if !i.autoBind || t.Kind() != reflect.Interface || t.NumMethod() == 0 {
+		return nil, nil
+	}
+	if i.parent != nil && i.parent.hasProvider(t) {
+		return nil, nil
+	}
+	var candidates []Candidate
+	add := func(ct reflect.Type) {
+		if !isBuiltin(ct) && ct != t && ct.Implements(t) {
+			candidates = append(candidates, Candidate{Type: ct, Site: i.site(ct)})
+		}
+	}
+	for _, ct := range sortedTypes(i.values) {
+		add(ct)
+	}
+	for _, ct := range sortedTypes(i.providersMap) {
+		add(ct)
+	}
+	sort.Slice(candidates, func(j, k int) bool {
+		return candidates[j].Type.String() < candidates[k].Type.String()
+	})
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return candidates[0].Type, nil
+	}
+	return nil, &AmbiguousProviderError{Type: t, Candidates: candidates}
+}
+
+// site returns the declaration of the provider of the type.
+func (i *Injector) site(t reflect.Type) ProviderSite {
+	if s, ok := i.sites[t]; ok {
+		return s
+	}
+	return ProviderSite{Provider: i.source(t)}
+}
+
+// memberSite returns the declaration of the group or named provider.
+func memberSite(p *providerFunc) ProviderSite {
+	s := ProviderSite{Provider: p.name()}
+	if p.source != nil {
+		s.Source, s.Namespace = p.source.source, p.source.namespace
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package wireless
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAmbiguousProvider(t *testing.T) {
+	t.Run("AutoBind", func(t *testing.T) {
+		i := New(WithAutoBind())
+		i.Provide(
+			Func(func() *bytes.Buffer { return new(bytes.Buffer) }),
+			Func(func(w io.Writer) *testType { return &testType{v: "written"} }),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var w io.Writer
+		if err := i.InjectAs(&w); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil || tt.v != "written" {
+			t.Errorf("Expected the auto bound dependency, got %v", err)
+		}
+	})
+
+	t.Run("AutoBindAmbiguous", func(t *testing.T) {
+		i := New(WithAutoBind())
+		i.Provide(
+			Func(func() *bytes.Buffer { return new(bytes.Buffer) }),
+			Value(&strings.Builder{}),
+			Func(func(w io.Writer) *testType { return &testType{} }),
+		)
+		err := i.Resolve()
+		var ae *AmbiguousProviderError
+		if !errors.As(err, &ae) || !errors.Is(err, ErrAmbiguousProvider) {
+			t.Fatalf("Expected AmbiguousProviderError, got %v", err)
+		}
+		if len(ae.Candidates) != 2 || ae.Candidates[0].Type.String() != "*bytes.Buffer" || ae.Candidates[1].Type.String() != "*strings.Builder" {
+			t.Errorf("Expected the buffer and builder candidates, got %v", ae.Candidates)
+		}
+		if !strings.Contains(ae.Candidates[1].Site.Source, "ambiguous_test.go:") {
+			t.Errorf("Expected the declaration site of the candidate, got %v", ae.Candidates[1].Site)
+		}
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Group("writers", Func(func() *bytes.Buffer { return new(bytes.Buffer) })),
+			Group("writers", Value(&strings.Builder{})),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var b *bytes.Buffer
+		if err := i.InjectGroup("writers", &b); err != nil || b == nil {
+			t.Errorf("Expected the single buffer member, got %v", err)
+		}
+		var w io.Writer
+		err := i.InjectGroup("writers", &w)
+		var ae *AmbiguousProviderError
+		if !errors.As(err, &ae) || ae.Group != "writers" || len(ae.Candidates) != 2 {
+			t.Errorf("Expected AmbiguousProviderError, got %v", err)
+		}
+		var r io.Closer
+		if err := i.InjectGroup("writers", &r); !errors.Is(err, ErrProviderNotFound) {
+			t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+		}
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Named("out", Value(&strings.Builder{})),
+			Named("out", Func(func() *bytes.Buffer { return new(bytes.Buffer) })),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var w io.Writer
+		var aerr *AmbiguousProviderError
+		if err := i.InjectNamed("out", &w); !errors.As(err, &aerr) {
+			t.Fatalf("Expected %v, got %v", ErrAmbiguousProvider, err)
+		}
+		if len(aerr.Candidates) != 2 || aerr.Candidates[0].Type.String() != "*bytes.Buffer" || aerr.Candidates[1].Type.String() != "*strings.Builder" {
+			t.Errorf("Expected candidates sorted by type, got %v", aerr.Candidates)
+		}
+	})
+}
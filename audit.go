@@ -0,0 +1,102 @@
+package wireless
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Injection is the record of the injection request in the audit trail enabled with WithAuditTrail.
+type Injection struct {
+	// Type is the injected type, or the type of the struct injected with Inject.
+	Type reflect.Type
+	// Group or Name is the name of the group or named provider injected with InjectGroup or InjectNamed.
+	Group string
+	Name  string
+	// Caller is the file and line the injection was requested from.
+	Caller string
+	Time   time.Time
+	// Scope is the kind of the scope the injection was requested from.
+	Scope string
+	// Err is the error the injection failed with.
+	Err error
+}
+
+// WithAuditTrail makes the injector record the last size injection requests of the injector and its child scopes,
+// so that it could be found out which code requested the type at runtime, e.g. during the incident.
+// The records are retrieved with Injections.
+func WithAuditTrail(size int) Option {
+	return func(i *Injector) {
+		if size > 0 {
+			i.trail = &auditTrail{records: make([]Injection, 0, size)}
+		}
+	}
+}
+
+// Injections returns the injection requests recorded in the audit trail shared by the injector, its ancestors and
+// descendants, from the oldest to the newest one. It returns nil if the audit trail is not enabled.
+func (i *Injector) Injections() []Injection {
+	if i.trail == nil {
+		return nil
+	}
+	return i.trail.list()
+}
+
+// auditTrail is the ring buffer of the injection records.
+type auditTrail struct {
+	lock    sync.Mutex
+	records []Injection
+	next    int
+}
+
+func (a *auditTrail) add(r Injection) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if len(a.records) < cap(a.records) {
+		a.records = append(a.records, r)
+		return
+	}
+	a.records[a.next] = r
+	a.next = (a.next + 1) % len(a.records)
+}
+
+func (a *auditTrail) list() []Injection {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append(append([]Injection(nil), a.records[a.next:]...), a.records[:a.next]...)
+}
+
+// audit records the injection into the target, which failed with the error the err points to, if any.
+// It is deferred by the injection methods, once the audit trail is enabled.
+func (i *Injector) audit(start time.Time, target interface{}, group, name string, err *error) {
+	t := reflect.TypeOf(target)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	i.trail.add(Injection{Type: t, Group: group, Name: name, Caller: caller(), Time: start, Scope: i.scopeKind(), Err: *err})
+}
+
+// packageDir is the directory of the package sources, which are skipped when looking for the caller.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// caller returns the file and line of the first caller outside of the package sources.
+func caller() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		f, more := frames.Next()
+		if filepath.Dir(f.File) != packageDir || strings.HasSuffix(f.File, "_test.go") {
+			return f.File + ":" + strconv.Itoa(f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
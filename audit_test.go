@@ -0,0 +1,84 @@
+package wireless
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAuditTrail(t *testing.T) {
+	i := New(WithAuditTrail(3))
+	i.Provide(
+		Value(&testType{v: "value"}),
+		Named("other", Value(&testType{v: "other"})),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	t.Run("records", func(t *testing.T) {
+		var v *testType
+		if err := i.InjectAs(&v); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if _, err := i.InjectHandle(&v); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var missing int
+		if err := i.InjectAs(&missing); !errors.Is(err, ErrProviderNotFound) {
+			t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+		}
+		records := i.Injections()
+		if len(records) != 3 {
+			t.Fatalf("Expected %v, got %v", 3, len(records))
+		}
+		for _, r := range records {
+			if !strings.Contains(r.Caller, "audit_test.go:") {
+				t.Errorf("Expected the caller in audit_test.go, got %v", r.Caller)
+			}
+			if r.Scope != ScopeRoot || r.Time.IsZero() {
+				t.Errorf("Expected the root scope record, got %+v", r)
+			}
+		}
+		if records[0].Type != reflect.TypeOf(&testType{}) || records[0].Err != nil {
+			t.Errorf("Expected the testType record, got %+v", records[0])
+		}
+		if records[2].Type != reflect.TypeOf(0) || !errors.Is(records[2].Err, ErrProviderNotFound) {
+			t.Errorf("Expected the failed int record, got %+v", records[2])
+		}
+	})
+
+	t.Run("ring buffer", func(t *testing.T) {
+		var v *testType
+		if err := i.InjectNamed("other", &v); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		c := i.NewScope(ScopeRequest)
+		if err := c.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err := c.InjectAs(&v); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		records := i.Injections()
+		if len(records) != 3 {
+			t.Fatalf("Expected %v, got %v", 3, len(records))
+		}
+		if named := records[1]; named.Name != "other" || named.Scope != ScopeRoot {
+			t.Errorf("Expected the named record of the root scope, got %+v", named)
+		}
+		if last := records[2]; last.Scope != ScopeRequest {
+			t.Errorf("Expected the record of the request scope, got %+v", last)
+		}
+		if records[0].Type != reflect.TypeOf(0) {
+			t.Errorf("Expected the oldest record dropped, got %+v", records)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if records := New().Injections(); records != nil {
+			t.Errorf("Expected nil, got %v", records)
+		}
+	})
+}
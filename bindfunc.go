@@ -0,0 +1,121 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BindFunc provides the interface type binding, with the implementation type chosen by the selector function
+// when the interface is injected for the first time. The arguments of the selector function are injected from
+// the other providers, and it returns the implementation type, declared either with the `new` statement or as
+// the reflect.Type, optionally along with an error. The implementation is then injected from its own provider.
+// Example:
+//
+//	wireless.BindFunc(new(Store), func(cfg *Config) interface{} {
+//		if cfg.Backend == "redis" {
+//			return new(*RedisStore)
+//		}
+//		return new(*PostgresStore)
+//	})
+func BindFunc(iface interface{}, selector interface{}) Provider {
+	return &bindFuncProvider{iface: iface, selector: selector, providerOptions: declared()}
+}
+
+type bindFuncProvider struct {
+	iface    interface{}
+	selector interface{}
+	providerOptions
+}
+
+func (b *bindFuncProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&b.providerOptions)
+	}
+}
+
+// resolveBindFuncs registers the provider functions of the interface types bound by the selector functions.
+func (i *Injector) resolveBindFuncs() {
+	for _, bp := range i.bindFuncProviders {
+		it, err := bindingType(bp.iface)
+		if err != nil {
+			i.errors = append(i.errors, fmt.Errorf("binding function interface %w", err))
+			continue
+		}
+		if it.Kind() != reflect.Interface && it.Kind() != reflect.Func {
+			i.errors = append(i.errors, fmt.Errorf("binding function interface: %s is neither an interface nor a function type", it))
+			continue
+		}
+		sel, err := newProviderFunc(bp.selector)
+		if err != nil {
+			i.errors = append(i.errors, fmt.Errorf("binding function of the interface: %s %w", it, err))
+			continue
+		}
+		if sel.cleanupOut > 0 {
+			i.errors = append(i.errors, fmt.Errorf("binding function: %s of the interface: %s could not return a cleanup function", sel.name(), it))
+			continue
+		}
+		register, err := i.conflict(it, bp.providerOptions, ProviderSite{Provider: sel.name(), Signature: sel.value.Type().String()})
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if !register {
+			continue
+		}
+		pf := &providerFunc{
+			id:         i.nextID(),
+			inTypes:    sel.inTypes,
+			out:        it,
+			errOut:     1,
+			cleanupOut: -1,
+		}
+		pf.value = reflect.MakeFunc(reflect.FuncOf(sel.inTypes, []reflect.Type{it, errorType}, false), i.selectBinding(pf, sel))
+		i.providersMap[it] = pf
+	}
+}
+
+// selectBinding returns the function injecting the implementation of the interface type chosen by the selector.
+// The provider of the implementation is recorded as the selected dependency of the binding, so that the binding
+// and its dependents are cleaned before it.
+func (i *Injector) selectBinding(p *providerFunc, sel *providerFunc) func([]reflect.Value) []reflect.Value {
+	it := p.out
+	fail := func(err error) []reflect.Value {
+		return []reflect.Value{reflect.Zero(it), reflect.ValueOf(&err).Elem()}
+	}
+	return func(args []reflect.Value) []reflect.Value {
+		out, _, err := sel.call(args)
+		if err != nil {
+			return fail(err)
+		}
+		var impl interface{}
+		if out.IsValid() && out.CanInterface() {
+			impl = out.Interface()
+		}
+		to, err := bindingType(impl)
+		if err != nil {
+			return fail(fmt.Errorf("binding function: %s of the interface: %s returned %w", sel.name(), it, err))
+		}
+		if err = checkBindable(it, to); err != nil {
+			return fail(fmt.Errorf("binding function: %s returned the %w", sel.name(), err))
+		}
+		rv := reflect.New(to)
+		if err = i.injectAs(i.context(), rv); err != nil {
+			return fail(fmt.Errorf("binding function: %s selected type: %s %w", sel.name(), to, err))
+		}
+		if impl := i.boundProvider(to); impl != nil {
+			p.selected.Store(impl)
+		}
+		return []reflect.Value{rv.Elem().Convert(it), reflect.Zero(errorType)}
+	}
+}
+
+// boundProvider returns the provider function of the type, or of the type it is bound to, if any.
+func (i *Injector) boundProvider(t reflect.Type) *providerFunc {
+	if pf, ok := i.providersMap[t]; ok {
+		return pf
+	}
+	if bt, ok := i.bindings[t]; ok {
+		return i.providersMap[bt]
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package wireless
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type bindFuncConfig struct {
+	Backend string
+}
+
+func TestBindFunc(t *testing.T) {
+	selector := func(c *bindFuncConfig) interface{} {
+		switch c.Backend {
+		case "strings":
+			return new(*strings.Reader)
+		case "type":
+			return reflect.TypeOf(new(strings.Reader))
+		case "invalid":
+			return new(*testType)
+		}
+		return nil
+	}
+	for _, c := range []struct {
+		backend string
+		err     string
+	}{
+		{backend: "strings"},
+		{backend: "type"},
+		{backend: "invalid", err: "does not implement interface type"},
+		{backend: "none", err: "type is nil"},
+	} {
+		t.Run(c.backend, func(t *testing.T) {
+			i := New()
+			i.Provide(
+				Value(&bindFuncConfig{Backend: c.backend}),
+				Value(strings.NewReader("text")),
+				Value(&testType{}),
+				BindFunc(new(io.Reader), selector),
+			)
+			if err := i.Resolve(); err != nil {
+				t.Fatal("Expected no error, got", err)
+			}
+			var r io.Reader
+			err := i.InjectAs(&r)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("Expected %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected no error, got", err)
+			}
+			if b, _ := io.ReadAll(r); string(b) != "text" {
+				t.Errorf("Expected %v, got %v", "text", string(b))
+			}
+		})
+	}
+}
+
+type bindFuncStore struct{ io.Reader }
+
+type bindFuncConsumer struct{ r io.Reader }
+
+func TestBindFuncCleanupOrder(t *testing.T) {
+	var cleaned []string
+	i := New()
+	i.Provide(
+		Value(&bindFuncConfig{}),
+		Func(func(r io.Reader) (*bindFuncConsumer, func()) {
+			return &bindFuncConsumer{r: r}, func() { cleaned = append(cleaned, "consumer") }
+		}),
+		Func(func(c *bindFuncConfig) (*strings.Reader, func()) {
+			return strings.NewReader("text"), func() { cleaned = append(cleaned, "reader") }
+		}),
+		Func(func(r *strings.Reader) (*bindFuncStore, func()) {
+			return &bindFuncStore{Reader: r}, func() { cleaned = append(cleaned, "store") }
+		}),
+		BindFunc(new(io.Reader), func() interface{} { return new(*bindFuncStore) }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var c *bindFuncConsumer
+	if err := i.InjectAs(&c); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	i.Clean()
+	if strings.Join(cleaned, ",") != "consumer,store,reader" {
+		t.Errorf("Expected %v, got %v", "consumer,store,reader", cleaned)
+	}
+}
@@ -0,0 +1,75 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by the errors returned when the construction of the value is skipped by the open
+// circuit breaker of its provider.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitBreaker caches the construction error of the provider function once it fails the threshold number of times
+// in a row. The subsequent injections fail fast with the cached error, matching ErrCircuitOpen, until the cooldown
+// duration elapses. Then the provider function is called again, and a single failure opens the circuit again.
+// Example:
+//
+//	wireless.CircuitBreaker(3, 30*time.Second, wireless.Func(DialPaymentService))
+func CircuitBreaker(threshold int, cooldown time.Duration, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) {
+		o.breaker = &breakerPolicy{threshold: threshold, cooldown: cooldown}
+	})
+	return p
+}
+
+// breakerPolicy is the circuit breaker policy of the provider function.
+type breakerPolicy struct {
+	threshold int
+	cooldown  time.Duration
+}
+
+// breaker is the circuit breaker state of the provider function.
+type breaker struct {
+	breakerPolicy
+	lock      sync.Mutex
+	failures  int
+	err       error
+	openUntil time.Time
+}
+
+// newBreaker returns the circuit breaker of the provider, or nil.
+func newBreaker(o providerOptions) *breaker {
+	if o.breaker == nil {
+		return nil
+	}
+	return &breaker{breakerPolicy: *o.breaker}
+}
+
+// allow returns the cached error if the circuit is open.
+func (b *breaker) allow() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.err != nil && time.Now().Before(b.openUntil) {
+		return fmt.Errorf("%w until %s: %w", ErrCircuitOpen, b.openUntil.Format(time.RFC3339), b.err)
+	}
+	return nil
+}
+
+// record records the result of the construction and reports whether it opened the circuit.
+func (b *breaker) record(err error) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err == nil {
+		b.failures, b.err = 0, nil
+		return false
+	}
+	b.failures++
+	if b.failures < b.threshold && b.err == nil {
+		return false
+	}
+	b.err = err
+	b.openUntil = time.Now().Add(b.cooldown)
+	return true
+}
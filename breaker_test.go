@@ -0,0 +1,60 @@
+package wireless
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errDial := errors.New("dial failed")
+	var calls int
+	fail := true
+	var opened int
+	i := New(WithEventHandler(func(e Event) {
+		if e.Kind == EventCircuitOpened {
+			opened++
+		}
+	}))
+	i.Provide(CircuitBreaker(2, 20*time.Millisecond, Func(func() (*testType, error) {
+		calls++
+		if fail {
+			return nil, errDial
+		}
+		return &testType{v: "connected"}, nil
+	})))
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var tt *testType
+	for j := 0; j < 2; j++ {
+		if err := i.InjectAs(&tt); !errors.Is(err, errDial) || errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("Expected %v, got %v", errDial, err)
+		}
+	}
+	err := i.InjectAs(&tt)
+	if !errors.Is(err, ErrCircuitOpen) || !errors.Is(err, errDial) {
+		t.Errorf("Expected %v, got %v", ErrCircuitOpen, err)
+	}
+	if calls != 2 || opened != 1 {
+		t.Errorf("Expected %v calls and %v opening, got %v and %v", 2, 1, calls, opened)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err = i.InjectAs(&tt); !errors.Is(err, errDial) || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected %v, got %v", errDial, err)
+	}
+	if err = i.InjectAs(&tt); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected %v, got %v", ErrCircuitOpen, err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	fail = false
+	if err = i.InjectAs(&tt); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if calls != 4 {
+		t.Errorf("Expected %v, got %v", 4, calls)
+	}
+}
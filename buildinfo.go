@@ -0,0 +1,75 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// Build information overrides set with the linker flags, e.g.
+//
+//	go build -ldflags "-X github.com/routercore/wireless.buildVersion=v1.2.3 \
+//		-X github.com/routercore/wireless.buildTime=2024-01-02T15:04:05Z"
+var (
+	buildVersion  string
+	buildRevision string
+	buildTime     string
+)
+
+// BuildInfo describes the build of the running binary.
+type BuildInfo struct {
+	// Path is the main module path.
+	Path string
+	// Version is the main module version, or the 'buildVersion' linker flag override.
+	Version string
+	// Revision is the VCS revision, or the 'buildRevision' linker flag override.
+	Revision string
+	// Time is the VCS commit time, or the 'buildTime' linker flag override in the RFC 3339 format.
+	Time time.Time
+	// Modified reports whether the VCS working tree had local modifications.
+	Modified bool
+	// GoVersion is the version of the Go toolchain that built the binary.
+	GoVersion string
+}
+
+// ReadBuildInfo reads the build information embedded in the running binary, overridden with the linker flags.
+func ReadBuildInfo() (*BuildInfo, error) {
+	bi := &BuildInfo{}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		bi.Path = info.Main.Path
+		bi.Version = info.Main.Version
+		bi.GoVersion = info.GoVersion
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				bi.Revision = s.Value
+			case "vcs.time":
+				bi.Time, _ = time.Parse(time.RFC3339, s.Value)
+			case "vcs.modified":
+				bi.Modified = s.Value == "true"
+			}
+		}
+	} else if buildVersion == "" {
+		return nil, errors.New("build information is not available")
+	}
+	if buildVersion != "" {
+		bi.Version = buildVersion
+	}
+	if buildRevision != "" {
+		bi.Revision = buildRevision
+	}
+	if buildTime != "" {
+		t, err := time.Parse(time.RFC3339, buildTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid build time: %s: %w", buildTime, err)
+		}
+		bi.Time = t
+	}
+	return bi, nil
+}
+
+// BuildInfoModule declares the provider of the *BuildInfo read with ReadBuildInfo.
+func BuildInfoModule() ProviderSet {
+	return NewSet(Func(ReadBuildInfo))
+}
@@ -0,0 +1,30 @@
+package wireless
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildInfo(t *testing.T) {
+	buildVersion, buildTime = "v1.2.3", "2024-01-02T15:04:05Z"
+	defer func() { buildVersion, buildTime = "", "" }()
+
+	i := New()
+	i.Provide(BuildInfoModule())
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var bi *BuildInfo
+	if err := i.InjectAs(&bi); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if bi.Version != "v1.2.3" {
+		t.Errorf("Expected %v, got %v", "v1.2.3", bi.Version)
+	}
+	if expected := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC); !bi.Time.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, bi.Time)
+	}
+	if bi.GoVersion == "" {
+		t.Error("Expected go version, got empty")
+	}
+}
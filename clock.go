@@ -0,0 +1,185 @@
+package wireless
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of the current time and the timers, injected into the time dependent components instead of
+// calling the time package directly, so that the tests might control the time with the FakeClock.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	// NewTicker returns the Ticker sending the current time on its channel with the period of the duration.
+	// It panics if the duration is not positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers the ticks of the Clock, dropping them for the slow receivers.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the Clock of the time package.
+var SystemClock Clock = systemClock{}
+
+// Time is the provider set of the SystemClock injected as the Clock, unless the Clock is provided otherwise.
+// The tests replace it with the FakeTime.
+// Example:
+//
+//	wireless.NewSet(wireless.Time, wireless.Func(NewSessionStore))
+var Time = NewSet(IfNotExists(InterfaceValue(new(Clock), SystemClock)))
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (systemClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (systemClock) NewTicker(d time.Duration) Ticker       { return systemTicker{t: time.NewTicker(d)} }
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (s systemTicker) C() <-chan time.Time { return s.t.C }
+func (s systemTicker) Stop()               { s.t.Stop() }
+
+// FakeTime overrides the Clock with the fake one, which is also provided as the *FakeClock, so that the tests
+// might advance it.
+// Example:
+//
+//	i.Provide(app.Providers, wireless.FakeTime(wireless.NewFakeClock(time.Time{})))
+func FakeTime(c *FakeClock) Provider {
+	return NewSet(Override(InterfaceValue(new(Clock), c)), Value(c))
+}
+
+// FakeClock is the Clock whose time moves only when it is advanced. The timers and tickers fire, in the order of
+// their deadlines, when the clock is advanced past them.
+type FakeClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	at     time.Time
+	period time.Duration
+	c      chan time.Time
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+// NewFakeClock returns the FakeClock set to the time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// Since returns the time elapsed since t according to the clock.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After returns the channel receiving the time of the clock once it is advanced by the duration.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.wait(d, 0).c
+}
+
+// Sleep blocks until the clock is advanced by the duration.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// NewTicker returns the Ticker firing every time the clock is advanced by the duration.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return &fakeTicker{clock: c, waiter: c.wait(d, d)}
+}
+
+// Advance moves the clock forward by the duration, firing the timers and tickers due until then.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	end := c.now.Add(d)
+	for len(c.waiters) > 0 && !c.waiters[0].at.After(end) {
+		w := c.waiters[0]
+		c.now = w.at
+		select {
+		case w.c <- c.now:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+			c.sort()
+		} else {
+			c.waiters = c.waiters[1:]
+		}
+	}
+	if end.After(c.now) {
+		c.now = end
+	}
+}
+
+// Set moves the clock forward to the time, same as Advance. The earlier time is ignored.
+func (c *FakeClock) Set(t time.Time) {
+	c.Advance(t.Sub(c.Now()))
+}
+
+// Waiters returns the number of the pending timers, sleeps and tickers, which lets the tests wait until
+// the components are blocked on the clock before advancing it.
+func (c *FakeClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.waiters)
+}
+
+func (c *FakeClock) wait(d, period time.Duration) *fakeWaiter {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	w := &fakeWaiter{at: c.now.Add(d), period: period, c: make(chan time.Time, 1)}
+	if d <= 0 && period == 0 {
+		w.c <- c.now
+		return w
+	}
+	c.waiters = append(c.waiters, w)
+	c.sort()
+	return w
+}
+
+func (c *FakeClock) sort() {
+	sort.SliceStable(c.waiters, func(j, k int) bool {
+		return c.waiters[j].at.Before(c.waiters[k].at)
+	})
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	for j, w := range t.clock.waiters {
+		if w == t.waiter {
+			t.clock.waiters = append(t.clock.waiters[:j], t.clock.waiters[j+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package wireless
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	t.Run("system", func(t *testing.T) {
+		i := New()
+		i.Provide(Time)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var c Clock
+		if err := i.InjectAs(&c); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if c != SystemClock {
+			t.Errorf("Expected %v, got %v", SystemClock, c)
+		}
+	})
+
+	t.Run("fake", func(t *testing.T) {
+		fc := NewFakeClock(time.Unix(0, 0))
+		i := New()
+		i.Provide(FakeTime(fc), Time)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var c Clock
+		if err := i.InjectAs(&c); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var injected *FakeClock
+		if err := i.InjectAs(&injected); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if c != Clock(fc) || injected != fc {
+			t.Errorf("Expected %v, got %v", fc, c)
+		}
+	})
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(0, 0)
+
+	t.Run("after", func(t *testing.T) {
+		c := NewFakeClock(start)
+		after := c.After(time.Second)
+		c.Advance(time.Second - 1)
+		select {
+		case <-after:
+			t.Fatal("Expected the timer not to fire yet")
+		default:
+		}
+		c.Advance(1)
+		if now := <-after; !now.Equal(start.Add(time.Second)) {
+			t.Errorf("Expected %v, got %v", start.Add(time.Second), now)
+		}
+		if c.Waiters() != 0 {
+			t.Errorf("Expected %v, got %v", 0, c.Waiters())
+		}
+		if c.Since(start) != time.Second {
+			t.Errorf("Expected %v, got %v", time.Second, c.Since(start))
+		}
+	})
+
+	t.Run("sleep", func(t *testing.T) {
+		c := NewFakeClock(start)
+		done := make(chan struct{})
+		go func() {
+			c.Sleep(time.Minute)
+			close(done)
+		}()
+		for c.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		c.Set(start.Add(time.Hour))
+		<-done
+		if !c.Now().Equal(start.Add(time.Hour)) {
+			t.Errorf("Expected %v, got %v", start.Add(time.Hour), c.Now())
+		}
+	})
+
+	t.Run("ticker", func(t *testing.T) {
+		c := NewFakeClock(start)
+		tk := c.NewTicker(time.Second)
+		c.Advance(time.Second)
+		if now := <-tk.C(); !now.Equal(start.Add(time.Second)) {
+			t.Errorf("Expected %v, got %v", start.Add(time.Second), now)
+		}
+		// The ticks are dropped for the slow receivers.
+		c.Advance(3 * time.Second)
+		if now := <-tk.C(); !now.Equal(start.Add(2 * time.Second)) {
+			t.Errorf("Expected %v, got %v", start.Add(2*time.Second), now)
+		}
+		tk.Stop()
+		c.Advance(time.Second)
+		select {
+		case <-tk.C():
+			t.Error("Expected no tick of the stopped ticker")
+		default:
+		}
+	})
+}
@@ -0,0 +1,105 @@
+// Package cobrawireless provides github.com/spf13/cobra adapters for the commands with dependencies injected
+// by wireless.Injector.
+package cobrawireless
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/routercore/wireless"
+	"github.com/spf13/cobra"
+)
+
+// CommandScope is the kind of the child scope created for each command run.
+const CommandScope = "command"
+
+// CommandsGroup is the name of the group of *cobra.Command members added as the subcommands by AddCommands.
+const CommandsGroup = "cobrawireless.commands"
+
+var (
+	commandType = reflect.TypeOf(new(cobra.Command))
+	argsType    = reflect.TypeOf([]string(nil))
+	errorType   = reflect.TypeOf(new(error)).Elem()
+)
+
+// RunE creates the cobra RunE function from the function taking its dependencies followed by the *cobra.Command
+// and []string arguments. Each run of the command creates the child scope of the CommandScope kind, with
+// the command and the input providers, from which the dependencies are injected. The scope is resolved with
+// the command context, which is also cancelled along with the lifecycle context of the injector. The lifecycle
+// hooks appended by the dependencies are started before the function is called, and the scope is stopped
+// and cleaned after the function returns.
+// Example:
+//
+//	cmd := &cobra.Command{Use: "migrate"}
+//	cmd.RunE = cobrawireless.RunE(i, func(db *sql.DB, cmd *cobra.Command, args []string) error { ... })
+func RunE(i *wireless.Injector, fn interface{}, providers ...wireless.Provider) (func(cmd *cobra.Command, args []string) error, error) {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func {
+		return nil, fmt.Errorf("command %T is not a function", fn)
+	}
+	rt := rv.Type()
+	n := rt.NumIn()
+	if n < 2 || rt.In(n-2) != commandType || rt.In(n-1) != argsType {
+		return nil, fmt.Errorf("command %T last arguments are expected to be *cobra.Command and []string", fn)
+	}
+	if rt.NumOut() > 1 || rt.NumOut() == 1 && rt.Out(0) != errorType {
+		return nil, fmt.Errorf("command %T might only return an error", fn)
+	}
+
+	return func(cmd *cobra.Command, args []string) (err error) {
+		s := i.NewScope(CommandScope)
+		s.Provide(wireless.Value(cmd))
+		s.Provide(providers...)
+		if err := s.ResolveContext(cmd.Context()); err != nil {
+			_ = s.Close()
+			return err
+		}
+		defer func() {
+			if cerr := s.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}()
+
+		in := make([]reflect.Value, n)
+		for j := 0; j < n-2; j++ {
+			dep := reflect.New(rt.In(j))
+			if err := s.InjectAs(dep.Interface()); err != nil {
+				return fmt.Errorf("command %T dependency: %w", fn, err)
+			}
+			in[j] = dep.Elem()
+		}
+		if err := s.Start(cmd.Context()); err != nil {
+			return fmt.Errorf("command %T scope: %w", fn, err)
+		}
+		in[n-2], in[n-1] = reflect.ValueOf(cmd), reflect.ValueOf(args)
+		outs := rv.Call(in)
+		if len(outs) == 1 && !outs[0].IsNil() {
+			return outs[0].Interface().(error)
+		}
+		return nil
+	}, nil
+}
+
+// MustRunE is like RunE but panics if the function could not be created.
+func MustRunE(i *wireless.Injector, fn interface{}, providers ...wireless.Provider) func(cmd *cobra.Command, args []string) error {
+	f, err := RunE(i, fn, providers...)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// AddCommands adds all the members of the CommandsGroup as the subcommands of the root command.
+// Example:
+//
+//	i.Provide(wireless.Group(cobrawireless.CommandsGroup, wireless.Func(NewMigrateCommand)))
+//	...
+//	if err := cobrawireless.AddCommands(i, rootCmd); err != nil { ... }
+func AddCommands(i *wireless.Injector, root *cobra.Command) error {
+	var cmds []*cobra.Command
+	if err := i.InjectGroup(CommandsGroup, &cmds); err != nil {
+		return err
+	}
+	root.AddCommand(cmds...)
+	return nil
+}
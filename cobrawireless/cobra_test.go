@@ -0,0 +1,84 @@
+package cobrawireless
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/routercore/wireless"
+	"github.com/spf13/cobra"
+)
+
+type greeter struct {
+	greeting string
+}
+
+type target struct {
+	name string
+}
+
+func TestCommands(t *testing.T) {
+	var out string
+	i := wireless.New()
+	i.Provide(
+		wireless.Value(&greeter{greeting: "hello"}),
+		wireless.Group(CommandsGroup, wireless.Func(func(i *wireless.Injector) (*cobra.Command, error) {
+			cmd := &cobra.Command{Use: "greet"}
+			run, err := RunE(i, func(g *greeter, tg *target, cmd *cobra.Command, args []string) error {
+				out = g.greeting + " " + tg.name + " " + args[0]
+				return nil
+			}, wireless.Func(func(cmd *cobra.Command) *target {
+				return &target{name: cmd.Name()}
+			}))
+			cmd.RunE = run
+			return cmd, err
+		})),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	root := &cobra.Command{Use: "app"}
+	if err := AddCommands(i, root); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	root.SetArgs([]string{"greet", "world"})
+	if err := root.Execute(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if out != "hello greet world" {
+		t.Errorf("Expected %v, got %v", "hello greet world", out)
+	}
+}
+
+func TestCommandLifecycle(t *testing.T) {
+	var calls []string
+	i := wireless.New()
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	cmd := &cobra.Command{Use: "serve"}
+	cmd.RunE = MustRunE(i, func(tg *target, cmd *cobra.Command, args []string) error {
+		calls = append(calls, "run")
+		return nil
+	}, wireless.Func(func(lc *wireless.Lifecycle) *target {
+		lc.Append(wireless.Hook{
+			OnStart: func(context.Context) error {
+				calls = append(calls, "start")
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				calls = append(calls, "stop")
+				return nil
+			},
+		})
+		return &target{}
+	}))
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if expected := []string{"start", "run", "stop"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, calls)
+	}
+}
@@ -0,0 +1,254 @@
+package wireless
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ConfigFormat is the format of the configuration file.
+type ConfigFormat string
+
+// Supported configuration file formats. The JSON format is supported out of the box, while the YAML and TOML
+// formats are registered by the blank import of the github.com/routercore/wireless/configwireless package.
+const (
+	// FormatAuto detects the format from the file extension.
+	FormatAuto ConfigFormat = ""
+	FormatJSON ConfigFormat = "json"
+	FormatYAML ConfigFormat = "yaml"
+	FormatTOML ConfigFormat = "toml"
+)
+
+// ConfigCodec decodes and encodes the configuration files of the format.
+type ConfigCodec interface {
+	// Decode decodes the data into v, failing on the keys unknown to v.
+	Decode(data []byte, v interface{}) error
+	// Encode encodes v into the data.
+	Encode(v interface{}) ([]byte, error)
+}
+
+// configFormats are the codecs of the configuration file formats registered with RegisterConfigFormat.
+var configFormats = struct {
+	lock       sync.RWMutex
+	codecs     map[ConfigFormat]ConfigCodec
+	extensions map[string]ConfigFormat
+}{
+	codecs:     map[ConfigFormat]ConfigCodec{FormatJSON: jsonCodec{}},
+	extensions: map[string]ConfigFormat{".json": FormatJSON},
+}
+
+// RegisterConfigFormat registers the codec of the configuration file format, detected from the listed file
+// extensions by FormatAuto. It is meant to be called from the package init function, same as Register.
+// Example:
+//
+//	func init() {
+//		wireless.RegisterConfigFormat(wireless.FormatYAML, yamlCodec{}, ".yaml", ".yml")
+//	}
+func RegisterConfigFormat(format ConfigFormat, codec ConfigCodec, extensions ...string) {
+	configFormats.lock.Lock()
+	defer configFormats.lock.Unlock()
+	configFormats.codecs[format] = codec
+	for _, ext := range extensions {
+		configFormats.extensions[strings.ToLower(ext)] = format
+	}
+}
+
+// configCodec returns the codec of the format, detected from the path extension if the format is FormatAuto.
+func configCodec(path string, format ConfigFormat) (ConfigCodec, error) {
+	configFormats.lock.RLock()
+	defer configFormats.lock.RUnlock()
+	if format == FormatAuto {
+		f, ok := configFormats.extensions[strings.ToLower(filepath.Ext(path))]
+		if !ok {
+			return nil, fmt.Errorf("config file format could not be detected from the path: %s", path)
+		}
+		format = f
+	}
+	codec, ok := configFormats.codecs[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported config file format: %s, import github.com/routercore/wireless/configwireless to register it", format)
+	}
+	return codec, nil
+}
+
+// jsonCodec is the ConfigCodec of the JSON format.
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+func (jsonCodec) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// includeKey is the top level key of the config file listing the included files.
+const includeKey = "include"
+
+// ConfigFile declares a provider of the *T config struct parsed from the file at given path. The file might list
+// the paths of other files, relative to its directory, under the top level 'include' key. The included files are
+// parsed first, in order, and might include other files themselves, so that the file overrides the values they
+// define. The overlay files are parsed in order over the same struct, so that they override the values defined
+// by the base file, e.g. with the environment specific settings. Missing overlay files are skipped. Sections
+// of the config might be registered as separate providers with FieldsOf.
+// Example:
+//
+//	import _ "github.com/routercore/wireless/configwireless"
+//
+//	wireless.NewSet(
+//		wireless.ConfigFile[Config]("config.yaml", wireless.FormatAuto, "config.local.yaml"),
+//		wireless.FieldsOf(new(*Config), "DB", "HTTP"),
+//	)
+func ConfigFile[T any](path string, format ConfigFormat, overlays ...string) Provider {
+	return Func(func() (*T, error) {
+		v := new(T)
+		if err := decodeConfigFile(path, format, v, nil); err != nil {
+			return nil, err
+		}
+		for _, o := range overlays {
+			if _, err := os.Stat(o); os.IsNotExist(err) {
+				continue
+			}
+			if err := decodeConfigFile(o, format, v, nil); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	})
+}
+
+// decodeConfigFile decodes the config file into v, after the files it includes. The including are the paths
+// of the files including the decoded one, used to detect the include cycles.
+func decodeConfigFile(path string, format ConfigFormat, v interface{}, including []string) error {
+	for _, p := range including {
+		if p == path {
+			return fmt.Errorf("config file: %s includes itself through: %s", path, strings.Join(including, " -> "))
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file failed: %w", err)
+	}
+	codec, err := configCodec(path, format)
+	if err != nil {
+		return err
+	}
+	data, includes, err := configIncludes(codec, data)
+	if err != nil {
+		return fmt.Errorf("reading includes of the config file: %s failed: %w", path, err)
+	}
+	for _, inc := range includes {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		if err := decodeConfigFile(inc, format, v, append(including, path)); err != nil {
+			return err
+		}
+	}
+	if err = codec.Decode(data, v); err != nil {
+		return fmt.Errorf("decoding config file: %s failed: %w", path, err)
+	}
+	return nil
+}
+
+// configIncludes returns the paths listed under the include key of the config file data, along with the data
+// without that key, so that it is not decoded into the config struct.
+func configIncludes(codec ConfigCodec, data []byte) ([]byte, []string, error) {
+	var doc map[string]interface{}
+	if err := codec.Decode(data, &doc); err != nil {
+		// The data which is not a document is left to be reported by its decoding into the config struct.
+		return data, nil, nil
+	}
+	inc, ok := doc[includeKey]
+	if !ok {
+		return data, nil, nil
+	}
+	list, ok := inc.([]interface{})
+	if !ok {
+		return nil, nil, errors.New("include is not a list of paths")
+	}
+	includes := make([]string, 0, len(list))
+	for _, p := range list {
+		s, ok := p.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("include: %v is not a path", p)
+		}
+		includes = append(includes, s)
+	}
+	delete(doc, includeKey)
+	data, err := codec.Encode(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, includes, nil
+}
+
+// FieldsOf declares the providers of the listed fields of the struct, or pointer to struct, type defined with
+// the `new` statement. The field providers depend on the provider of the struct itself.
+// Example:
+//
+//	wireless.FieldsOf(new(*Config), "DB", "HTTP")
+func FieldsOf(structType interface{}, fields ...string) Provider {
+	return &fieldsProvider{structType: structType, fields: fields}
+}
+
+type fieldsProvider struct {
+	structType interface{}
+	fields     []string
+	providerOptions
+}
+
+func (f *fieldsProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&f.providerOptions)
+	}
+}
+
+func (i *Injector) resolveFields() {
+	for _, fp := range i.fieldsProviders {
+		st := reflect.TypeOf(fp.structType)
+		if st == nil || st.Kind() != reflect.Ptr || !isStructOrPtr(st.Elem()) {
+			i.errors = append(i.errors, fmt.Errorf("fields provider type is not a pointer to struct or pointer to pointer to struct: %T", fp.structType))
+			continue
+		}
+		st = st.Elem()
+		et := st
+		if et.Kind() == reflect.Ptr {
+			et = et.Elem()
+		}
+		for _, name := range fp.fields {
+			sf, ok := et.FieldByName(name)
+			if !ok || !sf.IsExported() {
+				i.errors = append(i.errors, fmt.Errorf("struct: %s has no exported field: %s", et, name))
+				continue
+			}
+			if i.hasProvider(sf.Type) {
+				if fp.ifNotExists {
+					i.recordDecision("skipped", sf.Type, fmt.Sprintf("field: %s of %s", name, et))
+					continue
+				}
+				i.errors = append(i.errors, fmt.Errorf("provider already registered for type: %s", sf.Type))
+				continue
+			}
+			index := sf.Index
+			i.syntheticProviderFunc(sf.Type, []reflect.Type{st}, func(in []reflect.Value) []reflect.Value {
+				sv := in[0]
+				if sv.Kind() == reflect.Ptr {
+					if sv.IsNil() {
+						return []reflect.Value{reflect.Zero(sf.Type)}
+					}
+					sv = sv.Elem()
+				}
+				return []reflect.Value{sv.FieldByIndex(index)}
+			})
+		}
+	}
+}
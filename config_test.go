@@ -0,0 +1,121 @@
+package wireless
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type dbConfig struct {
+	DSN string `json:"dsn" yaml:"dsn" toml:"dsn"`
+}
+
+type fileConfig struct {
+	Name string   `json:"name" yaml:"name" toml:"name"`
+	DB   dbConfig `json:"db" yaml:"db" toml:"db"`
+}
+
+func TestConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := write("config.json", `{"name": "json", "db": {"dsn": "base"}}`)
+	overlay := write("overlay.json", `{"db": {"dsn": "overlay"}}`)
+
+	load := func(t *testing.T, path string, overlays ...string) (*fileConfig, error) {
+		i := New()
+		i.Provide(ConfigFile[fileConfig](path, FormatAuto, overlays...))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var cfg *fileConfig
+		err := i.InjectAs(&cfg)
+		return cfg, err
+	}
+
+	t.Run("json", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			ConfigFile[fileConfig](base, FormatAuto),
+			FieldsOf(new(*fileConfig), "DB"),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var s struct {
+			Config *fileConfig
+			DB     dbConfig
+		}
+		err = i.Inject(&s)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if s.Config.Name != "json" || s.DB.DSN != "base" {
+			t.Errorf("Expected config loaded, got %+v", s)
+		}
+	})
+
+	t.Run("Overlay", func(t *testing.T) {
+		cfg, err := load(t, base, overlay, filepath.Join(dir, "missing.json"))
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if cfg.Name != "json" || cfg.DB.DSN != "overlay" {
+			t.Errorf("Expected overlay applied, got %+v", cfg)
+		}
+	})
+
+	t.Run("Include", func(t *testing.T) {
+		if err := os.Mkdir(filepath.Join(dir, "conf.d"), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		write("conf.d/db.json", `{"include": ["../config.json"], "db": {"dsn": "included"}}`)
+		path := write("app.json", `{"include": ["conf.d/db.json"], "name": "app"}`)
+		cfg, err := load(t, path)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if cfg.Name != "app" || cfg.DB.DSN != "included" {
+			t.Errorf("Expected includes applied in order, got %+v", cfg)
+		}
+	})
+
+	t.Run("Include cycle", func(t *testing.T) {
+		write("a.json", `{"include": ["b.json"]}`)
+		path := write("b.json", `{"include": ["a.json"]}`)
+		if _, err := load(t, path); err == nil || !strings.Contains(err.Error(), "includes itself") {
+			t.Errorf("Expected the include cycle error, got %v", err)
+		}
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		path := write("unknown.json", `{"name": "json", "port": 80}`)
+		if _, err := load(t, path); err == nil {
+			t.Error("Expected error of the unknown field, got nil")
+		}
+	})
+
+	t.Run("Unregistered format", func(t *testing.T) {
+		path := write("config.ini", "name = ini\n")
+		if _, err := load(t, path); err == nil || !strings.Contains(err.Error(), "could not be detected") {
+			t.Errorf("Expected the format detection error, got %v", err)
+		}
+		i := New()
+		i.Provide(ConfigFile[fileConfig](path, ConfigFormat("ini")))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var cfg *fileConfig
+		if err := i.InjectAs(&cfg); err == nil || !strings.Contains(err.Error(), "configwireless") {
+			t.Errorf("Expected the unsupported format error, got %v", err)
+		}
+	})
+}
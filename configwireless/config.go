@@ -0,0 +1,68 @@
+// Package configwireless registers the YAML and TOML formats of the wireless.ConfigFile, backed by
+// the gopkg.in/yaml.v3 and github.com/BurntSushi/toml packages, so that the root module does not depend on them.
+// The formats are registered by the blank import of the package.
+// Example:
+//
+//	import _ "github.com/routercore/wireless/configwireless"
+//
+//	wireless.ConfigFile[Config]("config.yaml", wireless.FormatAuto)
+package configwireless
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/routercore/wireless"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	wireless.RegisterConfigFormat(wireless.FormatYAML, YAML, ".yaml", ".yml")
+	wireless.RegisterConfigFormat(wireless.FormatTOML, TOML, ".toml")
+}
+
+var (
+	// YAML is the wireless.ConfigCodec of the YAML format.
+	YAML wireless.ConfigCodec = yamlCodec{}
+	// TOML is the wireless.ConfigCodec of the TOML format.
+	TOML wireless.ConfigCodec = tomlCodec{}
+)
+
+type yamlCodec struct{}
+
+// Decode implements wireless.ConfigCodec interface.
+func (yamlCodec) Decode(data []byte, v interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	return dec.Decode(v)
+}
+
+// Encode implements wireless.ConfigCodec interface.
+func (yamlCodec) Encode(v interface{}) ([]byte, error) {
+	return yaml.Marshal(v)
+}
+
+type tomlCodec struct{}
+
+// Decode implements wireless.ConfigCodec interface.
+func (tomlCodec) Decode(data []byte, v interface{}) error {
+	md, err := toml.Decode(string(data), v)
+	if err != nil {
+		return err
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for j, k := range undecoded {
+			keys[j] = k.String()
+		}
+		return fmt.Errorf("unknown keys: %s", strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+// Encode implements wireless.ConfigCodec interface.
+func (tomlCodec) Encode(v interface{}) ([]byte, error) {
+	return toml.Marshal(v)
+}
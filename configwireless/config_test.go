@@ -0,0 +1,80 @@
+package configwireless
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+type dbConfig struct {
+	DSN string `yaml:"dsn" toml:"dsn"`
+}
+
+type config struct {
+	Name     string                  `yaml:"name" toml:"name"`
+	Password wireless.Secret[string] `yaml:"password" toml:"password"`
+	DB       dbConfig                `yaml:"db" toml:"db"`
+}
+
+func TestFormats(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	load := func(t *testing.T, path string, overlays ...string) (*config, error) {
+		i := wireless.New()
+		i.Provide(wireless.ConfigFile[config](path, wireless.FormatAuto, overlays...))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var cfg *config
+		err := i.InjectAs(&cfg)
+		return cfg, err
+	}
+	files := map[string]string{
+		"yaml": write("config.yaml", "name: yaml\npassword: s3cret\ndb:\n  dsn: base\n"),
+		"toml": write("config.toml", "name = \"toml\"\npassword = \"s3cret\"\n[db]\ndsn = \"base\"\n"),
+	}
+	overlay := write("overlay.yml", "db:\n  dsn: overlay\n")
+
+	for name, path := range files {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := load(t, path, overlay)
+			if err != nil {
+				t.Fatal("Expected no error, got", err)
+			}
+			if cfg.Name != name || cfg.Password.Value() != "s3cret" || cfg.DB.DSN != "overlay" {
+				t.Errorf("Expected config loaded with the overlay, got %+v", cfg)
+			}
+		})
+	}
+
+	t.Run("include", func(t *testing.T) {
+		write("base.toml", "name = \"base\"\n[db]\ndsn = \"included\"\n")
+		path := write("app.yaml", "include:\n  - base.toml\nname: app\n")
+		cfg, err := load(t, path)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if cfg.Name != "app" || cfg.DB.DSN != "included" {
+			t.Errorf("Expected the included file overridden, got %+v", cfg)
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		for _, path := range []string{
+			write("unknown.yaml", "name: yaml\nport: 80\n"),
+			write("unknown.toml", "name = \"toml\"\nport = 80\n"),
+		} {
+			if _, err := load(t, path); err == nil {
+				t.Errorf("Expected error of the unknown key in: %s, got nil", path)
+			}
+		}
+	})
+}
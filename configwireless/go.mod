@@ -0,0 +1,11 @@
+module github.com/routercore/wireless/configwireless
+
+go 1.22.3
+
+replace github.com/routercore/wireless => ../
+
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/routercore/wireless v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
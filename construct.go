@@ -0,0 +1,32 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Construct allocates a new value of the struct, or pointer to struct, type T and injects its fields the same way
+// as Inject does, including the Init and AfterInject calls. The constructed value is not registered in the injector.
+// Example:
+//
+//	cmd, err := wireless.Construct[*CreateUserCommand](i)
+func Construct[T any](i *Injector) (T, error) {
+	var zero T
+	t := reflect.TypeOf(&zero).Elem()
+	switch {
+	case t.Kind() == reflect.Struct:
+		v := new(T)
+		if err := i.Inject(v); err != nil {
+			return zero, err
+		}
+		return *v, nil
+	case t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct:
+		v := reflect.New(t.Elem())
+		if err := i.Inject(v.Interface()); err != nil {
+			return zero, err
+		}
+		return v.Interface().(T), nil
+	default:
+		return zero, fmt.Errorf("constructed type is not a struct nor a pointer to struct but: %s", t)
+	}
+}
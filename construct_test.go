@@ -0,0 +1,38 @@
+package wireless
+
+import "testing"
+
+type command struct {
+	Ptr *testType
+	initType
+}
+
+func TestConstruct(t *testing.T) {
+	ptr := &testType{v: "construct"}
+	i := New()
+	i.Provide(Value(ptr))
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	cmd, err := Construct[*command](i)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if cmd.Ptr != ptr || cmd.initialized != 1 || cmd.injected != 1 {
+		t.Errorf("Expected constructed and initialized command, got %+v", cmd)
+	}
+
+	value, err := Construct[command](i)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if value.Ptr != ptr || value.initialized != 1 {
+		t.Errorf("Expected constructed and initialized command, got %+v", value)
+	}
+
+	if _, err = Construct[int](i); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
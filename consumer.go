@@ -0,0 +1,205 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ConsumersGroup is the name of the group of the Subscription values run by the ConsumerGroup.
+const ConsumersGroup = "wireless.consumers"
+
+// Consumer is the contract of the message consumers, e.g. of Kafka, NATS or SQS, run by the ConsumerGroup.
+type Consumer interface {
+	// Subscribe subscribes to the topics or queues, when the injector is started. Its failure fails the start.
+	Subscribe(ctx context.Context) error
+	// Run consumes the messages until the context is canceled.
+	Run(ctx context.Context) error
+	// Close releases the resources of the consumer, once it is stopped.
+	Close() error
+}
+
+// Subscription is the Consumer run by the ConsumerGroup. The providers register the subscriptions with Consume.
+type Subscription struct {
+	Name     string
+	Consumer Consumer
+	// Restart is the policy of restarting the Run of the consumer, see AppendSupervisedRunner.
+	Restart RestartPolicy
+}
+
+// Consume adds the provider of the Subscription to the group of the consumers run by the ConsumerGroup.
+// Example:
+//
+//	wireless.Consume(wireless.Func(func(c *kafka.Consumer) wireless.Subscription {
+//		return wireless.Subscription{Name: "orders", Consumer: c, Restart: wireless.RestartPolicy{Mode: wireless.RestartOnFailure}}
+//	}))
+func Consume(p Provider) Provider {
+	return Group(ConsumersGroup, p)
+}
+
+// Consumers is the provider set of the *ConsumerGroup running the Subscription group registered with Consume,
+// along with its HealthCheck, which fails unless all the consumers are running. The ConsumerGroup depends on
+// the *Injector to collect the group, so it needs to be allowed with WithInjectorAllowlist if the dependencies
+// on the injector are restricted.
+var Consumers = NewSet(
+	Func(func(i *Injector, lc *Lifecycle) (*ConsumerGroup, error) {
+		var subs []Subscription
+		if err := i.injectGroup(i.context(), ConsumersGroup, reflect.ValueOf(&subs)); err != nil {
+			return nil, err
+		}
+		return NewConsumerGroup(subs, lc)
+	}),
+	Group(HealthGroup, Func(func(g *ConsumerGroup) HealthCheck {
+		return HealthCheck{Name: "consumers", Check: g.CheckHealth}
+	})),
+)
+
+// ConsumerState is the state of the Consumer run by the ConsumerGroup.
+type ConsumerState int
+
+// Consumer states.
+const (
+	// ConsumerIdle is the consumer which is not started yet.
+	ConsumerIdle ConsumerState = iota
+	// ConsumerSubscribed is the consumer which subscribed, but does not run yet.
+	ConsumerSubscribed
+	// ConsumerRunning is the consumer running its Run.
+	ConsumerRunning
+	// ConsumerFailed is the consumer which Run failed and which is either restarted or stopped for good.
+	ConsumerFailed
+	// ConsumerClosed is the stopped and closed consumer.
+	ConsumerClosed
+)
+
+// String returns the name of the state.
+func (s ConsumerState) String() string {
+	switch s {
+	case ConsumerIdle:
+		return "idle"
+	case ConsumerSubscribed:
+		return "subscribed"
+	case ConsumerRunning:
+		return "running"
+	case ConsumerFailed:
+		return "failed"
+	case ConsumerClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+// ConsumerStatus describes the state of the Consumer run by the ConsumerGroup.
+type ConsumerStatus struct {
+	Name  string
+	State ConsumerState
+	// Err is the error of the last failed Run of the consumer.
+	Err error
+}
+
+// ConsumerGroup runs the consumers between the start and the stop of the injector lifecycle: each consumer
+// is subscribed when the injector is started, supervised according to its restart policy and closed when
+// the injector is stopped.
+type ConsumerGroup struct {
+	consumers []*consumer
+}
+
+type consumer struct {
+	Subscription
+	lock   sync.Mutex
+	status ConsumerStatus
+}
+
+// NewConsumerGroup creates the ConsumerGroup of the subscriptions appending the lifecycle hooks of the consumers.
+func NewConsumerGroup(subs []Subscription, lc *Lifecycle) (*ConsumerGroup, error) {
+	g := &ConsumerGroup{}
+	for j, sub := range subs {
+		if sub.Name == "" {
+			sub.Name = strconv.Itoa(j)
+		}
+		if sub.Consumer == nil {
+			return nil, fmt.Errorf("subscription: %s has no consumer", sub.Name)
+		}
+		c := &consumer{Subscription: sub, status: ConsumerStatus{Name: sub.Name}}
+		g.consumers = append(g.consumers, c)
+		// The hooks are stopped in reverse order, so the consumer is closed once its Run returns.
+		lc.Append(Hook{Name: "consumer: " + sub.Name, OnStart: c.subscribe, OnStop: c.close})
+		lc.appendRunner("consumer: "+sub.Name, &supervisor{name: sub.Name, runner: c, policy: sub.Restart, emit: lc.emit})
+	}
+	return g, nil
+}
+
+// Statuses returns the states of the consumers, in the order of the group.
+func (g *ConsumerGroup) Statuses() []ConsumerStatus {
+	statuses := make([]ConsumerStatus, len(g.consumers))
+	for j, c := range g.consumers {
+		statuses[j] = c.state()
+	}
+	return statuses
+}
+
+// CheckHealth returns the errors of the consumers which are not running together.
+func (g *ConsumerGroup) CheckHealth(ctx context.Context) error {
+	var errs multiError
+	for _, s := range g.Statuses() {
+		if s.State == ConsumerRunning {
+			continue
+		}
+		if s.Err != nil {
+			errs = append(errs, fmt.Errorf("consumer: %s is %s: %w", s.Name, s.State, s.Err))
+		} else {
+			errs = append(errs, fmt.Errorf("consumer: %s is %s", s.Name, s.State))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *consumer) state() ConsumerStatus {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.status
+}
+
+func (c *consumer) setState(s ConsumerState, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.status.State = s
+	if err != nil {
+		c.status.Err = err
+	}
+}
+
+func (c *consumer) subscribe(ctx context.Context) error {
+	if err := c.Consumer.Subscribe(ctx); err != nil {
+		c.setState(ConsumerFailed, err)
+		return fmt.Errorf("subscribing consumer: %s failed: %w", c.Name, err)
+	}
+	c.setState(ConsumerSubscribed, nil)
+	return nil
+}
+
+// Run implements Runner, tracking the state of the consumer.
+func (c *consumer) Run(ctx context.Context) error {
+	c.setState(ConsumerRunning, nil)
+	err := c.Consumer.Run(ctx)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		c.setState(ConsumerFailed, err)
+	} else {
+		c.setState(ConsumerSubscribed, nil)
+	}
+	return err
+}
+
+func (c *consumer) close(ctx context.Context) error {
+	err := c.Consumer.Close()
+	c.setState(ConsumerClosed, nil)
+	if err != nil {
+		return fmt.Errorf("closing consumer: %s failed: %w", c.Name, err)
+	}
+	return nil
+}
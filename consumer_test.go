@@ -0,0 +1,90 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeConsumer struct {
+	subscribeErr error
+	runs         atomic.Int32
+	running      chan struct{}
+	closed       atomic.Bool
+}
+
+func (c *fakeConsumer) Subscribe(ctx context.Context) error {
+	return c.subscribeErr
+}
+
+func (c *fakeConsumer) Run(ctx context.Context) error {
+	if c.runs.Add(1) == 1 {
+		return errors.New("connection reset")
+	}
+	close(c.running)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *fakeConsumer) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func TestConsumers(t *testing.T) {
+	setup := func(t *testing.T, c *fakeConsumer) (*Injector, *ConsumerGroup) {
+		i := New()
+		i.Provide(
+			Consumers,
+			Consume(Value(Subscription{Name: "orders", Consumer: c, Restart: RestartPolicy{Mode: RestartOnFailure, Backoff: time.Millisecond}})),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var g *ConsumerGroup
+		if err := i.InjectAs(&g); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return i, g
+	}
+
+	t.Run("run", func(t *testing.T) {
+		c := &fakeConsumer{running: make(chan struct{})}
+		i, g := setup(t, c)
+		if err := i.CheckHealth(context.Background()); err == nil || !strings.Contains(err.Error(), "consumer: orders is idle") {
+			t.Errorf("Expected the idle consumer, got %v", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		<-c.running
+		if err := i.CheckHealth(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if c.runs.Load() != 2 || !c.closed.Load() {
+			t.Errorf("Expected the restarted and closed consumer, got %v runs", c.runs.Load())
+		}
+		s := g.Statuses()[0]
+		if s.State != ConsumerClosed || s.Err == nil {
+			t.Errorf("Expected the closed consumer with the last error, got %+v", s)
+		}
+	})
+
+	t.Run("subscribe failure", func(t *testing.T) {
+		subscribeErr := errors.New("unknown topic")
+		c := &fakeConsumer{subscribeErr: subscribeErr}
+		i, g := setup(t, c)
+		if err := i.Start(context.Background()); !errors.Is(err, subscribeErr) {
+			t.Errorf("Expected %v, got %v", subscribeErr, err)
+		}
+		if s := g.Statuses()[0]; s.State != ConsumerFailed {
+			t.Errorf("Expected %v, got %v", ConsumerFailed, s.State)
+		}
+	})
+}
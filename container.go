@@ -0,0 +1,51 @@
+package wireless
+
+import (
+	"io"
+	"reflect"
+)
+
+// Resolver is the narrow interface of the injector used for the late lookup of the dependencies.
+// The injector registers itself as the Resolver, so that the provider functions might depend on it instead of
+// the concrete *Injector, which makes them easy to test with a fake implementation.
+// Example:
+//
+//	func NewJobRunner(r wireless.Resolver) *JobRunner {
+//		return &JobRunner{resolver: r}
+//	}
+type Resolver interface {
+	InjectAs(as interface{}) error
+}
+
+// Container is implemented by the Injector and by the reflection free injectors generated with wirelessgen,
+// so that the code injecting the dependencies does not depend on the implementation.
+type Container interface {
+	Resolver
+	Clean()
+}
+
+var (
+	_ Container = (*Injector)(nil)
+	_ io.Closer = (*Injector)(nil)
+
+	resolverType = reflect.TypeOf(new(Resolver)).Elem()
+)
+
+// Get injects the value of the type T from the resolver.
+// Example:
+//
+//	repo, err := wireless.Get[Repository](r)
+func Get[T any](r Resolver) (T, error) {
+	var v T
+	err := r.InjectAs(&v)
+	return v, err
+}
+
+// MustGet is like Get but panics if the value could not be injected.
+func MustGet[T any](r Resolver) T {
+	v, err := Get[T](r)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
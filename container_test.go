@@ -0,0 +1,34 @@
+package wireless
+
+import (
+	"errors"
+	"testing"
+)
+
+type resolverUser struct {
+	r Resolver
+}
+
+func TestResolver(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(&initType{}),
+		Func(func(r Resolver) *resolverUser { return &resolverUser{r: r} }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	u, err := Get[*resolverUser](i)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if u.r != Resolver(i) {
+		t.Error("Expected the injector to be injected as the Resolver")
+	}
+	if _, err := Get[*initType](u.r); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if _, err := Get[*scopeRequest](u.r); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+	}
+}
@@ -0,0 +1,72 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Curry declares a provider of the partially applied function. The leading arguments of the input function that
+// have a provider in the injector are injected, while the remaining ones become the arguments of the provided
+// function type. The curried function returns all the results of the input function.
+// Example:
+//
+//	// Provides func(http.ResponseWriter, *http.Request) with the *Logger and *Store injected.
+//	wireless.Curry(func(log *Logger, s *Store, w http.ResponseWriter, r *http.Request) { ... })
+func Curry(fn interface{}) Provider {
+	return &curryProvider{v: fn}
+}
+
+type curryProvider struct {
+	v interface{}
+	providerOptions
+}
+
+func (c *curryProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&c.providerOptions)
+	}
+}
+
+func (i *Injector) resolveCurries() {
+	for _, cp := range i.curryProviders {
+		rv := reflect.ValueOf(cp.v)
+		if rv.Kind() != reflect.Func {
+			i.errors = append(i.errors, fmt.Errorf("curried provider %T is not a function", cp.v))
+			continue
+		}
+		rt := rv.Type()
+		variadic := rt.IsVariadic()
+
+		var bound, rest, outs []reflect.Type
+		for j := 0; j < rt.NumIn(); j++ {
+			in := rt.In(j)
+			if len(rest) == 0 && !(variadic && j == rt.NumIn()-1) && i.hasProvider(in) {
+				bound = append(bound, in)
+				continue
+			}
+			rest = append(rest, in)
+		}
+		for j := 0; j < rt.NumOut(); j++ {
+			outs = append(outs, rt.Out(j))
+		}
+		ct := reflect.FuncOf(rest, outs, variadic)
+		if i.hasProvider(ct) {
+			if cp.ifNotExists {
+				i.recordDecision("skipped", ct, "curry")
+				continue
+			}
+			i.errors = append(i.errors, fmt.Errorf("provider already registered for type: %s", ct))
+			continue
+		}
+
+		i.syntheticProviderFunc(ct, bound, func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.MakeFunc(ct, func(xs []reflect.Value) []reflect.Value {
+				all := append(append(make([]reflect.Value, 0, len(args)+len(xs)), args...), xs...)
+				if variadic {
+					return rv.CallSlice(all)
+				}
+				return rv.Call(all)
+			})}
+		})
+	}
+}
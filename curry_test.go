@@ -0,0 +1,30 @@
+package wireless
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCurry(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(&testType{v: "hello"}),
+		Curry(func(p *testType, sep string, names ...string) string {
+			return p.v + sep + strings.Join(names, sep)
+		}),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var greet func(string, ...string) string
+	err = i.InjectAs(&greet)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	if s := greet(" ", "curried", "world"); s != "hello curried world" {
+		t.Errorf("Expected %v, got %v", "hello curried world", s)
+	}
+}
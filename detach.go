@@ -0,0 +1,81 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Detach creates the scope of the ScopeJob kind, sibling of the scope, with the values of the listed types,
+// defined with the `new` statement, copied from the scope, e.g. the tenant ID or the trace span of the request.
+// The detached scope is resolved with the context of the scope without its cancellation, thus it outlives
+// the scope and keeps its context values, so that the work handed to the WorkerPool keeps the context of
+// the request. Only the plain values should be copied, as the values cleaned along with the scope are not
+// usable by the detached one.
+// Example:
+//
+//	js, err := s.Detach(new(TenantID), new(trace.SpanContext))
+//	if err != nil { ... }
+//	err = pool.Submit(ctx, wireless.DetachedJob(js, SendReceipt))
+func (i *Injector) Detach(types ...interface{}) (*Injector, error) {
+	if i.parent == nil {
+		return nil, errors.New("only the child scope might be detached")
+	}
+	providers := make([]Provider, 0, len(types))
+	for _, dt := range types {
+		t, err := bindingType(dt)
+		if err != nil {
+			return nil, fmt.Errorf("detached %w", err)
+		}
+		v := reflect.New(t)
+		if err := i.injectAsContext(i.context(), v.Interface()); err != nil {
+			return nil, fmt.Errorf("detaching the value of the type: %s failed: %w", t, err)
+		}
+		if t.Kind() == reflect.Interface {
+			providers = append(providers, InterfaceValue(v.Interface(), v.Elem().Interface()))
+			continue
+		}
+		providers = append(providers, Value(v.Elem().Interface()))
+	}
+	s := i.parent.NewScope(ScopeJob)
+	s.Provide(providers...)
+	if err := s.ResolveContext(context.WithoutCancel(i.context())); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// DetachedJob returns the Job executing the function with the detached scope, which is closed once the function
+// returns. The context of the job is canceled by the WorkerPool, while it carries the values of the detached scope
+// context, so that the job is traced as the part of the request. The scope needs to be closed by the caller
+// if the job is not submitted.
+func DetachedJob(s *Injector, job func(ctx context.Context, s *Injector) error) Job {
+	return func(ctx context.Context) error {
+		var errs multiError
+		if err := job(detachedContext{Context: ctx, values: s.context()}, s); err != nil {
+			errs = append(errs, err)
+		}
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		return nil
+	}
+}
+
+// detachedContext is the context with the values looked up first in the values context.
+type detachedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
@@ -0,0 +1,100 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type traceKey struct{}
+
+func TestDetach(t *testing.T) {
+	root := New()
+	root.Provide(Value(&initType{}))
+	if err := root.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	detach := func(t *testing.T) *Injector {
+		s := root.NewScope(ScopeRequest)
+		s.Provide(Value(&testType{v: "tenant"}), Value(ScopeKey{Key: "request"}))
+		if err := s.ResolveContext(context.WithValue(context.Background(), traceKey{}, "trace")); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		js, err := s.Detach(new(*testType))
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := s.Close(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return js
+	}
+
+	t.Run("values", func(t *testing.T) {
+		js := detach(t)
+		defer js.Close()
+		if js.Kind() != ScopeJob || js.Parent() != root {
+			t.Errorf("Expected %v, got %v", ScopeJob, js.Kind())
+		}
+		var tt *testType
+		if err := js.InjectAs(&tt); err != nil || tt.v != "tenant" {
+			t.Errorf("Expected the copied value, got %v, %v", tt, err)
+		}
+		var it *initType
+		if err := js.InjectAs(&it); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var key ScopeKey
+		if err := js.InjectAs(&key); err == nil {
+			t.Errorf("Expected the value not copied, got %v", key)
+		}
+		var ctx context.Context
+		if err := js.InjectAs(&ctx); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if ctx.Err() != nil || ctx.Value(traceKey{}) != "trace" {
+			t.Errorf("Expected the detached context with the value, got %v", ctx.Value(traceKey{}))
+		}
+	})
+
+	t.Run("job", func(t *testing.T) {
+		js := detach(t)
+		jobCtx, cancel := context.WithCancel(context.Background())
+		jobErr := errors.New("job failed")
+		job := DetachedJob(js, func(ctx context.Context, s *Injector) error {
+			cancel()
+			if ctx.Err() == nil || ctx.Value(traceKey{}) != "trace" {
+				t.Errorf("Expected the canceled job context with the value, got %v", ctx.Value(traceKey{}))
+			}
+			var tt *testType
+			if err := s.InjectAs(&tt); err != nil {
+				t.Error("Expected no error, got", err)
+			}
+			return jobErr
+		})
+		if err := job(jobCtx); !errors.Is(err, jobErr) {
+			t.Errorf("Expected %v, got %v", jobErr, err)
+		}
+		var tt *testType
+		if err := js.InjectAs(&tt); err == nil {
+			t.Error("Expected the closed scope")
+		}
+	})
+
+	t.Run("root", func(t *testing.T) {
+		if _, err := root.Detach(); err == nil {
+			t.Error("Expected the error of detaching the root injector")
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		s := root.NewScope(ScopeRequest)
+		defer s.Close()
+		if err := s.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if _, err := s.Detach(new(*testType)); err == nil {
+			t.Error("Expected the error of the missing value")
+		}
+	})
+}
@@ -0,0 +1,119 @@
+// Package digwireless converts the wireless providers into go.uber.org/dig container registrations and exposes
+// the types of the dig container as the wireless providers, so that both might be used in a single graph.
+package digwireless
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/routercore/wireless"
+	"go.uber.org/dig"
+)
+
+var (
+	cleanupType = reflect.TypeOf(func() {})
+	errorType   = reflect.TypeOf(new(error)).Elem()
+)
+
+// Provide registers the providers in the dig container. The provider functions and decorators are registered as
+// the dig constructors and decorators, the values and bindings as the constructors returning them, while the group
+// and name options are translated into the dig ones. The cleanup functions returned by the provider functions are
+// called by the returned cleanup function in reverse order. Other providers, e.g. Curry or NoOp, are not supported.
+// Example:
+//
+//	cleanup, err := digwireless.Provide(c, repository.Providers, service.Providers)
+//	if err != nil { ... }
+//	defer cleanup()
+func Provide(c *dig.Container, providers ...wireless.Provider) (func(), error) {
+	var cleanups []reflect.Value
+	cleanup := func() {
+		for j := len(cleanups) - 1; j >= 0; j-- {
+			cleanups[j].Call(nil)
+		}
+	}
+	for _, info := range wireless.Inspect(providers...) {
+		var opts []dig.ProvideOption
+		if info.Group != "" {
+			opts = append(opts, dig.Group(info.Group))
+		}
+		if info.Name != "" {
+			opts = append(opts, dig.Name(info.Name))
+		}
+		var err error
+		switch {
+		case info.Type == nil:
+			err = fmt.Errorf("provider: %T is invalid", info.Provider)
+		case info.Kind == wireless.KindFunc:
+			err = c.Provide(withoutCleanup(reflect.ValueOf(info.Func), &cleanups).Interface(), opts...)
+		case info.Kind == wireless.KindValue || info.Kind == wireless.KindInterfaceValue:
+			v := reflect.ValueOf(info.Value)
+			fn := reflect.MakeFunc(reflect.FuncOf(nil, []reflect.Type{info.Type}, false), func([]reflect.Value) []reflect.Value {
+				return []reflect.Value{v}
+			})
+			err = c.Provide(fn.Interface(), opts...)
+		case info.Kind == wireless.KindBinding:
+			to := info.Type
+			fn := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{info.Target}, []reflect.Type{to}, false), func(args []reflect.Value) []reflect.Value {
+				return []reflect.Value{args[0].Convert(to)}
+			})
+			err = c.Provide(fn.Interface(), opts...)
+		case info.Kind == wireless.KindDecorator:
+			err = c.Decorate(withoutCleanup(reflect.ValueOf(info.Func), &cleanups).Interface())
+		default:
+			err = fmt.Errorf("provider: %T is not supported by dig", info.Provider)
+		}
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("registering provider of type: %v in dig failed: %w", info.Type, err)
+		}
+	}
+	return cleanup, nil
+}
+
+// withoutCleanup wraps the function returning the cleanup function into the one collecting it into the cleanups.
+func withoutCleanup(fn reflect.Value, cleanups *[]reflect.Value) reflect.Value {
+	ft := fn.Type()
+	if ft.NumOut() < 2 || ft.Out(1) != cleanupType {
+		return fn
+	}
+	ins := make([]reflect.Type, ft.NumIn())
+	for j := range ins {
+		ins[j] = ft.In(j)
+	}
+	outs := []reflect.Type{ft.Out(0)}
+	if ft.NumOut() == 3 {
+		outs = append(outs, ft.Out(2))
+	}
+	return reflect.MakeFunc(reflect.FuncOf(ins, outs, ft.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		res := fn.Call(args)
+		if !res[1].IsNil() {
+			*cleanups = append(*cleanups, res[1])
+		}
+		return append(res[:1], res[2:]...)
+	})
+}
+
+// FromDig declares the providers of the types, defined with the 'new' statement, resolved from the dig container.
+// Example:
+//
+//	i.Provide(digwireless.FromDig(c, new(*Config), new(Repository)))
+func FromDig(c *dig.Container, types ...interface{}) wireless.ProviderSet {
+	set := make(wireless.ProviderSet, 0, len(types))
+	for _, tv := range types {
+		t := reflect.TypeOf(tv).Elem()
+		fn := reflect.MakeFunc(reflect.FuncOf(nil, []reflect.Type{t, errorType}, false), func([]reflect.Value) []reflect.Value {
+			out := reflect.New(t).Elem()
+			invoke := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{t}, nil, false), func(args []reflect.Value) []reflect.Value {
+				out.Set(args[0])
+				return nil
+			})
+			if err := c.Invoke(invoke.Interface()); err != nil {
+				err = fmt.Errorf("resolving type: %s from dig failed: %w", t, err)
+				return []reflect.Value{out, reflect.ValueOf(&err).Elem()}
+			}
+			return []reflect.Value{out, reflect.Zero(errorType)}
+		})
+		set = append(set, wireless.Func(fn.Interface()))
+	}
+	return set
+}
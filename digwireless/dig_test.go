@@ -0,0 +1,77 @@
+package digwireless
+
+import (
+	"testing"
+
+	"github.com/routercore/wireless"
+	"go.uber.org/dig"
+)
+
+type config struct {
+	DSN string
+}
+
+type store interface {
+	DSN() string
+}
+
+type sqlStore struct {
+	c *config
+}
+
+func (s *sqlStore) DSN() string {
+	return s.c.DSN
+}
+
+type service struct {
+	s store
+}
+
+func TestProvide(t *testing.T) {
+	var cleaned bool
+	c := dig.New()
+	cleanup, err := Provide(c, wireless.NewSet(
+		wireless.Value(&config{DSN: "postgres://"}),
+		wireless.Func(func(c *config) (*sqlStore, func(), error) {
+			return &sqlStore{c: c}, func() { cleaned = true }, nil
+		}),
+		wireless.Bind(new(store), new(*sqlStore)),
+	))
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := c.Invoke(func(s store) {
+		if s.DSN() != "postgres://" {
+			t.Errorf("Expected %v, got %v", "postgres://", s.DSN())
+		}
+	}); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	cleanup()
+	if !cleaned {
+		t.Error("Expected the provider to be cleaned")
+	}
+}
+
+func TestFromDig(t *testing.T) {
+	c := dig.New()
+	if err := c.Provide(func() *config { return &config{DSN: "postgres://"} }); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	i := wireless.New()
+	i.Provide(
+		FromDig(c, new(*config)),
+		wireless.Func(func(c *config) *service { return &service{s: &sqlStore{c: c}} }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var s *service
+	if err := i.InjectAs(&s); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if s.s.DSN() != "postgres://" {
+		t.Errorf("Expected %v, got %v", "postgres://", s.s.DSN())
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/routercore/wireless/digwireless
+
+go 1.22.3
+
+replace github.com/routercore/wireless => ../
+
+require (
+	github.com/routercore/wireless v0.0.0-00010101000000-000000000000
+	go.uber.org/dig v1.19.0
+)
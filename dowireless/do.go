@@ -0,0 +1,46 @@
+// Package dowireless bridges the github.com/samber/do injector and wireless.Injector, so that the services
+// registered in any of them might be injected from the other one, keyed by their type and optional name.
+package dowireless
+
+import (
+	"github.com/routercore/wireless"
+	"github.com/samber/do/v2"
+)
+
+// Import declares the provider of the type T invoked from the do injector.
+// Example:
+//
+//	i.Provide(dowireless.Import[*Config](di))
+func Import[T any](di do.Injector) wireless.Provider {
+	return wireless.FuncOf[T](func() (T, error) {
+		return do.Invoke[T](di)
+	})
+}
+
+// ImportNamed declares the named provider of the type T invoked from the do injector by the name.
+func ImportNamed[T any](di do.Injector, name string) wireless.Provider {
+	return wireless.Named(name, wireless.FuncOf[T](func() (T, error) {
+		return do.InvokeNamed[T](di, name)
+	}))
+}
+
+// Export registers the lazy do service of the type T injected from the resolved wireless injector.
+// Example:
+//
+//	dowireless.Export[Repository](di, i)
+func Export[T any](di do.Injector, i *wireless.Injector) {
+	do.Provide(di, func(do.Injector) (T, error) {
+		var v T
+		err := i.InjectAs(&v)
+		return v, err
+	})
+}
+
+// ExportNamed registers the lazy do service named as the name of the wireless named provider of the type T.
+func ExportNamed[T any](di do.Injector, i *wireless.Injector, name string) {
+	do.ProvideNamed(di, name, func(do.Injector) (T, error) {
+		var v T
+		err := i.InjectNamed(name, &v)
+		return v, err
+	})
+}
@@ -0,0 +1,76 @@
+package dowireless
+
+import (
+	"testing"
+
+	"github.com/routercore/wireless"
+	"github.com/samber/do/v2"
+)
+
+type config struct {
+	DSN string
+}
+
+type store struct {
+	c *config
+}
+
+func TestImport(t *testing.T) {
+	di := do.New()
+	do.ProvideValue(di, &config{DSN: "primary"})
+	do.ProvideNamedValue(di, "replica", &config{DSN: "replica"})
+
+	i := wireless.New()
+	i.Provide(
+		Import[*config](di),
+		ImportNamed[*config](di, "replica"),
+		wireless.Func(func(c *config) *store { return &store{c: c} }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var s *store
+	if err := i.InjectAs(&s); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if s.c.DSN != "primary" {
+		t.Errorf("Expected %v, got %v", "primary", s.c.DSN)
+	}
+	var c *config
+	if err := i.InjectNamed("replica", &c); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if c.DSN != "replica" {
+		t.Errorf("Expected %v, got %v", "replica", c.DSN)
+	}
+}
+
+func TestExport(t *testing.T) {
+	i := wireless.New()
+	i.Provide(
+		wireless.Value(&config{DSN: "primary"}),
+		wireless.Named("replica", wireless.Value(&config{DSN: "replica"})),
+		wireless.Func(func(c *config) *store { return &store{c: c} }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	di := do.New()
+	Export[*store](di, i)
+	ExportNamed[*config](di, i, "replica")
+	s, err := do.Invoke[*store](di)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if s.c.DSN != "primary" {
+		t.Errorf("Expected %v, got %v", "primary", s.c.DSN)
+	}
+	c, err := do.InvokeNamed[*config](di, "replica")
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if c.DSN != "replica" {
+		t.Errorf("Expected %v, got %v", "replica", c.DSN)
+	}
+}
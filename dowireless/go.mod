@@ -0,0 +1,12 @@
+module github.com/routercore/wireless/dowireless
+
+go 1.22.3
+
+replace github.com/routercore/wireless => ../
+
+require (
+	github.com/routercore/wireless v0.0.0-00010101000000-000000000000
+	github.com/samber/do/v2 v2.1.0
+)
+
+require github.com/samber/go-type-to-string v1.8.0 // indirect
@@ -0,0 +1,161 @@
+package wireless
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// WithConfigTypes marks the types, defined with the `new` statement, as the configuration dumped by DumpValues.
+// The struct types might be also marked with the blank field tagged with 'wireless:"config"'.
+// Example:
+//
+//	wireless.New(wireless.WithConfigTypes(new(*DBConfig), new(HTTPConfig)))
+func WithConfigTypes(types ...interface{}) Option {
+	return func(i *Injector) {
+		// The types are copied, as the map might be inherited from the parent scope.
+		configTypes := make(map[reflect.Type]bool, len(i.configTypes)+len(types))
+		for t := range i.configTypes {
+			configTypes[t] = true
+		}
+		for _, ct := range types {
+			if t := reflect.TypeOf(ct); t != nil && t.Kind() == reflect.Ptr {
+				configTypes[t.Elem()] = true
+			}
+		}
+		i.configTypes = configTypes
+	}
+}
+
+// DumpValues writes the JSON object of the configuration values, keyed by their types, which is the effective
+// configuration of the application. The configuration are the types marked with WithConfigTypes and the structs,
+// or pointers to structs, provided by the injector with the blank field tagged with 'wireless:"config"'.
+// The values not constructed yet are constructed. The Secret values and the struct fields tagged with
+// 'wireless:"secret"' are redacted, while the other struct fields are named after their 'json' tags.
+// Example:
+//
+//	type DBConfig struct {
+//		_        struct{} `wireless:"config"`
+//		Host     string   `json:"host"`
+//		Password string   `json:"password" wireless:"secret"`
+//	}
+func (i *Injector) DumpValues(w io.Writer) error {
+	i.lock.RLock()
+	if !i.resolved {
+		i.lock.RUnlock()
+		return ErrNotResolved
+	}
+	types := map[reflect.Type]bool{}
+	for t := range i.configTypes {
+		types[t] = true
+	}
+	for t := range i.values {
+		if isConfigStruct(t) {
+			types[t] = true
+		}
+	}
+	for t := range i.providersMap {
+		if isConfigStruct(t) {
+			types[t] = true
+		}
+	}
+	i.lock.RUnlock()
+
+	values := make(map[string]interface{}, len(types))
+	for _, t := range sortedTypes(types) {
+		rv := reflect.New(t)
+		if err := i.injectAsContext(i.context(), rv.Interface()); err != nil {
+			return fmt.Errorf("dumping the value of the type: %s failed: %w", t, err)
+		}
+		values[t.String()] = redactedJSON(rv.Elem(), 0)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(values)
+}
+
+// isConfigStruct reports whether the struct, or pointer to struct, type has the field tagged with 'wireless:"config"'.
+func isConfigStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for j := 0; j < t.NumField(); j++ {
+		if parseTag(t.Field(j).Tag).config {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedJSON returns the value marshaled to JSON the same as the input one, with the secrets redacted.
+func redactedJSON(rv reflect.Value, depth int) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+	if rv.Type().Implements(secretType) {
+		return redacted
+	}
+	if _, ok := interfaceOf(rv).(json.Marshaler); ok && (rv.Kind() != reflect.Ptr || !rv.IsNil()) {
+		return interfaceOf(rv)
+	}
+	if depth > maxRedactDepth {
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return redactedJSON(rv.Elem(), depth+1)
+	case reflect.Struct:
+		fields := map[string]interface{}{}
+		for j := 0; j < rv.NumField(); j++ {
+			ft := rv.Type().Field(j)
+			if !ft.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(ft.Tag.Get("json"), ",")
+			switch name {
+			case "-":
+				continue
+			case "":
+				name = ft.Name
+			}
+			if parseTag(ft.Tag).secret {
+				fields[name] = redacted
+				continue
+			}
+			fields[name] = redactedJSON(rv.Field(j), depth+1)
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return interfaceOf(rv)
+		}
+		elems := make([]interface{}, rv.Len())
+		for j := range elems {
+			elems[j] = redactedJSON(rv.Index(j), depth+1)
+		}
+		return elems
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		entries := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			entries[fmt.Sprint(interfaceOf(iter.Key()))] = redactedJSON(iter.Value(), depth+1)
+		}
+		return entries
+	default:
+		return interfaceOf(rv)
+	}
+}
@@ -0,0 +1,86 @@
+package wireless
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type dumpConfig struct {
+	_        struct{}       `wireless:"config"`
+	Host     string         `json:"host"`
+	Password string         `json:"password" wireless:"secret"`
+	Token    Secret[string] `json:"token"`
+	Timeout  time.Duration  `json:"timeout"`
+	Tags     map[string]int `json:"tags"`
+	Skipped  string         `json:"-"`
+}
+
+func TestDumpValues(t *testing.T) {
+	t.Run("config", func(t *testing.T) {
+		i := New(WithConfigTypes(new(*initType)))
+		i.Provide(
+			Func(func() *dumpConfig {
+				return &dumpConfig{Host: "db", Password: "pass", Token: NewSecret("token"), Timeout: time.Second, Tags: map[string]int{"a": 1}}
+			}),
+			Value(&initType{}),
+			Value(&testType{v: "not dumped"}),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var buf bytes.Buffer
+		if err := i.DumpValues(&buf); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var dump map[string]map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &dump); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if len(dump) != 2 {
+			t.Errorf("Expected %v, got %v", 2, buf.String())
+		}
+		if _, ok := dump["*wireless.initType"]; !ok {
+			t.Errorf("Expected the listed type, got %v", buf.String())
+		}
+		c := dump["*wireless.dumpConfig"]
+		expected := map[string]interface{}{
+			"host": "db", "password": redacted, "token": redacted, "timeout": float64(time.Second), "tags": map[string]interface{}{"a": float64(1)},
+		}
+		for k, v := range expected {
+			if got, ok := c[k]; !ok || !jsonEqual(got, v) {
+				t.Errorf("Expected %v, got %v for the field: %s", v, got, k)
+			}
+		}
+		if len(c) != len(expected) {
+			t.Errorf("Expected %v, got %v", len(expected), len(c))
+		}
+		if strings.Contains(buf.String(), "pass\"") {
+			t.Errorf("Expected the redacted secrets, got %v", buf.String())
+		}
+	})
+
+	t.Run("not provided", func(t *testing.T) {
+		i := New(WithConfigTypes(new(*dumpConfig)))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.DumpValues(&bytes.Buffer{}); err == nil {
+			t.Error("Expected the error of the missing config type")
+		}
+	})
+
+	t.Run("not resolved", func(t *testing.T) {
+		if err := New().DumpValues(&bytes.Buffer{}); err != ErrNotResolved {
+			t.Errorf("Expected %v, got %v", ErrNotResolved, err)
+		}
+	})
+}
+
+func jsonEqual(a, b interface{}) bool {
+	ab, _ := json.Marshal(a)
+	bb, _ := json.Marshal(b)
+	return string(ab) == string(bb)
+}
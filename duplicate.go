@@ -0,0 +1,69 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+)
+
+// ErrDuplicateProvider is matched by the DuplicateProviderError.
+var ErrDuplicateProvider = errors.New("duplicate provider")
+
+// ProviderSite describes the declaration of the provider.
+type ProviderSite struct {
+	// Provider is the name of the provider function, or the description of the value or binding.
+	Provider string
+	// Signature is the type of the provider function, if any.
+	Signature string
+	// Source is the file and line of the provider declaration, if known.
+	Source    string
+	Namespace string
+}
+
+// String returns the human readable description of the provider declaration.
+func (s ProviderSite) String() string {
+	str := s.Provider
+	if str == "" {
+		str = "unknown provider"
+	}
+	if s.Signature != "" {
+		str += " " + s.Signature
+	}
+	if s.Source != "" {
+		str += " declared at " + s.Source
+	}
+	if s.Namespace != "" {
+		str += " in the namespace: " + s.Namespace
+	}
+	return str
+}
+
+// DuplicateProviderError is returned by Resolve when multiple providers of the same type are registered.
+type DuplicateProviderError struct {
+	Type      reflect.Type
+	Existing  ProviderSite
+	Duplicate ProviderSite
+}
+
+// Error implements error interface.
+func (e *DuplicateProviderError) Error() string {
+	return fmt.Sprintf("provider already registered for type: %s, existing: %s, duplicate: %s; use wireless.Override "+
+		"to replace the existing provider, wireless.IfNotExists to keep it, or wireless.Named to register both",
+		e.Type, e.Existing, e.Duplicate)
+}
+
+// Is matches the ErrDuplicateProvider.
+func (e *DuplicateProviderError) Is(target error) bool {
+	return target == ErrDuplicateProvider
+}
+
+// declared returns the options of the provider declared by the caller of the provider constructor.
+func declared() providerOptions {
+	var o providerOptions
+	if _, file, line, ok := runtime.Caller(2); ok {
+		o.source = file + ":" + strconv.Itoa(line)
+	}
+	return o
+}
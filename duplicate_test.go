@@ -0,0 +1,47 @@
+package wireless
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newDuplicateType() *testType { return &testType{} }
+
+func TestDuplicateProvider(t *testing.T) {
+	i := New()
+	i.Provide(
+		Namespace("storage", Func(newDuplicateType)),
+		Func(func() *testType { return &testType{} }),
+	)
+	err := i.Resolve()
+	if !errors.Is(err, ErrDuplicateProvider) {
+		t.Fatalf("Expected %v, got %v", ErrDuplicateProvider, err)
+	}
+	var de *DuplicateProviderError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected DuplicateProviderError, got %T", err)
+	}
+	if de.Existing.Namespace != "storage" || !strings.HasSuffix(de.Existing.Provider, "newDuplicateType") {
+		t.Errorf("Expected the existing provider of the storage namespace, got %v", de.Existing)
+	}
+	if de.Duplicate.Signature != "func() *wireless.testType" {
+		t.Errorf("Expected %v, got %v", "func() *wireless.testType", de.Duplicate.Signature)
+	}
+	for _, s := range []ProviderSite{de.Existing, de.Duplicate} {
+		if !strings.Contains(s.Source, "duplicate_test.go:") {
+			t.Errorf("Expected the declaration in duplicate_test.go, got %v", s.Source)
+		}
+	}
+	for _, remedy := range []string{"wireless.Override", "wireless.IfNotExists", "wireless.Named"} {
+		if !strings.Contains(err.Error(), remedy) {
+			t.Errorf("Expected the %v remedy, got %v", remedy, err)
+		}
+	}
+
+	i = New()
+	i.Provide(Values(&testType{}, &initType{}), Value(&testType{}))
+	if err := i.Resolve(); !errors.As(err, &de) || de.Existing.Provider != "value" || de.Existing.Source == "" {
+		t.Errorf("Expected the duplicate value error, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package wireless
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Env declares a provider of the *T config struct populated from the environment variables.
+// The variable name of each field is defined with the 'env' struct field tag, or derived from the field name
+// in the upper snake case, and prefixed with the input prefix. Nested structs have their variable names
+// prefixed with the name of their field. The 'env' tag might also mark the field as required, while the
+// 'default' tag defines the value used if the variable is not set.
+// Example:
+//
+//	type Config struct {
+//		Addr    string        `env:"ADDR,required"`
+//		Timeout time.Duration `default:"5s"`
+//		DB      DBConfig      // Variables prefixed with APP_DB_.
+//	}
+//
+//	wireless.Env[Config]("APP")
+func Env[T any](prefix string) Provider {
+	return Func(func() (*T, error) {
+		v := new(T)
+		if err := loadEnv(reflect.ValueOf(v).Elem(), prefix); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf(new(encoding.TextUnmarshaler)).Elem()
+)
+
+// loadEnv populates the struct value fields with the environment variables.
+func loadEnv(rv reflect.Value, prefix string) error {
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("environment config type is not a struct but: %s", rv.Type())
+	}
+	var errs multiError
+	for j := 0; j < rv.NumField(); j++ {
+		ft := rv.Type().Field(j)
+		if !ft.IsExported() {
+			continue
+		}
+		tag, _ := ft.Tag.Lookup("env")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = toSnakeCase(ft.Name)
+		}
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		fv := rv.Field(j)
+		if ft.Type.Kind() == reflect.Struct && !reflect.PointerTo(ft.Type).Implements(textUnmarshalerType) {
+			if err := loadEnv(fv, name); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			if opts == "required" {
+				errs = append(errs, fmt.Errorf("required environment variable: %s of the field: %s is not set", name, ft.Name))
+				continue
+			}
+			if value, ok = ft.Tag.Lookup("default"); !ok {
+				continue
+			}
+		}
+		if err := setString(fv, value); err != nil {
+			errs = append(errs, fmt.Errorf("environment variable: %s of the field: %s is invalid: %w", name, ft.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// setString sets the value parsed from the string.
+func setString(fv reflect.Value, s string) error {
+	if fv.CanAddr() && fv.Addr().Type().Implements(textUnmarshalerType) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 0, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		parts := strings.Split(s, ",")
+		sv := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for j, p := range parts {
+			if err := setString(sv.Index(j), strings.TrimSpace(p)); err != nil {
+				return err
+			}
+		}
+		fv.Set(sv)
+	case reflect.Ptr:
+		pv := reflect.New(fv.Type().Elem())
+		if err := setString(pv.Elem(), s); err != nil {
+			return err
+		}
+		fv.Set(pv)
+	default:
+		return fmt.Errorf("unsupported type: %s", fv.Type())
+	}
+	return nil
+}
+
+// toSnakeCase converts the Go identifier into the upper snake case, e.g. 'MaxConns' into 'MAX_CONNS'.
+func toSnakeCase(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for j, r := range runes {
+		if j > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[j-1]) || j+1 < len(runes) && unicode.IsLower(runes[j+1])) {
+			sb.WriteRune('_')
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package wireless
+
+import (
+	"testing"
+	"time"
+)
+
+type envConfig struct {
+	Addr     string        `env:"LISTEN_ADDR,required"`
+	Timeout  time.Duration `default:"5s"`
+	MaxConns int
+	Hosts    []string
+	DB       struct {
+		DSN string `env:"DSN"`
+	}
+	Skip string `env:"-"`
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("APP_LISTEN_ADDR", ":8080")
+	t.Setenv("APP_MAX_CONNS", "10")
+	t.Setenv("APP_HOSTS", "a, b")
+	t.Setenv("APP_DB_DSN", "postgres://")
+	t.Setenv("APP_SKIP", "skipped")
+
+	i := New()
+	i.Provide(Env[envConfig]("APP"))
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var cfg *envConfig
+	err = i.InjectAs(&cfg)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if cfg.Addr != ":8080" || cfg.Timeout != 5*time.Second || cfg.MaxConns != 10 || cfg.DB.DSN != "postgres://" || cfg.Skip != "" {
+		t.Errorf("Expected config loaded from environment, got %+v", cfg)
+	}
+	if len(cfg.Hosts) != 2 || cfg.Hosts[1] != "b" {
+		t.Errorf("Expected hosts [a b], got %v", cfg.Hosts)
+	}
+
+	i = New()
+	i.Provide(Env[envConfig]("MISSING"))
+	err = i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if err = i.InjectAs(&cfg); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
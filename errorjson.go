@@ -0,0 +1,120 @@
+package wireless
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// jsonError is the machine readable description of the error rendered by ErrorJSON.
+type jsonError struct {
+	// Kind is one of: 'multiple', 'duplicate', 'ambiguous', 'not found', 'cycle', 'layer violation', 'panic',
+	// 'poisoned', 'shutting down' or 'error' for any other error.
+	Kind     string     `json:"kind"`
+	Message  string     `json:"message"`
+	Type     string     `json:"type,omitempty"`
+	Group    string     `json:"group,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Provider string     `json:"provider,omitempty"`
+	Cycle    []string   `json:"cycle,omitempty"`
+	Sites    []jsonSite `json:"sites,omitempty"`
+	// Errors are the errors joined or wrapped by the error.
+	Errors []jsonError `json:"errors,omitempty"`
+}
+
+// jsonSite is the machine readable description of the ProviderSite.
+type jsonSite struct {
+	Role      string `json:"role,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Provider  string `json:"provider,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ErrorJSON renders the error returned by the injector, e.g. by Resolve or Close, as the machine readable JSON,
+// so that it could be turned into the CI annotations or processed by the log pipelines. The joined errors are
+// rendered as the tree, with the duplicate and ambiguous providers described by their declaration sites,
+// the missing types and the dependency cycles. It returns nil if the error is nil.
+// Example:
+//
+//	if err := i.Resolve(); err != nil {
+//		os.Stderr.Write(wireless.ErrorJSON(err))
+//		os.Exit(1)
+//	}
+func ErrorJSON(err error) []byte {
+	if err == nil {
+		return nil
+	}
+	// The description consists of strings only, so it is always marshalled.
+	b, _ := json.Marshal(describeError(err))
+	return b
+}
+
+// describeError returns the machine readable description of the error and the errors it wraps.
+func describeError(err error) jsonError {
+	je := jsonError{Kind: "error", Message: err.Error()}
+	switch e := err.(type) {
+	case multiError:
+		je.Kind = "multiple"
+	case cycleError:
+		je.Kind, je.Cycle = "cycle", e.trace
+		return je
+	case notFoundError:
+		je.Kind, je.Type = "not found", e.t.String()
+		return je
+	case *DuplicateProviderError:
+		je.Kind, je.Type = "duplicate", typeString(e.Type)
+		je.Sites = []jsonSite{siteJSON("existing", nil, e.Existing), siteJSON("duplicate", nil, e.Duplicate)}
+		return je
+	case *AmbiguousProviderError:
+		je.Kind, je.Type, je.Group, je.Name = "ambiguous", typeString(e.Type), e.Group, e.Name
+		for _, c := range e.Candidates {
+			je.Sites = append(je.Sites, siteJSON("candidate", c.Type, c.Site))
+		}
+		return je
+	case *LayerViolationError:
+		je.Kind = "layer violation"
+		je.Sites = []jsonSite{siteJSON("from", nil, e.From), siteJSON("to", nil, e.To)}
+		return je
+	case *PanicError:
+		je.Kind, je.Provider = "panic", e.Provider
+	default:
+		switch {
+		case errors.Is(err, ErrPoisoned):
+			je.Kind = "poisoned"
+		case err == ErrShuttingDown:
+			je.Kind = "shutting down"
+		case isJoined(err):
+			je.Kind = "multiple"
+		}
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if e == ErrPoisoned {
+				// The poisoned error is described by its kind.
+				continue
+			}
+			je.Errors = append(je.Errors, describeError(e))
+		}
+	case interface{ Unwrap() error }:
+		if e := u.Unwrap(); e != nil {
+			je.Errors = append(je.Errors, describeError(e))
+		}
+	}
+	return je
+}
+
+// siteJSON returns the machine readable description of the provider declaration.
+func siteJSON(role string, t reflect.Type, s ProviderSite) jsonSite {
+	return jsonSite{
+		Role: role, Type: typeString(t), Provider: s.Provider, Signature: s.Signature, Source: s.Source, Namespace: s.Namespace,
+	}
+}
+
+// isJoined reports whether the error joins multiple errors, e.g. with errors.Join.
+func isJoined(err error) bool {
+	_, ok := err.(interface{ Unwrap() []error })
+	return ok
+}
@@ -0,0 +1,74 @@
+package wireless
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorJSON(t *testing.T) {
+	decode := func(t *testing.T, err error) jsonError {
+		var je jsonError
+		if err := json.Unmarshal(ErrorJSON(err), &je); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return je
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		if b := ErrorJSON(nil); b != nil {
+			t.Errorf("Expected nil, got %s", b)
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Func(newDuplicateType),
+			Func(func() *testType { return &testType{} }),
+			Value(42),
+			Value(43),
+		)
+		je := decode(t, i.Resolve())
+		if je.Kind != "multiple" || len(je.Errors) != 2 {
+			t.Fatalf("Expected 2 errors, got %+v", je)
+		}
+		for _, e := range je.Errors {
+			if e.Kind != "duplicate" || len(e.Sites) != 2 || e.Sites[0].Role != "existing" {
+				t.Errorf("Expected duplicate with sites, got %+v", e)
+			}
+			if !strings.Contains(e.Sites[1].Source, "errorjson_test.go:") {
+				t.Errorf("Expected the declaration in errorjson_test.go, got %v", e.Sites[1].Source)
+			}
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		type a struct{}
+		type b struct{}
+		i := New()
+		i.Provide(
+			Func(func(b) a { return a{} }),
+			Func(func(a) b { return b{} }),
+		)
+		je := decode(t, i.Resolve())
+		if je.Kind != "cycle" || len(je.Cycle) != 3 || je.Cycle[0] != je.Cycle[2] || je.Cycle[0] == je.Cycle[1] {
+			t.Errorf("Expected cycle, got %+v", je)
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		err := errors.Join(errors.New("stop failed"), &PanicError{Provider: "newServer", Value: notFoundError{t: reflect.TypeOf(testType{})}})
+		je := decode(t, err)
+		if je.Kind != "multiple" || len(je.Errors) != 2 || je.Errors[0].Kind != "error" {
+			t.Fatalf("Expected 2 errors, got %+v", je)
+		}
+		p := je.Errors[1]
+		if p.Kind != "panic" || p.Provider != "newServer" || len(p.Errors) != 1 || p.Errors[0].Kind != "not found" ||
+			p.Errors[0].Type != "wireless.testType" {
+			t.Errorf("Expected panic wrapping not found, got %+v", p)
+		}
+	})
+}
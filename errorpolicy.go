@@ -0,0 +1,59 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorPolicy is the behavior of the injector when the provider function fails to construct its instance
+// on injection.
+type ErrorPolicy int
+
+// Error policies set by WithErrorPolicy.
+const (
+	// ErrorRetry makes the next injection of the failed provider call the provider function again. It is the default.
+	ErrorRetry ErrorPolicy = iota
+	// ErrorCache makes the injections of the failed provider return its error without calling it again, until it is
+	// released with Release.
+	ErrorCache
+	// ErrorPoison makes all the following injections of the injector fail with the ErrPoisoned wrapping the error
+	// of the first failed provider.
+	ErrorPoison
+)
+
+// ErrPoisoned is matched by the errors returned by the injector poisoned by the failed provider with ErrorPoison.
+var ErrPoisoned = errors.New("injector poisoned")
+
+// WithErrorPolicy sets the behavior of the injector and its child scopes on the failure of the provider function.
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(i *Injector) {
+		i.errorPolicy = policy
+	}
+}
+
+// admit returns the error of the injection into the injector which is shutting down or poisoned.
+func (i *Injector) admit() error {
+	if i.closing.Load() {
+		return ErrShuttingDown
+	}
+	if err, ok := i.poisoned.Load().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// constructFailed applies the error policy to the failed construction of the provider function instance.
+func (i *Injector) constructFailed(p *providerFunc, err error) error {
+	if i.errorPolicy == ErrorPoison {
+		i.poisoned.CompareAndSwap(nil, fmt.Errorf("%w by the failure of the provider: %s: %w", ErrPoisoned, p.name(), err))
+	}
+	return err
+}
+
+// cachedError returns the cached error of the failed provider function with ErrorCache.
+func (i *Injector) cachedError(p *providerFunc) error {
+	if i.errorPolicy == ErrorCache {
+		return p.err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package wireless
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorPolicy(t *testing.T) {
+	buildErr := errors.New("build failed")
+	newInjector := func(policy ErrorPolicy, calls *int) *Injector {
+		i := New(WithErrorPolicy(policy))
+		i.Provide(
+			Func(func() (*testType, error) {
+				*calls++
+				if *calls == 1 {
+					return nil, buildErr
+				}
+				return &testType{}, nil
+			}),
+			Value(&initType{}),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return i
+	}
+
+	t.Run("Retry", func(t *testing.T) {
+		var calls int
+		i := newInjector(ErrorRetry, &calls)
+		var tt *testType
+		if err := i.InjectAs(&tt); !errors.Is(err, buildErr) {
+			t.Errorf("Expected %v, got %v", buildErr, err)
+		}
+		if err := i.InjectAs(&tt); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected %v, got %v", 2, calls)
+		}
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		var calls int
+		i := newInjector(ErrorCache, &calls)
+		var tt *testType
+		for j := 0; j < 2; j++ {
+			if err := i.InjectAs(&tt); !errors.Is(err, buildErr) {
+				t.Errorf("Expected %v, got %v", buildErr, err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("Expected %v, got %v", 1, calls)
+		}
+		if err := i.Release(new(*testType)); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err := i.InjectAs(&tt); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+
+	t.Run("Poison", func(t *testing.T) {
+		var calls int
+		i := newInjector(ErrorPoison, &calls)
+		var tt *testType
+		if err := i.InjectAs(&tt); !errors.Is(err, buildErr) {
+			t.Errorf("Expected %v, got %v", buildErr, err)
+		}
+		var it *initType
+		err := i.InjectAs(&it)
+		if !errors.Is(err, ErrPoisoned) || !errors.Is(err, buildErr) {
+			t.Errorf("Expected %v, got %v", ErrPoisoned, err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected %v, got %v", 1, calls)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// EventKind is the kind of the Event emitted by the injector.
+type EventKind string
+
+// Event kinds emitted by the injector.
+const (
+	// EventShadowed is emitted when the child scope provides a type which is also provided by its ancestor.
+	EventShadowed EventKind = "shadowed"
+	// EventRefreshed is emitted when the instance of the TTL provider is replaced.
+	EventRefreshed EventKind = "refreshed"
+	// EventRefreshFailed is emitted when the construction of the TTL provider instance fails on refresh.
+	EventRefreshFailed EventKind = "refresh failed"
+	// EventRetry is emitted when the attempt to call the provider function with the Retry policy fails.
+	EventRetry EventKind = "retry"
+	// EventCircuitOpened is emitted when the circuit breaker of the provider function opens.
+	EventCircuitOpened EventKind = "circuit opened"
+	// EventPanic is emitted when the panic of the cleanup function is recovered.
+	EventPanic EventKind = "panic"
+	// EventRestart is emitted when the supervised Runner is restarted.
+	EventRestart EventKind = "restart"
+	// EventCleanupFailed is emitted when the teardown function registered with OnShutdown fails.
+	EventCleanupFailed EventKind = "cleanup failed"
+	// EventScopeLeaked is emitted when the child scope is not cleaned within the duration set by
+	// WithScopeLeakDetection.
+	EventScopeLeaked EventKind = "scope leaked"
+	// EventStaleInjection is emitted when IsCurrent detects the value replaced since it was injected.
+	EventStaleInjection EventKind = "stale injection"
+	// EventHookStopped is emitted when the OnStop function of the lifecycle hook returns.
+	EventHookStopped EventKind = "hook stopped"
+	// EventJobFailed is emitted when the job executed by the WorkerPool or the JobScheduler fails or panics.
+	EventJobFailed EventKind = "job failed"
+	// EventRequestFailed is emitted through Emit by the adapters, such as httpwireless, when serving the request
+	// fails, so that the details of the failure are not exposed to the client.
+	EventRequestFailed EventKind = "request failed"
+)
+
+// Event is the structured notification emitted by the injector to the handler registered with WithEventHandler.
+type Event struct {
+	Kind EventKind
+	// Scope is the kind of the scope emitting the event, empty for the root injector.
+	Scope string
+	// Type is the type the event relates to.
+	Type reflect.Type
+	// Provider is the description of the provider the event relates to.
+	Provider string
+	// Shadowed is the description of the ancestor provider shadowed by the Provider.
+	Shadowed string
+	// Attempt is the number of the failed attempt reported by the EventRetry, of the restart reported
+	// by the EventRestart, or of the replacements since the injection reported by the EventStaleInjection.
+	Attempt int
+	// Age is the age of the child scope reported by the EventScopeLeaked.
+	Age time.Duration
+	// Duration is the duration of the OnStop function reported by the EventHookStopped.
+	Duration time.Duration
+	// Err is the error the event reports, if any.
+	Err error
+}
+
+// String returns the human readable description of the event.
+func (e Event) String() string {
+	switch e.Kind {
+	case EventShadowed:
+		return fmt.Sprintf("provider: %s of type: %s in the %q scope shadows the provider: %s", e.Provider, e.Type, e.Scope, e.Shadowed)
+	case EventRetry:
+		return fmt.Sprintf("attempt: %d of the provider: %s of type: %s failed: %v", e.Attempt, e.Provider, e.Type, e.Err)
+	case EventRestart:
+		return fmt.Sprintf("restart: %d of the runner: %s after: %v", e.Attempt, e.Provider, e.Err)
+	case EventStaleInjection:
+		return fmt.Sprintf("value of type: %s was replaced: %d times since it was injected", e.Type, e.Attempt)
+	case EventHookStopped:
+		s := fmt.Sprintf("lifecycle hook: %s stopped in: %v", e.Provider, e.Duration)
+		if e.Err != nil {
+			s += ": " + e.Err.Error()
+		}
+		return s
+	case EventScopeLeaked:
+		return fmt.Sprintf("scope: %q is not cleaned after: %v", e.Scope, e.Age)
+	}
+	s := fmt.Sprintf("%s: %s of type: %s", e.Kind, e.Provider, e.Type)
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// WithEventHandler registers the handler of the events emitted by the injector and its child scopes.
+// The handler is called synchronously, so it should not block.
+func WithEventHandler(h func(Event)) Option {
+	return func(i *Injector) {
+		i.eventHandler = h
+	}
+}
+
+// Emit passes the event to the handler registered with WithEventHandler, if any. It is meant for the adapters
+// reporting the failures which occur outside of the injector, e.g. EventRequestFailed.
+func (i *Injector) Emit(e Event) {
+	i.emit(e)
+}
+
+// emit passes the event to the registered handler.
+func (i *Injector) emit(e Event) {
+	if i.eventHandler == nil {
+		return
+	}
+	e.Scope = i.kind
+	i.eventHandler(e)
+}
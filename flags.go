@@ -0,0 +1,78 @@
+package wireless
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// FlagSet is the set of the command line flags declared by the providers of the injector.
+// It is parsed on Resolve, before any value is constructed, and is injectable as *FlagSet afterwards.
+type FlagSet struct {
+	*flag.FlagSet
+}
+
+// Flags declares the provider of the type T returned by the function declaring the command line flags.
+// The function has a signature 'func(fs *wireless.FlagSet) T' and is called on Resolve, before the flags are parsed,
+// thus the returned value needs to refer to the flag values, e.g. by being a pointer to the struct used with the
+// flag.Var functions.
+// Example:
+//
+//	wireless.Flags(func(fs *wireless.FlagSet) *ServerFlags {
+//		f := &ServerFlags{}
+//		fs.StringVar(&f.Addr, "addr", ":8080", "server listen address")
+//		return f
+//	})
+func Flags(fn interface{}) Provider {
+	return &flagsProvider{v: fn}
+}
+
+// WithArgs sets up the command line arguments parsed by the flag set of the injector. By default, os.Args are used.
+func WithArgs(args ...string) Option {
+	return func(i *Injector) {
+		i.args = args
+	}
+}
+
+type flagsProvider struct {
+	v interface{}
+	providerOptions
+}
+
+func (f *flagsProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&f.providerOptions)
+	}
+}
+
+var flagSetType = reflect.TypeOf(new(FlagSet))
+
+func (i *Injector) resolveFlags() {
+	if len(i.errors) > 0 || len(i.flagsProviders) == 0 {
+		return
+	}
+	args := i.args
+	if args == nil {
+		args = os.Args[1:]
+	}
+	fs := &FlagSet{FlagSet: flag.NewFlagSet(os.Args[0], flag.ContinueOnError)}
+	for _, fp := range i.flagsProviders {
+		rv := reflect.ValueOf(fp.v)
+		rt := rv.Type()
+		if rv.Kind() != reflect.Func || rt.NumIn() != 1 || rt.In(0) != flagSetType || rt.NumOut() != 1 {
+			i.errors = append(i.errors, fmt.Errorf("flags provider %T is not a func(*wireless.FlagSet) T", fp.v))
+			continue
+		}
+		if _, ok := i.values[rt.Out(0)]; ok {
+			i.errors = append(i.errors, fmt.Errorf("provider for type: %s already exists", rt.Out(0)))
+			continue
+		}
+		i.values[rt.Out(0)] = rv.Call([]reflect.Value{reflect.ValueOf(fs)})[0]
+	}
+	if err := fs.Parse(args); err != nil {
+		i.errors = append(i.errors, fmt.Errorf("parsing command line flags failed: %w", err))
+		return
+	}
+	i.values[flagSetType] = reflect.ValueOf(fs)
+}
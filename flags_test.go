@@ -0,0 +1,47 @@
+package wireless
+
+import "testing"
+
+type serverFlags struct {
+	Addr    string
+	Verbose bool
+}
+
+func TestFlags(t *testing.T) {
+	i := New(WithArgs("-addr", ":9090", "-v", "extra"))
+	i.Provide(
+		Flags(func(fs *FlagSet) *serverFlags {
+			f := &serverFlags{}
+			fs.StringVar(&f.Addr, "addr", ":8080", "listen address")
+			fs.BoolVar(&f.Verbose, "v", false, "verbose")
+			return f
+		}),
+		Func(func(f *serverFlags) *testType { return &testType{v: f.Addr} }),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var s struct {
+		Flags *serverFlags
+		Type  *testType
+		Set   *FlagSet
+	}
+	err = i.Inject(&s)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if s.Flags.Addr != ":9090" || !s.Flags.Verbose || s.Type.v != ":9090" {
+		t.Errorf("Expected parsed flags injected, got %+v, %+v", s.Flags, s.Type)
+	}
+	if s.Set.NArg() != 1 || s.Set.Arg(0) != "extra" {
+		t.Errorf("Expected remaining args, got %v", s.Set.Args())
+	}
+
+	i = New(WithArgs("-unknown"))
+	i.Provide(Flags(func(fs *FlagSet) *serverFlags { return &serverFlags{} }))
+	if err = i.Resolve(); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
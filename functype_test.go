@@ -0,0 +1,72 @@
+package wireless
+
+import (
+	"testing"
+	"time"
+)
+
+type nowFunc func() time.Time
+
+type clockFunc func() time.Time
+
+func TestFunctionTypes(t *testing.T) {
+	fixed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixedNow := func() time.Time { return fixed }
+
+	i := New()
+	i.Provide(
+		InterfaceValue(new(nowFunc), fixedNow),
+		Bind(new(clockFunc), new(nowFunc)),
+		Named("zero", InterfaceValue(new(nowFunc), func() time.Time { return time.Time{} })),
+		Func(func(now clockFunc) *testType { return &testType{v: now().Format(time.RFC3339)} }),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var tt *testType
+	err = i.InjectAs(&tt)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if expected := fixed.Format(time.RFC3339); tt.v != expected {
+		t.Errorf("Expected %v, got %v", expected, tt.v)
+	}
+
+	var clock clockFunc
+	err = i.InjectAs(&clock)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if !clock().Equal(fixed) {
+		t.Errorf("Expected %v, got %v", fixed, clock())
+	}
+
+	var zero nowFunc
+	err = i.InjectNamed("zero", &zero)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if !zero().IsZero() {
+		t.Errorf("Expected zero time, got %v", zero())
+	}
+}
+
+func TestInterfaceValue(t *testing.T) {
+	i := New()
+	i.Provide(InterfaceValue(new(interfaceType), testType{v: "iface"}))
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var it interfaceType
+	err = i.InjectAs(&it)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if tt, ok := it.(testType); !ok || tt.v != "iface" {
+		t.Errorf("Expected %v, got %v", "iface", it)
+	}
+}
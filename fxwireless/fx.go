@@ -0,0 +1,92 @@
+// Package fxwireless exports the wireless providers as go.uber.org/fx options, so that the modules defined with
+// wireless might be consumed by the fx applications.
+package fxwireless
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/routercore/wireless"
+	"go.uber.org/fx"
+)
+
+var (
+	cleanupType   = reflect.TypeOf(func() {})
+	lifecycleType = reflect.TypeOf(new(fx.Lifecycle)).Elem()
+)
+
+// AsFxOption converts the providers into the fx option. The provider functions and decorators are provided
+// as the fx constructors and decorators, the values and bindings as the constructors returning them, while
+// the group and name options are translated into the fx result tags. The cleanup functions returned by
+// the provider functions are appended as the OnStop hooks of the fx lifecycle. Other providers, e.g. Curry or NoOp,
+// are reported with fx.Error.
+// Example:
+//
+//	fx.New(fxwireless.AsFxOption(repository.Providers), fx.Invoke(run))
+func AsFxOption(set wireless.ProviderSet) fx.Option {
+	var opts []fx.Option
+	for _, info := range wireless.Inspect(set) {
+		var fn interface{}
+		switch {
+		case info.Type == nil:
+			opts = append(opts, fx.Error(fmt.Errorf("provider: %T is invalid", info.Provider)))
+			continue
+		case info.Kind == wireless.KindFunc:
+			fn = withLifecycle(reflect.ValueOf(info.Func)).Interface()
+		case info.Kind == wireless.KindValue || info.Kind == wireless.KindInterfaceValue:
+			v := reflect.ValueOf(info.Value)
+			fn = reflect.MakeFunc(reflect.FuncOf(nil, []reflect.Type{info.Type}, false), func([]reflect.Value) []reflect.Value {
+				return []reflect.Value{v}
+			}).Interface()
+		case info.Kind == wireless.KindBinding:
+			to := info.Type
+			fn = reflect.MakeFunc(reflect.FuncOf([]reflect.Type{info.Target}, []reflect.Type{to}, false), func(args []reflect.Value) []reflect.Value {
+				return []reflect.Value{args[0].Convert(to)}
+			}).Interface()
+		case info.Kind == wireless.KindDecorator:
+			opts = append(opts, fx.Decorate(withLifecycle(reflect.ValueOf(info.Func)).Interface()))
+			continue
+		default:
+			opts = append(opts, fx.Error(fmt.Errorf("provider: %T is not supported by fx", info.Provider)))
+			continue
+		}
+		switch {
+		case info.Group != "":
+			fn = fx.Annotate(fn, fx.ResultTags(fmt.Sprintf(`group:"%s"`, info.Group)))
+		case info.Name != "":
+			fn = fx.Annotate(fn, fx.ResultTags(fmt.Sprintf(`name:"%s"`, info.Name)))
+		}
+		opts = append(opts, fx.Provide(fn))
+	}
+	return fx.Options(opts...)
+}
+
+// withLifecycle wraps the function returning the cleanup function into the one taking the fx.Lifecycle as the last
+// argument and appending the cleanup function as its OnStop hook.
+func withLifecycle(fn reflect.Value) reflect.Value {
+	ft := fn.Type()
+	if ft.NumOut() < 2 || ft.Out(1) != cleanupType || ft.IsVariadic() {
+		return fn
+	}
+	ins := make([]reflect.Type, ft.NumIn(), ft.NumIn()+1)
+	for j := range ins {
+		ins[j] = ft.In(j)
+	}
+	outs := []reflect.Type{ft.Out(0)}
+	if ft.NumOut() == 3 {
+		outs = append(outs, ft.Out(2))
+	}
+	n := len(ins)
+	return reflect.MakeFunc(reflect.FuncOf(append(ins, lifecycleType), outs, false), func(args []reflect.Value) []reflect.Value {
+		res := fn.Call(args[:n])
+		if !res[1].IsNil() {
+			cleanup := res[1].Interface().(func())
+			args[n].Interface().(fx.Lifecycle).Append(fx.Hook{OnStop: func(context.Context) error {
+				cleanup()
+				return nil
+			}})
+		}
+		return append(res[:1], res[2:]...)
+	})
+}
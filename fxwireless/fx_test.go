@@ -0,0 +1,49 @@
+package fxwireless
+
+import (
+	"context"
+	"testing"
+
+	"github.com/routercore/wireless"
+	"go.uber.org/fx"
+)
+
+type config struct {
+	DSN string
+}
+
+type store struct {
+	c *config
+}
+
+func TestAsFxOption(t *testing.T) {
+	var (
+		cleaned bool
+		s       *store
+	)
+	app := fx.New(
+		AsFxOption(wireless.NewSet(
+			wireless.Value(&config{DSN: "postgres://"}),
+			wireless.Func(func(c *config) (*store, func(), error) {
+				return &store{c: c}, func() { cleaned = true }, nil
+			}),
+		)),
+		fx.Populate(&s),
+		fx.NopLogger,
+	)
+	if err := app.Err(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if s.c.DSN != "postgres://" {
+		t.Errorf("Expected %v, got %v", "postgres://", s.c.DSN)
+	}
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if !cleaned {
+		t.Error("Expected the provider to be cleaned")
+	}
+}
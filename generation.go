@@ -0,0 +1,77 @@
+package wireless
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Handle is the stamp of the injected value with the generation of its type, returned by InjectHandle.
+type Handle struct {
+	Type reflect.Type
+	// Generation is the number of times the value of the type was replaced before the injection.
+	Generation uint64
+}
+
+// InjectHandle injects the value same as InjectAs and returns its Handle, which allows detecting with IsCurrent
+// whether the value was replaced since, e.g. by Swap on the configuration reload, the refresh of the TTL provider
+// or Release.
+// Example:
+//
+//	var cfg *Config
+//	h, err := i.InjectHandle(&cfg)
+//	...
+//	if !i.IsCurrent(h) {
+//		// the configuration was reloaded, but the cached cfg was not updated
+//	}
+func (i *Injector) InjectHandle(as interface{}) (Handle, error) {
+	if as == nil {
+		return Handle{}, errors.New("input injection type is nil")
+	}
+	t := reflect.TypeOf(as)
+	if t.Kind() != reflect.Ptr {
+		return Handle{}, errors.New("input injection type is not a pointer")
+	}
+	// The generation is read before the injection, so that the value replaced in between is reported as stale.
+	h := Handle{Type: t.Elem(), Generation: i.Generation(t.Elem())}
+	if err := i.InjectAs(as); err != nil {
+		return Handle{}, err
+	}
+	return h, nil
+}
+
+// IsCurrent reports whether the value injected with the handle was not replaced since. The stale handles are
+// reported with the EventStaleInjection.
+func (i *Injector) IsCurrent(h Handle) bool {
+	if h.Type == nil {
+		return false
+	}
+	if g := i.Generation(h.Type); g != h.Generation {
+		i.emit(Event{Kind: EventStaleInjection, Type: h.Type, Attempt: int(g - h.Generation)})
+		return false
+	}
+	return true
+}
+
+// Generation returns the number of times the value of the type was replaced, with the interface types bound
+// to the type of the replaced value. The types of the ancestors are looked up in the ancestors.
+func (i *Injector) Generation(t reflect.Type) uint64 {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	if bt, ok := i.bindings[t]; ok {
+		t = bt
+	}
+	_, isValue := i.values[t]
+	_, isProvided := i.providersMap[t]
+	if !isValue && !isProvided && i.parent != nil {
+		return i.parent.Generation(t)
+	}
+	return i.generations[t]
+}
+
+// nextGeneration records the replacement of the value of the type. It needs to be called with the injector lock held.
+func (i *Injector) nextGeneration(t reflect.Type) {
+	if i.generations == nil {
+		i.generations = map[reflect.Type]uint64{}
+	}
+	i.generations[t]++
+}
@@ -0,0 +1,79 @@
+package wireless
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGeneration(t *testing.T) {
+	var events []Event
+	i := New(WithEventHandler(func(e Event) { events = append(events, e) }))
+	i.Provide(
+		Value(&testType{v: "value"}),
+		Func(func() testType { return testType{v: "func"} }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	t.Run("current", func(t *testing.T) {
+		var v testType
+		h, err := i.InjectHandle(&v)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if !i.IsCurrent(h) {
+			t.Errorf("Expected current handle, got %+v", h)
+		}
+	})
+
+	t.Run("stale after swap", func(t *testing.T) {
+		var v *testType
+		h, err := i.InjectHandle(&v)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err = i.Swap(&testType{v: "swapped"}); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if i.IsCurrent(h) {
+			t.Errorf("Expected stale handle, got %+v", h)
+		}
+		if len(events) != 1 || events[0].Kind != EventStaleInjection || events[0].Attempt != 1 {
+			t.Errorf("Expected %v event, got %v", EventStaleInjection, events)
+		}
+		if h, _ = i.InjectHandle(&v); !i.IsCurrent(h) || v.v != "swapped" {
+			t.Errorf("Expected current swapped handle, got %+v %v", h, v)
+		}
+	})
+
+	t.Run("child scope", func(t *testing.T) {
+		c := i.NewScope("child")
+		if err := c.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var v testType
+		h, err := c.InjectHandle(&v)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err = i.Swap(testType{v: "swapped func"}); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if c.IsCurrent(h) {
+			t.Errorf("Expected stale handle, got %+v", h)
+		}
+	})
+
+	t.Run("status", func(t *testing.T) {
+		generations := map[reflect.Type]uint64{}
+		for _, s := range i.ProviderStatuses() {
+			generations[s.Type] = s.Generation
+		}
+		for _, typ := range []reflect.Type{reflect.TypeOf(&testType{}), reflect.TypeOf(testType{})} {
+			if generations[typ] != 1 {
+				t.Errorf("Expected %v, got %v", 1, generations[typ])
+			}
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package wireless
+
+import "testing"
+
+type repository[T any] interface {
+	Get() T
+}
+
+type memoryRepository[T any] struct {
+	v T
+}
+
+func (m *memoryRepository[T]) Get() T { return m.v }
+
+func newMemoryRepository[T any](v T) (*memoryRepository[T], error) {
+	return &memoryRepository[T]{v: v}, nil
+}
+
+func newSliceRepository[T any](vs ...T) *memoryRepository[[]T] {
+	return &memoryRepository[[]T]{v: vs}
+}
+
+func TestFuncOf(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(testType{v: "generic"}),
+		FuncOf[repository[testType]](newMemoryRepository[testType]),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var r repository[testType]
+	err = i.InjectAs(&r)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if r.Get().v != "generic" {
+		t.Errorf("Expected %v, got %v", "generic", r.Get())
+	}
+
+	t.Run("Variadic", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Value([]testType{{v: "first"}, {v: "second"}}),
+			FuncOf[repository[[]testType]](newSliceRepository[testType]),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var r repository[[]testType]
+		err = i.InjectAs(&r)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if vs := r.Get(); len(vs) != 2 || vs[1].v != "second" {
+			t.Errorf("Expected %v, got %v", "first and second", vs)
+		}
+	})
+
+	i = New()
+	i.Provide(FuncOf[repository[string]](newMemoryRepository[testType]))
+	if err = i.Resolve(); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -0,0 +1,185 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Group adds the value or provider function to the named group of values, instead of registering it by its type.
+// All the members of the group are injected at once into a slice, either with InjectGroup or into a struct field
+// tagged with 'wireless:"group=<name>"'. The members are ordered by their Weight and then by the registration order.
+// Example:
+//
+//	wireless.NewSet(
+//		wireless.Group("handlers", wireless.Func(NewUserHandler)),
+//		wireless.Group("handlers", wireless.Weight(-1, wireless.Func(NewHealthHandler))),
+//	)
+func Group(name string, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.group = name })
+	return p
+}
+
+// InjectGroup injects all the members of the named group into the input pointer to slice.
+// The members need to be assignable to the slice element type. The group might be also injected into the pointer
+// to the single value, if exactly one of its members is assignable to it, failing with the AmbiguousProviderError
+// if there are multiple such members.
+func (i *Injector) InjectGroup(name string, as interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), as, name, "", &err)
+	}
+	return i.injectGroupContext(i.context(), name, as)
+}
+
+// InjectGroupContext injects the members of the named group, same as InjectGroup, with the input context passed
+// to their construction as by InjectAsContext.
+func (i *Injector) InjectGroupContext(ctx context.Context, name string, as interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), as, name, "", &err)
+	}
+	return i.injectGroupContext(ctx, name, as)
+}
+
+func (i *Injector) injectGroupContext(ctx context.Context, name string, as interface{}) error {
+	if err := i.admit(); err != nil {
+		return err
+	}
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if !i.resolved {
+		return ErrNotResolved
+	}
+	if i.cleaned {
+		return ErrAlreadyCleaned
+	}
+	if len(i.errors) > 0 {
+		return i.errors
+	}
+	if as == nil {
+		return errors.New("input injection type is nil")
+	}
+	rVal := reflect.ValueOf(as)
+	if rVal.Kind() != reflect.Ptr {
+		return fmt.Errorf("input group injection type is not a pointer but: %T", as)
+	}
+	return i.injectGroup(ctx, name, rVal)
+}
+
+func (i *Injector) injectGroup(ctx context.Context, name string, rVal reflect.Value) error {
+	st := rVal.Type().Elem()
+	if st.Kind() != reflect.Slice {
+		return i.injectGroupMember(ctx, name, rVal)
+	}
+	members := i.groups[name]
+	slice := reflect.MakeSlice(st, 0, len(members))
+	for _, m := range members {
+		if !m.out.AssignableTo(st.Elem()) {
+			return fmt.Errorf("group: %s member of type: %s is not assignable to: %s", name, m.out, st.Elem())
+		}
+		v, err := i.instance(ctx, m)
+		if err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, v)
+	}
+	rVal.Elem().Set(slice)
+	return nil
+}
+
+// injectGroupMember injects the single member of the group assignable to the input pointer type.
+func (i *Injector) injectGroupMember(ctx context.Context, name string, rVal reflect.Value) error {
+	t := rVal.Type().Elem()
+	var candidates []*providerFunc
+	for _, m := range i.groups[name] {
+		if m.out.AssignableTo(t) {
+			candidates = append(candidates, m)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return fmt.Errorf("group: %s %w", name, notFoundError{t: t})
+	case 1:
+	default:
+		err := &AmbiguousProviderError{Type: t, Group: name}
+		for _, c := range candidates {
+			err.Candidates = append(err.Candidates, Candidate{Type: c.out, Site: memberSite(c)})
+		}
+		return err
+	}
+	v, err := i.instance(ctx, candidates[0])
+	if err != nil {
+		return err
+	}
+	rVal.Elem().Set(v)
+	return nil
+}
+
+func (i *Injector) resolveGroups() {
+	for _, p := range i.groupProviders {
+		pf, o, err := i.newMember(p)
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		i.groups[o.group] = append(i.groups[o.group], pf)
+	}
+	for _, members := range i.groups {
+		sort.SliceStable(members, func(j, k int) bool {
+			return members[j].weight < members[k].weight
+		})
+	}
+}
+
+// addMember adds the provider to the group or named providers if its options define any of them, reporting whether
+// the provider is consumed. The provider not allowed in the kind of the scope is consumed with an error.
+func (i *Injector) addMember(p Provider, o providerOptions) bool {
+	switch {
+	case !i.allowedIn(p, o):
+		// The provider not allowed in the kind of the scope is reported and dropped.
+	case o.group != "":
+		i.groupProviders = append(i.groupProviders, p)
+	case o.name != "":
+		i.namedProviders = append(i.namedProviders, p)
+	default:
+		return false
+	}
+	return true
+}
+
+// newMember creates the provider function of the group or named provider.
+func (i *Injector) newMember(p Provider) (*providerFunc, *providerOptions, error) {
+	switch pt := p.(type) {
+	case *valueProvider:
+		if pt.v == nil {
+			return nil, nil, fmt.Errorf("input value provider of the group: %q or name: %q is nil", pt.group, pt.name)
+		}
+		rv := reflect.ValueOf(pt.v)
+		if err := i.checkTypedNil(rv); err != nil {
+			return nil, nil, err
+		}
+		return &providerFunc{id: i.nextID(), out: rv.Type(), outValue: rv, weight: pt.weight}, &pt.providerOptions, nil
+	case *interfaceValueProvider:
+		if pt.value == nil {
+			return nil, nil, fmt.Errorf("input value provider of the group: %q or name: %q is nil", pt.group, pt.name)
+		}
+		it, rv, err := pt.convert()
+		if err != nil {
+			return nil, nil, err
+		}
+		return &providerFunc{id: i.nextID(), out: it, outValue: rv, weight: pt.weight}, &pt.providerOptions, nil
+	case *funcProvider:
+		pf, err := pt.providerFunc()
+		if err != nil {
+			return nil, nil, err
+		}
+		pf.id = i.nextID()
+		pf.weight = pt.weight
+		return pf, &pt.providerOptions, nil
+	default:
+		return nil, nil, fmt.Errorf("only values and provider functions might be grouped or named, but got: %T", p)
+	}
+}
@@ -0,0 +1,79 @@
+package wireless
+
+import "testing"
+
+func TestGroup(t *testing.T) {
+	newType := func(v string) func(p *testType) testType {
+		return func(p *testType) testType { return testType{v: p.v + v} }
+	}
+
+	i := New()
+	i.Provide(
+		Value(&testType{v: "handler-"}),
+		Group("handlers", Func(newType("b"))),
+		Group("handlers", Value(testType{v: "c"})),
+		Group("handlers", Weight(-1, Func(newType("a")))),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var s struct {
+		Handlers []interfaceType `wireless:"group=handlers"`
+		Empty    []testType      `wireless:"group=empty"`
+	}
+	err = i.Inject(&s)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	expected := []string{"handler-a", "handler-b", "c"}
+	if len(s.Handlers) != len(expected) {
+		t.Fatalf("Expected %d members, got %d", len(expected), len(s.Handlers))
+	}
+	for j, h := range s.Handlers {
+		if h.(testType).v != expected[j] {
+			t.Errorf("Expected %v, got %v", expected[j], h)
+		}
+	}
+	if s.Empty == nil || len(s.Empty) != 0 {
+		t.Errorf("Expected empty group, got %v", s.Empty)
+	}
+
+	var tts []testType
+	err = i.InjectGroup("handlers", &tts)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if len(tts) != len(expected) || tts[0] != s.Handlers[0] {
+		t.Errorf("Expected same group members, got %v", tts)
+	}
+}
+
+func TestGroupPooled(t *testing.T) {
+	var constructed int
+	i := New()
+	i.Provide(Group("buffers", Pooled(Func(func() *pooledBuffer {
+		constructed++
+		return &pooledBuffer{}
+	}))))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var buffers []*pooledBuffer
+	if err := i.InjectGroup("buffers", &buffers); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var buffer *pooledBuffer
+	if err := i.InjectGroup("buffers", &buffer); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if len(buffers) != 1 || buffers[0] == nil || buffer == nil || buffer == buffers[0] {
+		t.Errorf("Expected separate pooled instances, got %v and %v", buffers, buffer)
+	}
+	if constructed != 2 {
+		t.Errorf("Expected %v, got %v", 2, constructed)
+	}
+}
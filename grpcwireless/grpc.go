@@ -0,0 +1,89 @@
+// Package grpcwireless serves the remote introspection of the running wireless injector over gRPC, so that
+// the wiring of any deployed service, i.e. its providers, their dependencies, timings and the cleanup plan,
+// could be inspected with the platform tools. The service is defined in introspectionpb/introspection.proto.
+package grpcwireless
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/routercore/wireless"
+	"github.com/routercore/wireless/grpcwireless/introspectionpb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Register registers the introspection service of the injector with the gRPC server.
+// Example:
+//
+//	s := grpc.NewServer()
+//	grpcwireless.Register(s, i)
+func Register(s grpc.ServiceRegistrar, i *wireless.Injector) {
+	introspectionpb.RegisterIntrospectionServer(s, NewIntrospectionServer(i))
+}
+
+// NewIntrospectionServer creates the introspection service of the injector.
+func NewIntrospectionServer(i *wireless.Injector) introspectionpb.IntrospectionServer {
+	return &server{i: i}
+}
+
+type server struct {
+	introspectionpb.UnimplementedIntrospectionServer
+	i *wireless.Injector
+}
+
+// Providers implements introspectionpb.IntrospectionServer.
+func (s *server) Providers(context.Context, *introspectionpb.ProvidersRequest) (*introspectionpb.ProvidersResponse, error) {
+	return &introspectionpb.ProvidersResponse{State: s.i.State().String(), Providers: providers(s.i.ProviderStatuses())}, nil
+}
+
+// Timings implements introspectionpb.IntrospectionServer.
+func (s *server) Timings(context.Context, *introspectionpb.TimingsRequest) (*introspectionpb.TimingsResponse, error) {
+	r := s.i.StartupReport()
+	resp := &introspectionpb.TimingsResponse{Resolve: durationpb.New(r.Resolve), Start: durationpb.New(r.Start)}
+	for _, b := range r.Built {
+		build := &introspectionpb.Build{Type: typeString(b.Type), Provider: b.Provider, Duration: durationpb.New(b.Duration)}
+		if b.Err != nil {
+			build.Error = b.Err.Error()
+		}
+		resp.Builds = append(resp.Builds, build)
+	}
+	for _, d := range r.Decisions {
+		resp.Decisions = append(resp.Decisions, &introspectionpb.Decision{Kind: d.Kind, Type: typeString(d.Type), Provider: d.Provider})
+	}
+	return resp, nil
+}
+
+// CleanupPlan implements introspectionpb.IntrospectionServer.
+func (s *server) CleanupPlan(context.Context, *introspectionpb.CleanupPlanRequest) (*introspectionpb.CleanupPlanResponse, error) {
+	return &introspectionpb.CleanupPlanResponse{Providers: providers(s.i.CleanupOrder())}, nil
+}
+
+func providers(statuses []wireless.ProviderStatus) []*introspectionpb.Provider {
+	var ps []*introspectionpb.Provider
+	for _, st := range statuses {
+		p := &introspectionpb.Provider{
+			Type:       typeString(st.Type),
+			Group:      st.Group,
+			Name:       st.Name,
+			Provider:   st.Provider,
+			State:      st.State.String(),
+			Generation: st.Generation,
+		}
+		if st.Err != nil {
+			p.Error = st.Err.Error()
+		}
+		for _, d := range st.Dependencies {
+			p.Dependencies = append(p.Dependencies, d.String())
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+func typeString(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
@@ -0,0 +1,81 @@
+package grpcwireless
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/routercore/wireless"
+	"github.com/routercore/wireless/grpcwireless/introspectionpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type (
+	config  struct{}
+	service struct{}
+)
+
+func TestIntrospection(t *testing.T) {
+	i := wireless.New()
+	i.Provide(
+		wireless.Value(&config{}),
+		wireless.Func(func(*config) *service { return &service{} }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var s *service
+	if err := i.InjectAs(&s); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	lis := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer()
+	Register(srv, i)
+	go srv.Serve(lis)
+	defer srv.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }))
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	defer conn.Close()
+	client := introspectionpb.NewIntrospectionClient(conn)
+	ctx := context.Background()
+
+	t.Run("providers", func(t *testing.T) {
+		resp, err := client.Providers(ctx, &introspectionpb.ProvidersRequest{})
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if resp.State != "resolved" || len(resp.Providers) != 2 {
+			t.Fatalf("Expected two providers of the resolved injector, got %v", resp)
+		}
+		p := resp.Providers[1]
+		if p.Type != "*grpcwireless.service" || p.State != "constructed" || len(p.Dependencies) != 1 || p.Dependencies[0] != "*grpcwireless.config" {
+			t.Errorf("Expected the constructed service depending on the config, got %v", p)
+		}
+	})
+
+	t.Run("timings", func(t *testing.T) {
+		resp, err := client.Timings(ctx, &introspectionpb.TimingsRequest{})
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if len(resp.Builds) != 1 || resp.Builds[0].Type != "*grpcwireless.service" || resp.Builds[0].Duration == nil {
+			t.Errorf("Expected the service build, got %v", resp.Builds)
+		}
+	})
+
+	t.Run("cleanup plan", func(t *testing.T) {
+		resp, err := client.CleanupPlan(ctx, &introspectionpb.CleanupPlanRequest{})
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if len(resp.Providers) != 1 || resp.Providers[0].Type != "*grpcwireless.service" {
+			t.Errorf("Expected the service cleanup, got %v", resp.Providers)
+		}
+	})
+}
@@ -0,0 +1,5 @@
+// Package introspectionpb contains the protocol buffers and the gRPC service of the remote introspection
+// of the running wireless injector, served by grpcwireless.
+package introspectionpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative introspection.proto
@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: introspection.proto
+
+package introspectionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Introspection_Providers_FullMethodName   = "/wireless.introspection.v1.Introspection/Providers"
+	Introspection_Timings_FullMethodName     = "/wireless.introspection.v1.Introspection/Timings"
+	Introspection_CleanupPlan_FullMethodName = "/wireless.introspection.v1.Introspection/CleanupPlan"
+)
+
+// IntrospectionClient is the client API for Introspection service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Introspection exposes the wiring of the running injector.
+type IntrospectionClient interface {
+	// Providers returns the state of the injector and its providers, with their dependencies forming the graph.
+	Providers(ctx context.Context, in *ProvidersRequest, opts ...grpc.CallOption) (*ProvidersResponse, error)
+	// Timings returns the durations of the resolution, start and the constructions made so far.
+	Timings(ctx context.Context, in *TimingsRequest, opts ...grpc.CallOption) (*TimingsResponse, error)
+	// CleanupPlan returns the constructed providers in the order in which they are cleaned.
+	CleanupPlan(ctx context.Context, in *CleanupPlanRequest, opts ...grpc.CallOption) (*CleanupPlanResponse, error)
+}
+
+type introspectionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIntrospectionClient(cc grpc.ClientConnInterface) IntrospectionClient {
+	return &introspectionClient{cc}
+}
+
+func (c *introspectionClient) Providers(ctx context.Context, in *ProvidersRequest, opts ...grpc.CallOption) (*ProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProvidersResponse)
+	err := c.cc.Invoke(ctx, Introspection_Providers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *introspectionClient) Timings(ctx context.Context, in *TimingsRequest, opts ...grpc.CallOption) (*TimingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimingsResponse)
+	err := c.cc.Invoke(ctx, Introspection_Timings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *introspectionClient) CleanupPlan(ctx context.Context, in *CleanupPlanRequest, opts ...grpc.CallOption) (*CleanupPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupPlanResponse)
+	err := c.cc.Invoke(ctx, Introspection_CleanupPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IntrospectionServer is the server API for Introspection service.
+// All implementations must embed UnimplementedIntrospectionServer
+// for forward compatibility
+//
+// Introspection exposes the wiring of the running injector.
+type IntrospectionServer interface {
+	// Providers returns the state of the injector and its providers, with their dependencies forming the graph.
+	Providers(context.Context, *ProvidersRequest) (*ProvidersResponse, error)
+	// Timings returns the durations of the resolution, start and the constructions made so far.
+	Timings(context.Context, *TimingsRequest) (*TimingsResponse, error)
+	// CleanupPlan returns the constructed providers in the order in which they are cleaned.
+	CleanupPlan(context.Context, *CleanupPlanRequest) (*CleanupPlanResponse, error)
+	mustEmbedUnimplementedIntrospectionServer()
+}
+
+// UnimplementedIntrospectionServer must be embedded to have forward compatible implementations.
+type UnimplementedIntrospectionServer struct {
+}
+
+func (UnimplementedIntrospectionServer) Providers(context.Context, *ProvidersRequest) (*ProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Providers not implemented")
+}
+func (UnimplementedIntrospectionServer) Timings(context.Context, *TimingsRequest) (*TimingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Timings not implemented")
+}
+func (UnimplementedIntrospectionServer) CleanupPlan(context.Context, *CleanupPlanRequest) (*CleanupPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupPlan not implemented")
+}
+func (UnimplementedIntrospectionServer) mustEmbedUnimplementedIntrospectionServer() {}
+
+// UnsafeIntrospectionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IntrospectionServer will
+// result in compilation errors.
+type UnsafeIntrospectionServer interface {
+	mustEmbedUnimplementedIntrospectionServer()
+}
+
+func RegisterIntrospectionServer(s grpc.ServiceRegistrar, srv IntrospectionServer) {
+	s.RegisterService(&Introspection_ServiceDesc, srv)
+}
+
+func _Introspection_Providers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectionServer).Providers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Introspection_Providers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectionServer).Providers(ctx, req.(*ProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Introspection_Timings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectionServer).Timings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Introspection_Timings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectionServer).Timings(ctx, req.(*TimingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Introspection_CleanupPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectionServer).CleanupPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Introspection_CleanupPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectionServer).CleanupPlan(ctx, req.(*CleanupPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Introspection_ServiceDesc is the grpc.ServiceDesc for Introspection service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Introspection_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wireless.introspection.v1.Introspection",
+	HandlerType: (*IntrospectionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Providers",
+			Handler:    _Introspection_Providers_Handler,
+		},
+		{
+			MethodName: "Timings",
+			Handler:    _Introspection_Timings_Handler,
+		},
+		{
+			MethodName: "CleanupPlan",
+			Handler:    _Introspection_CleanupPlan_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "introspection.proto",
+}
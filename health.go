@@ -0,0 +1,38 @@
+package wireless
+
+import (
+	"context"
+	"fmt"
+)
+
+// HealthGroup is the name of the group of HealthCheck members verified by CheckHealth.
+const HealthGroup = "health"
+
+// HealthCheck is the named check of the provided instance health.
+// Example:
+//
+//	wireless.Group(wireless.HealthGroup, wireless.Func(func(db *sql.DB) wireless.HealthCheck {
+//		return wireless.HealthCheck{Name: "db", Check: db.PingContext}
+//	}))
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// CheckHealth runs all the members of the HealthGroup and returns the errors of the failed checks together.
+func (i *Injector) CheckHealth(ctx context.Context) error {
+	var checks []HealthCheck
+	if err := i.InjectGroup(HealthGroup, &checks); err != nil {
+		return err
+	}
+	var errs multiError
+	for _, c := range checks {
+		if err := c.Check(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("health check: %s failed: %w", c.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
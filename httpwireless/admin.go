@@ -0,0 +1,93 @@
+package httpwireless
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/routercore/wireless"
+)
+
+// Authorizer authorizes the request of the AdminHandler, returning an error if it is not authorized.
+type Authorizer func(r *http.Request) error
+
+// BearerToken creates the Authorizer accepting the requests with the 'Authorization: Bearer <token>' header.
+func BearerToken(token string) Authorizer {
+	return func(r *http.Request) error {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return errors.New("invalid bearer token")
+		}
+		return nil
+	}
+}
+
+// AdminHandler creates the http.Handler of the operations mutating the running injector, so that the operators
+// have the controlled runtime levers during the incidents:
+//
+//   - POST /refresh?type=<type> releases the instance of the type and its dependents, see wireless.Injector.Release,
+//     so that they are constructed again on the next injection,
+//   - POST /scopes/clean?id=<id> cleans the active scope with the identifier listed by the DebugHandler.
+//
+// All the requests need to be authorized by the Authorizer, and are rejected if it is nil.
+// Example:
+//
+//	mux.Handle("/admin/wireless/", http.StripPrefix("/admin/wireless", httpwireless.AdminHandler(i, httpwireless.BearerToken(token))))
+func AdminHandler(i *wireless.Injector, auth Authorizer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /refresh", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("type")
+		t := providedType(i, name)
+		if t == nil {
+			http.Error(w, "provider of type: "+name+" not found", http.StatusNotFound)
+			return
+		}
+		respond(w, i.ReleaseType(t))
+	})
+	mux.HandleFunc("POST /scopes/clean", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid scope id: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err = i.CleanScope(id); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		respond(w, nil)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth == nil {
+			http.Error(w, "admin operations are disabled", http.StatusForbidden)
+			return
+		}
+		if err := auth(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// providedType returns the type provided by the provider function with the name, or nil if there is none.
+func providedType(i *wireless.Injector, name string) reflect.Type {
+	for _, s := range i.ProviderStatuses() {
+		if s.Group == "" && s.Name == "" && s.Provider != "value" && s.Type.String() == name {
+			return s.Type
+		}
+	}
+	return nil
+}
+
+func respond(w http.ResponseWriter, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
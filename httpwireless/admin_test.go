@@ -0,0 +1,77 @@
+package httpwireless
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+func TestAdminHandler(t *testing.T) {
+	built := 0
+	i := wireless.New()
+	i.Provide(wireless.Func(func() *greeter {
+		built++
+		return &greeter{greeting: "hello"}
+	}))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var g *greeter
+	if err := i.InjectAs(&g); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	scope := i.NewScope(wireless.ScopeRequest)
+	h := AdminHandler(i, BearerToken("secret"))
+	serve := func(h http.Handler, method, target, token string) int {
+		req := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		if code := serve(h, http.MethodPost, "/refresh?type=*httpwireless.greeter", "wrong"); code != http.StatusUnauthorized {
+			t.Errorf("Expected %v, got %v", http.StatusUnauthorized, code)
+		}
+		if code := serve(AdminHandler(i, nil), http.MethodPost, "/refresh?type=*httpwireless.greeter", "secret"); code != http.StatusForbidden {
+			t.Errorf("Expected %v, got %v", http.StatusForbidden, code)
+		}
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		if code := serve(h, http.MethodGet, "/refresh?type=*httpwireless.greeter", "secret"); code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %v, got %v", http.StatusMethodNotAllowed, code)
+		}
+		if code := serve(h, http.MethodPost, "/refresh?type=*httpwireless.missing", "secret"); code != http.StatusNotFound {
+			t.Errorf("Expected %v, got %v", http.StatusNotFound, code)
+		}
+		if code := serve(h, http.MethodPost, "/refresh?type=*httpwireless.greeter", "secret"); code != http.StatusOK {
+			t.Errorf("Expected %v, got %v", http.StatusOK, code)
+		}
+		if err := i.InjectAs(&g); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if built != 2 {
+			t.Errorf("Expected %v, got %v", 2, built)
+		}
+	})
+
+	t.Run("clean scope", func(t *testing.T) {
+		id := strconv.FormatInt(Debug(i).Scopes.List[0].ID, 10)
+		if code := serve(h, http.MethodPost, "/scopes/clean?id="+id, "secret"); code != http.StatusOK {
+			t.Errorf("Expected %v, got %v", http.StatusOK, code)
+		}
+		if scope.State() != wireless.StateCleaned {
+			t.Errorf("Expected %v, got %v", wireless.StateCleaned, scope.State())
+		}
+		if code := serve(h, http.MethodPost, "/scopes/clean?id="+id, "secret"); code != http.StatusNotFound {
+			t.Errorf("Expected %v, got %v", http.StatusNotFound, code)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package httpwireless
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/routercore/wireless"
+)
+
+// DebugInfo is the JSON document served by the DebugHandler.
+type DebugInfo struct {
+	State     string          `json:"state"`
+	Scopes    DebugScopes     `json:"scopes"`
+	Providers []DebugProvider `json:"providers"`
+	// Injections are the injection requests recorded by the injector with wireless.WithAuditTrail.
+	Injections []DebugInjection `json:"injections,omitempty"`
+}
+
+// DebugScopes describes the active child scopes of the injector.
+type DebugScopes struct {
+	Active int            `json:"active"`
+	ByKind map[string]int `json:"byKind"`
+	Oldest time.Duration  `json:"oldest"`
+	List   []DebugScope   `json:"list"`
+}
+
+// DebugScope describes the active child scope.
+type DebugScope struct {
+	// ID is the identifier of the scope, which might be cleaned with the AdminHandler.
+	ID      int64         `json:"id"`
+	Kind    string        `json:"kind"`
+	Depth   int           `json:"depth"`
+	Created time.Time     `json:"created"`
+	Age     time.Duration `json:"age"`
+}
+
+// DebugProvider describes the state of the provider.
+type DebugProvider struct {
+	Type     string `json:"type"`
+	Group    string `json:"group,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Error    string `json:"error,omitempty"`
+	// Generation is the number of times the value was replaced, e.g. by the configuration reload.
+	Generation uint64 `json:"generation,omitempty"`
+}
+
+// DebugInjection describes the injection request recorded in the audit trail.
+type DebugInjection struct {
+	Type   string    `json:"type"`
+	Group  string    `json:"group,omitempty"`
+	Name   string    `json:"name,omitempty"`
+	Caller string    `json:"caller"`
+	Time   time.Time `json:"time"`
+	Scope  string    `json:"scope"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// DebugHandler creates the http.Handler serving the DebugInfo of the injector as JSON, with the states
+// of its providers, the active child scopes and the recorded injection requests, so that e.g. the leaked request scopes are visible.
+// Example:
+//
+//	mux.Handle("/debug/wireless", httpwireless.DebugHandler(i))
+func DebugHandler(i *wireless.Injector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(Debug(i)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// Debug describes the current state of the injector.
+func Debug(i *wireless.Injector) DebugInfo {
+	info := DebugInfo{State: i.State().String(), Scopes: DebugScopes{ByKind: map[string]int{}, List: []DebugScope{}}}
+	for _, s := range i.ActiveScopes() {
+		info.Scopes.Active++
+		info.Scopes.ByKind[s.Kind]++
+		if s.Age > info.Scopes.Oldest {
+			info.Scopes.Oldest = s.Age
+		}
+		info.Scopes.List = append(info.Scopes.List, DebugScope{ID: s.ID, Kind: s.Kind, Depth: s.Depth, Created: s.Created, Age: s.Age})
+	}
+	for _, s := range i.ProviderStatuses() {
+		p := DebugProvider{
+			Type: s.Type.String(), Group: s.Group, Name: s.Name, Provider: s.Provider, State: s.State.String(), Generation: s.Generation,
+		}
+		if s.Err != nil {
+			p.Error = s.Err.Error()
+		}
+		info.Providers = append(info.Providers, p)
+	}
+	for _, r := range i.Injections() {
+		in := DebugInjection{Group: r.Group, Name: r.Name, Caller: r.Caller, Time: r.Time, Scope: r.Scope}
+		if r.Type != nil {
+			in.Type = r.Type.String()
+		}
+		if r.Err != nil {
+			in.Error = r.Err.Error()
+		}
+		info.Injections = append(info.Injections, in)
+	}
+	return info
+}
@@ -0,0 +1,47 @@
+package httpwireless
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+func TestDebugHandler(t *testing.T) {
+	i := wireless.New(wireless.WithAuditTrail(8))
+	i.Provide(wireless.Value(&greeter{greeting: "hello"}))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	i.NewScope(wireless.ScopeRequest)
+	s := i.NewScope(wireless.ScopeRequest)
+	if err := s.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var g *greeter
+	if err := s.InjectAs(&g); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	i.NewScope(wireless.ScopeJob).Clean()
+
+	rec := httptest.NewRecorder()
+	DebugHandler(i).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var info DebugInfo
+	if err := json.NewDecoder(rec.Body).Decode(&info); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if info.State != "resolved" {
+		t.Errorf("Expected %v, got %v", "resolved", info.State)
+	}
+	if info.Scopes.Active != 2 || info.Scopes.ByKind[wireless.ScopeRequest] != 2 || len(info.Scopes.List) != 2 {
+		t.Errorf("Expected two active request scopes, got %v", info.Scopes)
+	}
+	if len(info.Providers) != 1 || info.Providers[0].Type != "*httpwireless.greeter" || info.Providers[0].State != "constructed" {
+		t.Errorf("Expected the greeter value, got %v", info.Providers)
+	}
+	if len(info.Injections) != 1 || info.Injections[0].Type != "*httpwireless.greeter" || info.Injections[0].Scope != wireless.ScopeRequest {
+		t.Errorf("Expected the greeter injection, got %v", info.Injections)
+	}
+}
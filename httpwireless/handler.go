@@ -0,0 +1,135 @@
+// Package httpwireless provides net/http adapters for the functions with dependencies injected by wireless.Injector.
+package httpwireless
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/routercore/wireless"
+)
+
+var (
+	responseWriterType = reflect.TypeOf(new(http.ResponseWriter)).Elem()
+	requestType        = reflect.TypeOf(new(http.Request))
+	errorType          = reflect.TypeOf(new(error)).Elem()
+)
+
+// Handler creates the http.Handler from the function taking its dependencies followed by the http.ResponseWriter
+// and *http.Request arguments. Each request is served within its own wireless.ScopeRequest scope, which provides
+// the *http.Request, and which is closed once the function returns.
+// The dependencies provided by the injector are thus constructed once and shared, while the ones scoped
+// to wireless.ScopeRequest are constructed per request, with the request context. The dependencies are checked
+// when the handler is created, by currying the function with wireless.Curry. The function might optionally return
+// an error, which, as the failures to inject the dependencies, is reported as wireless.EventRequestFailed to the
+// event handler of the injector, while the client only receives the internal server error status.
+// Example:
+//
+//	i.Provide(wireless.ScopedTo(wireless.ScopeRequest, wireless.Func(func(r *http.Request) *User { ... })))
+//	h, err := httpwireless.Handler(i, func(s *Store, u *User, w http.ResponseWriter, r *http.Request) { ... })
+func Handler(i *wireless.Injector, fn interface{}) (http.Handler, error) {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func {
+		return nil, fmt.Errorf("handler %T is not a function", fn)
+	}
+	rt := rv.Type()
+	n := rt.NumIn()
+	if n < 2 || rt.In(n-2) != responseWriterType || rt.In(n-1) != requestType {
+		return nil, fmt.Errorf("handler %T last arguments are expected to be http.ResponseWriter and *http.Request", fn)
+	}
+	if rt.NumOut() > 1 || rt.NumOut() == 1 && rt.Out(0) != errorType {
+		return nil, fmt.Errorf("handler %T might only return an error", fn)
+	}
+	outs := make([]reflect.Type, rt.NumOut())
+	for j := range outs {
+		outs[j] = rt.Out(j)
+	}
+	deps := make([]reflect.Type, n-2)
+	for j := range deps {
+		deps[j] = rt.In(j)
+	}
+	h := &handler{i: i, fn: fn, curried: reflect.FuncOf([]reflect.Type{responseWriterType, requestType}, outs, false), deps: deps}
+	if err := h.check(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// handler serves the requests with the function whose dependencies are injected from the request scope.
+type handler struct {
+	i       *wireless.Injector
+	fn      interface{}
+	curried reflect.Type
+	deps    []reflect.Type
+}
+
+// scope creates the resolved request scope of the request.
+func (h *handler) scope(r *http.Request, providers ...wireless.Provider) (*wireless.Injector, error) {
+	s := h.i.NewScope(wireless.ScopeRequest)
+	s.Provide(wireless.Value(r))
+	s.Provide(providers...)
+	if err := s.ResolveContext(r.Context()); err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("handler %T request scope: %w", h.fn, err)
+	}
+	return s, nil
+}
+
+// check reports the first dependency of the function which could not be resolved in the request scope.
+// The function is curried once, so that the requests only inject its dependencies.
+func (h *handler) check() error {
+	s, err := h.scope(new(http.Request), wireless.Curry(h.fn))
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	for _, st := range s.ProviderStatuses() {
+		t := st.Type
+		if t == h.curried {
+			return nil
+		}
+		// The function is curried only up to its first dependency which is not provided.
+		if t.Kind() == reflect.Func && t.NumIn() > 2 && t.In(t.NumIn()-1) == requestType && st.Provider != "value" {
+			return fmt.Errorf("handler %T dependency: %s has no provider", h.fn, t.In(0))
+		}
+	}
+	return fmt.Errorf("handler %T could not be curried", h.fn)
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s, err := h.scope(r)
+	if err != nil {
+		h.fail(h.i, w, err)
+		return
+	}
+	defer s.Close()
+	in := make([]reflect.Value, len(h.deps), len(h.deps)+2)
+	for j, t := range h.deps {
+		dep := reflect.New(t)
+		if err := s.InjectAsContext(r.Context(), dep.Interface()); err != nil {
+			h.fail(s, w, fmt.Errorf("handler %T dependency: %w", h.fn, err))
+			return
+		}
+		in[j] = dep.Elem()
+	}
+	outs := reflect.ValueOf(h.fn).Call(append(in, reflect.ValueOf(w), reflect.ValueOf(r)))
+	if len(outs) == 1 && !outs[0].IsNil() {
+		h.fail(s, w, outs[0].Interface().(error))
+	}
+}
+
+// fail reports the error of the request and writes the internal server error.
+func (h *handler) fail(i *wireless.Injector, w http.ResponseWriter, err error) {
+	i.Emit(wireless.Event{Kind: wireless.EventRequestFailed, Provider: fmt.Sprintf("%T", h.fn), Err: err})
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// MustHandler is like Handler but panics if the handler could not be created.
+func MustHandler(i *wireless.Injector, fn interface{}) http.Handler {
+	h, err := Handler(i, fn)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
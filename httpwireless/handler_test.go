@@ -0,0 +1,103 @@
+package httpwireless
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+type greeter struct{ greeting string }
+
+func TestHandler(t *testing.T) {
+	var events []wireless.Event
+	i := wireless.New(wireless.WithEventHandler(func(e wireless.Event) {
+		if e.Kind == wireless.EventRequestFailed {
+			events = append(events, e)
+		}
+	}))
+	i.Provide(wireless.Value(&greeter{greeting: "hello"}))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	h, err := Handler(i, func(g *greeter, w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Query().Get("fail") != "" {
+			return errors.New("failed")
+		}
+		_, err := w.Write([]byte(g.greeting))
+		return err
+	})
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Body.String() != "hello" {
+		t.Errorf("Expected %v, got %v", "hello", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?fail=1", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected %v, got %v", http.StatusInternalServerError, rec.Code)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != http.StatusText(http.StatusInternalServerError) {
+		t.Errorf("Expected %v, got %v", http.StatusText(http.StatusInternalServerError), body)
+	}
+	if len(events) != 1 || events[0].Err == nil || events[0].Err.Error() != "failed" || events[0].Scope != wireless.ScopeRequest {
+		t.Errorf("Expected %v, got %v", "failed", events)
+	}
+
+	if _, err = Handler(i, func(w http.ResponseWriter) {}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+type principal struct{ name string }
+
+func TestHandlerRequestScope(t *testing.T) {
+	var constructed, cleaned int
+	i := wireless.New()
+	i.Provide(
+		wireless.Value(&greeter{greeting: "hello"}),
+		wireless.ScopedTo(wireless.ScopeRequest, wireless.Func(func(r *http.Request) (*principal, func()) {
+			constructed++
+			return &principal{name: r.Header.Get("X-User")}, func() { cleaned++ }
+		})),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	h, err := Handler(i, func(g *greeter, p *principal, w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(g.greeting + " " + p.name))
+	})
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	for _, user := range []string{"alice", "bob"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User", user)
+		h.ServeHTTP(rec, req)
+		if rec.Body.String() != "hello "+user {
+			t.Errorf("Expected %v, got %v", "hello "+user, rec.Body.String())
+		}
+	}
+	if constructed != 2 || cleaned != 2 {
+		t.Errorf("Expected the principal constructed and cleaned per request, got %d, %d", constructed, cleaned)
+	}
+	if scopes := i.ActiveScopes(); len(scopes) != 0 {
+		t.Errorf("Expected the request scopes closed, got %v", scopes)
+	}
+
+	_, err = Handler(i, func(g *greeter, tk *strings.Builder, w http.ResponseWriter, r *http.Request) {})
+	if err == nil || !strings.Contains(err.Error(), "*strings.Builder has no provider") {
+		t.Errorf("Expected the missing dependency error, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package httpwireless
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/routercore/wireless"
+)
+
+// DefaultShutdownTimeout is the graceful shutdown timeout used if the ServerConfig does not define one.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// RoutesGroup is the name of the group of RouteRegistrar members served by the Routes handler.
+const RoutesGroup = "httpwireless.routes"
+
+// ServerConfig is the configuration of the *http.Server.
+type ServerConfig struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+}
+
+// RouteRegistrar is implemented by the members of the RoutesGroup registering their routes in the mux.
+type RouteRegistrar interface {
+	RegisterRoutes(mux *http.ServeMux)
+}
+
+// ServerModule declares the *http.Server provider requiring the injected *ServerConfig and http.Handler.
+// The server starts listening when the injector is started, serves in the background and is gracefully shut down
+// when the injector is stopped.
+// Example:
+//
+//	i.Provide(
+//		wireless.Env[httpwireless.ServerConfig]("HTTP"),
+//		httpwireless.ServerModule(),
+//		httpwireless.RoutesModule(),
+//		wireless.Group(httpwireless.RoutesGroup, wireless.Func(NewUserRoutes)),
+//	)
+func ServerModule() wireless.ProviderSet {
+	return wireless.NewSet(wireless.Func(NewServer))
+}
+
+// RoutesModule declares the http.Handler provider serving the routes of all the RoutesGroup members.
+func RoutesModule() wireless.ProviderSet {
+	return wireless.NewSet(wireless.Func(Routes))
+}
+
+// Routes creates the *http.ServeMux with the routes of all the RoutesGroup members registered.
+func Routes(i *wireless.Injector) (http.Handler, error) {
+	var registrars []RouteRegistrar
+	if err := i.InjectGroup(RoutesGroup, &registrars); err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	for _, r := range registrars {
+		r.RegisterRoutes(mux)
+	}
+	return mux, nil
+}
+
+// NewServer creates the server of the handler and appends its lifecycle hooks.
+func NewServer(c *ServerConfig, h http.Handler, lc *wireless.Lifecycle) *http.Server {
+	srv := &http.Server{
+		Addr:              c.Addr,
+		Handler:           h,
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		ReadTimeout:       c.ReadTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+	}
+	r := &serverRunner{srv: srv, shutdownTimeout: c.ShutdownTimeout}
+	if r.shutdownTimeout <= 0 {
+		r.shutdownTimeout = DefaultShutdownTimeout
+	}
+	lc.Append(wireless.Hook{OnStart: r.listen})
+	lc.AppendRunner(r)
+	return srv
+}
+
+// serverRunner serves the server between the injector start and stop.
+type serverRunner struct {
+	srv             *http.Server
+	ln              net.Listener
+	shutdownTimeout time.Duration
+}
+
+// listen opens the listener on start, so that the address errors fail the start.
+func (r *serverRunner) listen(ctx context.Context) error {
+	addr := r.srv.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	r.ln = ln
+	return nil
+}
+
+// Run serves until the context is canceled and then gracefully shuts the server down.
+func (r *serverRunner) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.srv.Serve(r.ln)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.shutdownTimeout)
+	defer cancel()
+	if err := r.srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
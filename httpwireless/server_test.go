@@ -0,0 +1,63 @@
+package httpwireless
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+type pingRoutes struct{}
+
+func (pingRoutes) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "pong")
+	})
+}
+
+func TestServerModule(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	i := wireless.New()
+	i.Provide(
+		wireless.Value(&ServerConfig{Addr: addr}),
+		ServerModule(),
+		RoutesModule(),
+		wireless.Group(RoutesGroup, wireless.Value(pingRoutes{})),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var srv *http.Server
+	if err := i.InjectAs(&srv); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.Start(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	resp, err := http.Get("http://" + addr + "/ping")
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != "pong" {
+		t.Errorf("Expected %v, got %v", "pong", string(body))
+	}
+
+	if err := i.Stop(context.Background()); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if _, err := http.Get("http://" + addr + "/ping"); err == nil {
+		t.Error("Expected connection error after stop, got nil")
+	}
+}
@@ -0,0 +1,34 @@
+package wireless
+
+import (
+	"context"
+	"reflect"
+)
+
+// Initializer is implemented by the types that need a second initialization phase after they are constructed
+// by the provider function or have their fields injected with Inject.
+type Initializer interface {
+	Init(ctx context.Context) error
+}
+
+// AfterInjector is implemented by the types that need to be notified after they are constructed by the provider
+// function or have their fields injected with Inject.
+type AfterInjector interface {
+	AfterInject()
+}
+
+// initialize calls the Init and AfterInject methods of the value, if it implements any of them.
+func (i *Injector) initialize(ctx context.Context, v reflect.Value) error {
+	if isNil(v) || !v.CanInterface() {
+		return nil
+	}
+	if in, ok := v.Interface().(Initializer); ok {
+		if err := in.Init(ctx); err != nil {
+			return err
+		}
+	}
+	if ai, ok := v.Interface().(AfterInjector); ok {
+		ai.AfterInject()
+	}
+	return nil
+}
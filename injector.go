@@ -1,16 +1,23 @@
 package wireless
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 var (
 	errorType   = reflect.TypeOf(new(error)).Elem()
+	contextType = reflect.TypeOf(new(context.Context)).Elem()
 	cleanupFunc = reflect.FuncOf(nil, nil, false)
 )
 
@@ -19,16 +26,35 @@ var (
 	ErrAlreadyResolved = errors.New("injector already resolved")
 	ErrNotResolved     = errors.New("injector not resolved")
 	ErrAlreadyCleaned  = errors.New("injector already cleaned")
+	// ErrShuttingDown is returned by the injections into the injector which is being cleaned or closed.
+	ErrShuttingDown = errors.New("injector is shutting down")
+	// ErrProviderNotFound is matched by the errors returned when there is no provider for the injected type.
+	ErrProviderNotFound = errors.New("provider not found")
 )
 
+// Option is the injector configuration option.
+type Option func(i *Injector)
+
 // New creates a new injector.
-func New() *Injector {
+func New(options ...Option) *Injector {
 	i := &Injector{
 		values:       map[reflect.Type]reflect.Value{},
 		providersMap: map[reflect.Type]*providerFunc{},
 		bindings:     map[reflect.Type]reflect.Type{},
+		groups:       map[string][]*providerFunc{},
+		named:        map[namedKey]*providerFunc{},
+		scoped:       map[reflect.Type]*funcProvider{},
+		lifecycle:    &Lifecycle{},
+		created:      time.Now(),
 	}
+	i.lifecycle.emit = i.emit
 	i.values[reflect.TypeOf(i)] = reflect.ValueOf(i)
+	i.values[resolverType] = reflect.ValueOf(i).Convert(resolverType)
+	i.values[reflect.TypeOf(i.lifecycle)] = reflect.ValueOf(i.lifecycle)
+	i.values[releaseFuncType] = reflect.ValueOf(ReleaseFunc(i.release))
+	for _, o := range options {
+		o(i)
+	}
 	return i
 }
 
@@ -36,31 +62,129 @@ func New() *Injector {
 type Injector struct {
 	id            int64
 	lock          sync.RWMutex
+	funcsLock     sync.Mutex
 	resolved      bool
 	values        map[reflect.Type]reflect.Value
 	providersMap  map[reflect.Type]*providerFunc
 	providerFuncs []*providerFunc
 	bindings      map[reflect.Type]reflect.Type
+	nonShared     []nonSharedBinding
 
 	valueProviders          []*valueProvider
 	bindingProviders        []*bindingProvider
+	bindFuncProviders       []*bindFuncProvider
 	funcProviders           []*funcProvider
 	interfaceValueProviders []*interfaceValueProvider
+	decoratorProviders      []*decoratorProvider
+	interceptorProviders    []*interceptorProvider
+	noOpProviders           []*noOpProvider
+	curryProviders          []*curryProvider
+	fieldsProviders         []*fieldsProvider
+	flagsProviders          []*flagsProvider
+	postProcessors          []PostProcessor
+	groupProviders          []Provider
+	groups                  map[string][]*providerFunc
+	namedProviders          []Provider
+	named                   map[namedKey]*providerFunc
+	declarations            []Provider
+	rewriters               []Rewriter
+
+	ctx               context.Context
+	cancel            context.CancelFunc
+	lifecycle         *Lifecycle
+	parent            *Injector
+	kind              string
+	scopeKinds        map[string]bool
+	scoped            map[reflect.Type]*funcProvider
+	eventHandler      func(Event)
+	strictShadowing   bool
+	propagatePanics   bool
+	validator         Validator
+	disallowNilOutput bool
+	unexportedFields  bool
+	idempotentResolve bool
+	typedNilValues    bool
+	autoBind          bool
+	errorPolicy       ErrorPolicy
+	setterInjection   bool
+	strictPrimitives  bool
+	injectorAllowlist map[reflect.Type]bool
+	configTypes       map[reflect.Type]bool
+	maxDependencies   int
+	maxFanIn          int
+	policies          []dependencyPolicy
+	layerRules        []LayerRule
+	args              []string
+	watchLock         sync.Mutex
+	sessionsLock      sync.Mutex
+	sessions          map[interface{}]*Injector
+	created           time.Time
+	scopeID           int64
+	childrenLock      sync.Mutex
+	children          map[*Injector]struct{}
+	leakAfter         time.Duration
+	leakTimer         *time.Timer
+	watchers          map[reflect.Type][]*watcher
+
+	errors      multiError
+	cleanErrors multiError
+	report      reportState
+	overridden  map[reflect.Type]bool
+	defaults    map[reflect.Type]bool
+	sites       map[reflect.Type]ProviderSite
+	trail       *auditTrail
+	generations map[reflect.Type]uint64
+	cleaned     bool
+	cleanOnce   sync.Once
+	closing     atomic.Bool
+	poisoned    atomic.Value
 
-	errors  multiError
-	cleaned bool
+	// cleanErrorsLock guards the cleanErrors, which the cleanups append to under the read lock as well, when
+	// the construction fails.
+	cleanErrorsLock sync.Mutex
 }
 
 // Inject tries to inject all the fields within provided input pointer to struct.
 // In order to omit a field it might use a struct field tag: 'wireless:"-"'.
+// Fields of the struct typed field, or a pointer to struct, tagged with 'wireless:"dive"' are injected recursively,
+// instead of injecting the field as a whole. Embedded fields are injected as a whole if there is a provider for their
+// type, otherwise their fields are injected recursively.
+// Slice fields tagged with 'wireless:"group=<name>"' are populated with all the members of the named group.
+// Fields tagged with 'wireless:"name=<name>"' are injected with the named provider and 'map[string]T' fields
+// tagged with 'wireless:"named"' are populated with all the named providers of type T.
+// Fields tagged with 'wireless:"optional"' are left with zero value if there is no provider for their type.
+// Setter methods are called as described in WithSetterInjection.
+// Unexported fields are injected only if they are tagged with 'wireless:"inject"' or the injector is created
+// with WithUnexportedFields option.
 // Example:
 //
 //	type ExampleType struct {
 //		InjectMe 	*OtherType
 //		SkipMe 		*DifferentType `wireless:"-"
+//		Nested 		NestedType `wireless:"dive"`
 //		skipPrivate *PrivateType
 //	}
-func (i *Injector) Inject(in interface{}) error {
+func (i *Injector) Inject(in interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), in, "", "", &err)
+	}
+	return i.inject(i.context(), in)
+}
+
+// InjectContext injects the fields of the input pointer to struct, same as Inject, while the provider functions
+// and initializers of the values constructed by this injection get the values of the input context. The injection
+// stops with the context error once the context is done.
+func (i *Injector) InjectContext(ctx context.Context, in interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), in, "", "", &err)
+	}
+	return i.inject(ctx, in)
+}
+
+func (i *Injector) inject(ctx context.Context, in interface{}) error {
+	if err := i.admit(); err != nil {
+		return err
+	}
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 	if !i.resolved {
@@ -82,29 +206,184 @@ func (i *Injector) Inject(in interface{}) error {
 	if rv.Type().Kind() != reflect.Struct {
 		return fmt.Errorf("input injection type is not a pointer to the struct but: %T", in)
 	}
+	if err := i.injectFields(ctx, rv, nil); err != nil {
+		return err
+	}
+	if err := i.injectSetters(ctx, reflect.ValueOf(in)); err != nil {
+		return err
+	}
+	if err := i.initialize(ctx, reflect.ValueOf(in)); err != nil {
+		return fmt.Errorf("initialization of the injected %T failed: %w", in, err)
+	}
+	return nil
+}
+
+// injectFields injects the fields of the addressable struct value, dived into from the structs of the types
+// on the path.
+func (i *Injector) injectFields(ctx context.Context, rv reflect.Value, path []reflect.Type) error {
+	path = append(path, rv.Type())
 	for j := 0; j < rv.NumField(); j++ {
 		fv := rv.Field(j)
 		ft := rv.Type().Field(j)
-		if !ft.IsExported() {
+		tag := parseTag(ft.Tag)
+		if tag.skip {
+			continue
+		}
+		exported := ft.IsExported()
+		if !exported && (i.unexportedFields || tag.inject) {
+			fv = reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+			exported = true
+		}
+		if ft.Anonymous && (tag.dive || !i.hasProvider(ft.Type)) && isStructOrPtr(ft.Type) {
+			// The promoted fields of unexported embedded struct are still settable.
+			if !exported && fv.Kind() == reflect.Ptr && fv.IsNil() {
+				continue
+			}
+			if err := i.diveField(ctx, fv, ft, path); err != nil {
+				return err
+			}
 			continue
 		}
-		if tv := ft.Tag.Get("wireless"); tv == "-" {
+		if !exported {
+			continue
+		}
+		if tag.dive {
+			if err := i.diveField(ctx, fv, ft, path); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag.group != "" {
+			if err := i.injectGroup(ctx, tag.group, fv.Addr()); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag.named {
+			if err := i.injectNamedMap(ctx, fv.Addr()); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag.name != "" {
+			if err := i.injectNamed(ctx, tag.name, fv.Addr()); err != nil {
+				if tag.optional && errors.Is(err, ErrProviderNotFound) {
+					continue
+				}
+				return err
+			}
 			continue
 		}
 		fv = fv.Addr()
-		if err := i.injectAs(fv); err != nil {
+		if err := i.injectAs(ctx, fv); err != nil {
+			if tag.optional && errors.Is(err, ErrProviderNotFound) {
+				continue
+			}
 			return err
 		}
 	}
-	// Sort the providers again to have the least dependent be on the end.
-	sort.Slice(i.providerFuncs, func(j, k int) bool {
-		return i.providerFuncs[j].depth < i.providerFuncs[k].depth
-	})
 	return nil
 }
 
+// WithTypedNilValues allows providing the nil pointers, functions, channels and maps wrapped in the non-nil
+// interface as the values, which are refused by default, as they would fail only when used by their dependents.
+func WithTypedNilValues() Option {
+	return func(i *Injector) {
+		i.typedNilValues = true
+	}
+}
+
+// checkTypedNil returns the error if the value is the typed nil, unless they are allowed.
+func (i *Injector) checkTypedNil(v reflect.Value) error {
+	if i.typedNilValues || v.Kind() == reflect.Slice || !isNil(v) {
+		return nil
+	}
+	return fmt.Errorf("input value provider of type: %s is a typed nil", v.Type())
+}
+
+// WithUnexportedFields makes Inject populate the unexported struct fields as well as the exported ones.
+func WithUnexportedFields() Option {
+	return func(i *Injector) {
+		i.unexportedFields = true
+	}
+}
+
+// WithIdempotentResolve makes Resolve and ResolveContext of the already resolved injector a no-op, instead of
+// returning ErrAlreadyResolved, so that multiple components might resolve the shared injector defensively.
+func WithIdempotentResolve() Option {
+	return func(i *Injector) {
+		i.idempotentResolve = true
+	}
+}
+
+// hasProvider checks if there is any provider for given type.
+func (i *Injector) hasProvider(t reflect.Type) bool {
+	if _, ok := i.values[t]; ok {
+		return true
+	}
+	if _, ok := i.providersMap[t]; ok {
+		return true
+	}
+	if _, ok := i.bindings[t]; ok {
+		return true
+	}
+	return i.parent != nil && i.parent.hasProvider(t)
+}
+
+func isStructOrPtr(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+}
+
+// diveField injects the fields of the struct or pointer to struct field, allocating it if needed.
+// The field of the type already on the path of the dived structs is refused, as it would be allocated endlessly.
+func (i *Injector) diveField(ctx context.Context, fv reflect.Value, ft reflect.StructField, path []reflect.Type) error {
+	if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+		if slices.Contains(path, fv.Type().Elem()) {
+			return fmt.Errorf("field %s tagged with 'dive' refers to the enclosing type: %s", ft.Name, fv.Type().Elem())
+		}
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	if fv.Kind() != reflect.Struct {
+		return fmt.Errorf("field %s tagged with 'dive' is not a struct nor a pointer to struct but: %s", ft.Name, ft.Type)
+	}
+	return i.injectFields(ctx, fv, path)
+}
+
 // InjectAs gets the injector for the input pointer to type.
-func (i *Injector) InjectAs(as interface{}) error {
+func (i *Injector) InjectAs(as interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), as, "", "", &err)
+	}
+	return i.injectAsContext(i.context(), as)
+}
+
+// InjectAsContext gets the injector for the input pointer to type, same as InjectAs, while the provider functions
+// and initializers of the values constructed by this injection get the values of the input context. The shared
+// instances are constructed with the context without its cancellation, as they outlive the injection.
+// Once the context is done, the injection stops instead of waiting for the construction by other injection,
+// failing with the context error, or for the next retry, in which case the input pointer is left untouched.
+// The provider function being called is not interrupted.
+// Example:
+//
+//	var repo Repository
+//	err := i.InjectAsContext(r.Context(), &repo)
+func (i *Injector) InjectAsContext(ctx context.Context, as interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), as, "", "", &err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return i.injectAsContext(ctx, as)
+}
+
+func (i *Injector) injectAsContext(ctx context.Context, as interface{}) error {
+	if err := i.admit(); err != nil {
+		return err
+	}
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 
@@ -125,20 +404,19 @@ func (i *Injector) InjectAs(as interface{}) error {
 	if rVal.Kind() != reflect.Ptr {
 		return errors.New("input injection type is not a pointer")
 	}
-	err := i.injectAs(rVal)
+	err := i.injectAs(ctx, rVal)
 	if err != nil {
 		return err
 	}
 
-	// Sort the providers again to have the least dependent be on the end.
-	sort.Slice(i.providerFuncs, func(j, k int) bool {
-		return i.providerFuncs[j].depth < i.providerFuncs[k].depth
-	})
 	return nil
 }
 
-func (i *Injector) injectAs(rVal reflect.Value) error {
+func (i *Injector) injectAs(ctx context.Context, rVal reflect.Value) error {
 	elem := rVal.Type().Elem()
+	if i.strictPrimitives && isPrimitive(elem) {
+		return fmt.Errorf("injection of the basic type: %s requires a name in the strict primitives mode", elem)
+	}
 	provider, ok := i.values[elem]
 	if ok {
 		rVal.Elem().Set(provider)
@@ -148,68 +426,260 @@ func (i *Injector) injectAs(rVal reflect.Value) error {
 	if !ok {
 		bv, ok := i.bindings[elem]
 		if !ok {
-			return fmt.Errorf("injector not found for the type: %s", elem)
+			bt, err := i.autoBound(elem)
+			if err != nil {
+				return err
+			}
+			bv, ok = bt, bt != nil
+		}
+		if !ok {
+			if i.parent != nil {
+				return i.parent.injectAsContext(ctx, rVal.Interface())
+			}
+			if err := i.scopedError(elem); err != nil {
+				return err
+			}
+			return notFoundError{t: elem}
 		}
 		provider, ok = i.values[bv]
 		if ok {
-			rVal.Elem().Set(provider)
+			rVal.Elem().Set(provider.Convert(elem))
 			return nil
 		}
 		pf, ok = i.providersMap[bv]
 		if !ok {
-			return fmt.Errorf("injector not found for the type: %s", elem)
+			return notFoundError{t: elem}
 		}
 	}
-	// Check if the value of the provider set is already resolved.
-	if pf.outValue.IsValid() {
-		rVal.Elem().Set(pf.outValue)
-		return nil
-	}
-
-	err := i.executeNecessaryProviders(pf)
+	v, err := i.instance(ctx, pf)
 	if err != nil {
 		return err
 	}
-	rVal.Elem().Set(pf.outValue)
+	rVal.Elem().Set(v.Convert(elem))
 	return nil
 }
 
-func (i *Injector) executeNecessaryProviders(pf *providerFunc) error {
+// instance returns the instance of the provider function, constructing it along with its dependencies if needed.
+// The pooled providers construct their instance on each injection.
+func (i *Injector) instance(ctx context.Context, pf *providerFunc) (reflect.Value, error) {
+	if err := i.executeNecessaryProviders(ctx, pf); err != nil {
+		return reflect.Value{}, err
+	}
+	if pf.pool != nil {
+		return i.pooledInstance(ctx, pf)
+	}
+	i.touch(pf)
+	return pf.outValue, nil
+}
+
+func (i *Injector) executeNecessaryProviders(ctx context.Context, pf *providerFunc) error {
 	providers := pf.getProviders()
 	for _, p := range providers {
-		if p.outValue.IsValid() {
-			continue
-		}
-		ins := make([]reflect.Value, len(p.in))
-		for j, in := range p.in {
-			switch it := in.(type) {
-			case reflect.Value:
-				ins[j] = it
-			case boundProviderFunc:
-				ins[j] = it.f.outValue
-			case *providerFunc:
-				ins[j] = it.outValue
-			}
-		}
-		outs := p.value.Call(ins)
-		if p.errOut > 0 {
-			if errVal := outs[p.errOut]; !errVal.IsNil() {
-				err := errVal.Interface().(error)
-				return err
-			}
+		// The instances of the pooled providers are constructed on each injection.
+		if p.pool != nil {
+			continue
 		}
-		if p.cleanupOut > 0 {
-			cf := outs[p.cleanupOut]
-			if !cf.IsNil() {
-				p.cleanup = cf
-			}
+		if err := i.constructOnce(ctx, p); err != nil {
+			return err
 		}
-		p.outValue = outs[0]
-		i.providerFuncs = append(i.providerFuncs, p)
 	}
 	return nil
 }
 
+// constructOnce constructs the instance of the provider function unless it is already constructed.
+// The construction is synchronized per provider function, so that the concurrent injections, e.g. of the child
+// scopes lazily referencing the types of their parent, construct the instance only once.
+func (i *Injector) constructOnce(ctx context.Context, p *providerFunc) error {
+	if err := p.buildLock.lock(ctx); err != nil {
+		return err
+	}
+	defer p.buildLock.unlock()
+	if p.outValue.IsValid() {
+		return nil
+	}
+	if err := i.cachedError(p); err != nil {
+		return err
+	}
+	out, err := i.construct(ctx, p)
+	if err != nil {
+		return i.constructFailed(p, err)
+	}
+	p.stateLock.Lock()
+	p.outValue = out
+	p.stateLock.Unlock()
+	i.funcsLock.Lock()
+	i.providerFuncs = append(i.providerFuncs, p)
+	i.funcsLock.Unlock()
+	i.touch(p)
+	i.scheduleRefresh(p)
+	return nil
+}
+
+// construct constructs the value of the provider function, unless its circuit breaker is open.
+func (i *Injector) construct(ctx context.Context, p *providerFunc) (reflect.Value, error) {
+	if p.breaker != nil {
+		if err := p.breaker.allow(); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	start := time.Now()
+	out, err := i.build(ctx, p)
+	i.recordBuild(p, time.Since(start), err)
+	p.stateLock.Lock()
+	p.err = err
+	p.stateLock.Unlock()
+	if p.breaker != nil && p.breaker.record(err) {
+		i.emit(Event{Kind: EventCircuitOpened, Type: p.out, Provider: p.name(), Err: err})
+	}
+	return out, err
+}
+
+// build calls the provider function with its decorators, post processors, validator and initializers.
+// The context only stops the retries, while the functions get its values without the cancellation, as the instance
+// outlives the construction.
+func (i *Injector) build(ctx context.Context, p *providerFunc) (reflect.Value, error) {
+	retryCtx := ctx
+	ctx = context.WithoutCancel(ctx)
+	ins, err := i.inputs(ctx, p)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	out, cleanup, err := i.callWithRetry(retryCtx, p, ins)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if cleanup.IsValid() {
+		p.addCleanup(cleanup)
+	}
+	if err = i.checkNilOutput(p, out); err != nil {
+		i.clean(p)
+		return reflect.Value{}, err
+	}
+	return i.process(ctx, p, out)
+}
+
+// process applies the decorators, post processors, validator and initializers of the provider function p
+// to its value out. The cleanups of the provider function are executed when any of them fails.
+func (i *Injector) process(ctx context.Context, p *providerFunc, out reflect.Value) (reflect.Value, error) {
+	for _, d := range p.decorators {
+		ins, err := i.inputs(ctx, d)
+		if err != nil {
+			i.clean(p)
+			return reflect.Value{}, err
+		}
+		ins[0] = out
+		var cleanup reflect.Value
+		out, cleanup, err = i.call(d, ins)
+		if err != nil {
+			i.clean(p)
+			return reflect.Value{}, err
+		}
+		if cleanup.IsValid() {
+			p.addCleanup(cleanup)
+		}
+		if err = i.checkNilOutput(d, out); err != nil {
+			i.clean(p)
+			return reflect.Value{}, err
+		}
+	}
+	if p.inherited {
+		// The instance of the ancestor is already processed by the ancestor.
+		return out, nil
+	}
+	out, err := i.postProcess(p.out, out)
+	if err != nil {
+		i.clean(p)
+		return reflect.Value{}, err
+	}
+	if err = i.validate(out); err != nil {
+		i.clean(p)
+		return reflect.Value{}, fmt.Errorf("validation of the value provided by: %s failed: %w", p.name(), err)
+	}
+	if err = i.initialize(ctx, out); err != nil {
+		i.clean(p)
+		return reflect.Value{}, fmt.Errorf("initialization of the value provided by: %s failed: %w", p.name(), err)
+	}
+	return out, nil
+}
+
+// inputs returns the input arguments of the provider function call, with the instances of the pooled dependencies.
+func (i *Injector) inputs(ctx context.Context, p *providerFunc) ([]reflect.Value, error) {
+	ins := p.args(ctx)
+	for j, in := range p.in {
+		var dep *providerFunc
+		switch it := in.(type) {
+		case *providerFunc:
+			dep = it
+		case boundProviderFunc:
+			dep = it.f
+		}
+		if dep == nil || dep.pool == nil {
+			continue
+		}
+		v, err := i.pooledInstance(ctx, dep)
+		if err != nil {
+			return nil, err
+		}
+		if bf, ok := in.(boundProviderFunc); ok {
+			v = v.Convert(bf.boundAs)
+		}
+		ins[j] = v
+	}
+	return ins, nil
+}
+
+// DisallowNilOutputs makes the provider functions returning nil pointer, interface, map, slice, channel or function
+// without an error fail the injection. By default, such nil values are injected as any other value.
+func DisallowNilOutputs() Option {
+	return func(i *Injector) {
+		i.disallowNilOutput = true
+	}
+}
+
+func (i *Injector) checkNilOutput(p *providerFunc, out reflect.Value) error {
+	if !i.disallowNilOutput || !isNil(out) {
+		return nil
+	}
+	return fmt.Errorf("provider: %s returned nil %s without an error", p.name(), p.out)
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return !v.IsValid()
+}
+
+// context returns the context the injector was resolved with.
+func (i *Injector) context() context.Context {
+	if i.ctx == nil {
+		return context.Background()
+	}
+	return i.ctx
+}
+
+// postProcess executes all the post processors over the value v of type t.
+func (i *Injector) postProcess(t reflect.Type, v reflect.Value) (reflect.Value, error) {
+	for _, pp := range i.postProcessors {
+		res, err := pp(t, v.Interface())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("post processing of the type: %s failed: %w", t, err)
+		}
+		if res == nil {
+			v = reflect.Zero(t)
+			continue
+		}
+		rv := reflect.ValueOf(res)
+		if !rv.Type().AssignableTo(t) {
+			return reflect.Value{}, fmt.Errorf("post processor returned value of type: %s that is not assignable to: %s", rv.Type(), t)
+		}
+		v = reflect.New(t).Elem()
+		v.Set(rv)
+	}
+	return v, nil
+}
+
 // Provide builds up provider injector.
 func (i *Injector) Provide(providers ...Provider) {
 	for _, provider := range providers {
@@ -219,27 +689,89 @@ func (i *Injector) Provide(providers ...Provider) {
 
 func (i *Injector) addProviders(providers ...Provider) {
 	for _, provider := range providers {
-		switch pt := provider.(type) {
-		case *interfaceValueProvider:
-			i.interfaceValueProviders = append(i.interfaceValueProviders, pt)
-		case *bindingProvider:
-			i.bindingProviders = append(i.bindingProviders, pt)
-		case *funcProvider:
-			i.funcProviders = append(i.funcProviders, pt)
-		case *valueProvider:
-			i.valueProviders = append(i.valueProviders, pt)
-		case ProviderSet:
-			i.addProviders(pt...)
+		if set, ok := provider.(ProviderSet); ok {
+			i.addProviders(set...)
+			continue
+		}
+		for _, p := range i.rewrite(provider) {
+			i.addProvider(p)
+		}
+	}
+}
+
+func (i *Injector) addProvider(provider Provider) {
+	i.declarations = append(i.declarations, provider)
+	switch pt := provider.(type) {
+	case *interfaceValueProvider:
+		if i.scopedNonFunc("interface value", pt.providerOptions) || i.addMember(pt, pt.providerOptions) {
+			return
+		}
+		i.interfaceValueProviders = append(i.interfaceValueProviders, pt)
+	case *bindingProvider:
+		if i.scopedNonFunc("binding", pt.providerOptions) || i.addMember(pt, pt.providerOptions) {
+			return
+		}
+		i.bindingProviders = append(i.bindingProviders, pt)
+	case *funcProvider:
+		if i.addMember(pt, pt.providerOptions) {
+			return
+		}
+		i.funcProviders = append(i.funcProviders, pt)
+	case *valueProvider:
+		if i.scopedNonFunc("value", pt.providerOptions) || i.addMember(pt, pt.providerOptions) {
+			return
 		}
+		i.valueProviders = append(i.valueProviders, pt)
+	case *bindFuncProvider:
+		i.bindFuncProviders = append(i.bindFuncProviders, pt)
+	case *decoratorProvider:
+		i.decoratorProviders = append(i.decoratorProviders, pt)
+	case *interceptorProvider:
+		i.interceptorProviders = append(i.interceptorProviders, pt)
+	case *flagsProvider:
+		i.flagsProviders = append(i.flagsProviders, pt)
+	case *fieldsProvider:
+		i.fieldsProviders = append(i.fieldsProviders, pt)
+	case *curryProvider:
+		i.curryProviders = append(i.curryProviders, pt)
+	case *noOpProvider:
+		i.noOpProviders = append(i.noOpProviders, pt)
+	case *postProcessorProvider:
+		i.postProcessors = append(i.postProcessors, pt.fn)
+	}
+}
+
+// scopedNonFunc reports the provider other than the provider function declared with ScopedTo, which has no instance
+// to be constructed per scope, rather than ignoring the scope.
+func (i *Injector) scopedNonFunc(kind string, o providerOptions) bool {
+	if o.scope == "" {
+		return false
 	}
+	i.errors = append(i.errors, fmt.Errorf("%s declared at: %s could not be scoped to: %q, only the provider functions might be scoped, provide it to the scope instead", kind, o.source, o.scope))
+	return true
 }
 
-// Resolve the injection providers.
+// Resolve the injection providers. The child scopes are resolved with the lifecycle context of their parent.
 func (i *Injector) Resolve() error {
+	if i.parent != nil {
+		return i.ResolveContext(i.parent.context())
+	}
+	return i.ResolveContext(context.Background())
+}
+
+// ResolveContext resolves the injection providers with the context passed to all the provider functions taking
+// context.Context as their first argument, as well as to the Init methods of the constructed values.
+// The injector derives its lifecycle context from the input one, which is injectable as context.Context by any
+// provider and gets cancelled when the injector is cleaned, unless the context.Context is provided explicitly.
+// The lifecycle context of the child scope is also cancelled along with the lifecycle context of its parent.
+func (i *Injector) ResolveContext(ctx context.Context) error {
 	if i.cleaned {
 		return ErrAlreadyCleaned
 	}
 	if i.resolved {
+		if i.idempotentResolve {
+			return nil
+		}
 		return ErrAlreadyResolved
 	}
 	if len(i.errors) > 0 {
@@ -247,11 +779,23 @@ func (i *Injector) Resolve() error {
 	}
 	i.lock.Lock()
 	defer i.lock.Unlock()
+	defer i.recordDuration(&i.report.resolve, time.Now())
 
+	i.ctx, i.cancel = context.WithCancel(ctx)
+	if i.parent != nil {
+		cancel := i.cancel
+		stop := context.AfterFunc(i.parent.context(), cancel)
+		i.cancel = func() {
+			stop()
+			cancel()
+		}
+	}
 	i.resolveBindings()
 	i.resolveInterfaceValues()
 	i.resolveValues()
+	i.resolveFlags()
 	if err := i.resolveProvideFunctions(); err != nil {
+		i.cancel()
 		return err
 	}
 
@@ -259,21 +803,93 @@ func (i *Injector) Resolve() error {
 	return nil
 }
 
-// Clean execute all clean functions of the provider functions in reverse order to which it was called.
+// Clean cancels the lifecycle context of the injector and executes all clean functions of the provider functions
+// in reverse topological order of their dependencies, which does not depend on the order in which the values
+// were lazily constructed, see CleanupOrder. The session scopes created with Scope are closed first.
+// The injector is cleaned only once, so Clean might be called from multiple paths, e.g. the signal handler and
+// the deferred call, while the concurrent calls wait until the first one completes.
+// Clean first drains the injector: the new injections fail with ErrShuttingDown, while the ones in flight finish
+// before the cleanup functions are executed.
 func (i *Injector) Clean() {
-	if i.cleaned {
-		return
-	}
+	i.cleanOnce.Do(i.cleanAll)
+}
+
+// cleanAll cleans the session scopes and the provider functions of the injector.
+func (i *Injector) cleanAll() {
+	i.closing.Store(true)
+	errs := i.closeSessions()
 	i.lock.Lock()
 	defer i.lock.Unlock()
-	for j := len(i.providerFuncs) - 1; j >= 0; j-- {
-		provider := i.providerFuncs[j]
-		if !provider.cleanup.IsValid() {
-			continue
+	i.addCleanErrors(errs...)
+	if i.cancel != nil {
+		i.cancel()
+	}
+	for _, p := range i.cleanupOrder() {
+		if p.weak != nil {
+			p.weak.stop()
+		}
+		if p.ttl != nil {
+			p.ttl.stop()
 		}
-		provider.cleanup.Call(nil)
+		i.clean(p)
 	}
 	i.cleaned = true
+	if i.leakTimer != nil {
+		i.leakTimer.Stop()
+	}
+	if i.parent != nil {
+		i.parent.removeChild(i)
+		if i.kind == ScopeSession {
+			i.parent.forgetSession(i)
+		}
+	}
+	// The cleaned injector refuses the injections with ErrAlreadyCleaned.
+	i.closing.Store(false)
+}
+
+// closeTimeout bounds the stopping of the lifecycle hooks by Close.
+const closeTimeout = 30 * time.Second
+
+// Close stops the started lifecycle hooks and cleans the injector, returning the stop errors together with
+// the errors of the teardown functions registered with OnShutdown and the panics recovered from the cleanup
+// functions. It implements io.Closer, so that the injector might be closed along with other resources.
+// The new injections fail with ErrShuttingDown as soon as Close is called, so that the dependencies being stopped
+// and cleaned are not handed out.
+// The hooks are stopped with the injector context without its cancellation, as the context is typically already
+// canceled by the time the injector is closed, bounded by the closeTimeout.
+func (i *Injector) Close() error {
+	i.closing.Store(true)
+	defer i.closing.Store(false)
+	var errs multiError
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(i.context()), closeTimeout)
+	defer cancel()
+	if err := i.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	i.Clean()
+	i.cleanErrorsLock.Lock()
+	errs = append(errs, i.cleanErrors...)
+	i.cleanErrors = nil
+	i.cleanErrorsLock.Unlock()
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// resolveContext provides the lifecycle context of the injector, unless the context.Context is provided explicitly
+// to the injector. The scopes provide their own lifecycle context over the one of the parent.
+func (i *Injector) resolveContext() {
+	if _, ok := i.values[contextType]; ok {
+		return
+	}
+	if _, ok := i.providersMap[contextType]; ok {
+		return
+	}
+	if _, ok := i.bindings[contextType]; ok {
+		return
+	}
+	i.values[contextType] = reflect.ValueOf(&i.ctx).Elem()
 }
 
 // Value sets up raw value that could be used as an injection for other types.
@@ -282,15 +898,30 @@ func (i *Injector) resolveValues() {
 		return
 	}
 	for _, vp := range i.valueProviders {
+		if vp.v == nil && vp.position > 0 {
+			i.errors = append(i.errors, fmt.Errorf("input value provider at position: %d of Values is nil", vp.position))
+			continue
+		}
 		if vp.v == nil {
 			i.errors = append(i.errors, errors.New("input value provider is nil"))
 			return
 		}
 
 		rv := reflect.ValueOf(vp.v)
-		_, ok := i.values[rv.Type()]
-		if ok {
-			i.errors = append(i.errors, fmt.Errorf("provider for type: %s already exists", rv.Type().String()))
+		register, err := i.conflict(rv.Type(), vp.providerOptions, ProviderSite{Provider: "value"})
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if !register {
+			continue
+		}
+		if err := i.checkTypedNil(rv); err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if err := i.validate(rv); err != nil {
+			i.errors = append(i.errors, fmt.Errorf("validation of the value: %s failed: %w", rv.Type(), err))
 			continue
 		}
 		i.values[rv.Type()] = rv
@@ -306,29 +937,44 @@ func (i *Injector) resolveInterfaceValues() {
 			i.errors = append(i.errors, errors.New("input value provider is nil"))
 			return
 		}
-		to := reflect.ValueOf(vp.value)
-		it := reflect.TypeOf(vp.iface)
-		if it.Elem().Kind() != reflect.Interface {
-			i.errors = append(i.errors, fmt.Errorf("one of provided interface values are not using interface as type: %s -> %s", it.String(), to.String()))
+		it, to, err := vp.convert()
+		if err != nil {
+			i.errors = append(i.errors, err)
 			continue
 		}
-		if !to.CanConvert(it) {
-			i.errors = append(i.errors, fmt.Errorf("one of provided interface values type does not implement interface type: %s -> %s", it.String(), to.String()))
+		if err = i.checkTypedNil(reflect.ValueOf(vp.value)); err != nil {
+			i.errors = append(i.errors, err)
 			continue
 		}
 
-		_, ok := i.values[it]
-		if ok {
-			i.errors = append(i.errors, fmt.Errorf("provider for type: %s already exists", to.Type().String()))
+		register, err := i.conflict(it, vp.providerOptions, ProviderSite{Provider: "interface value of " + to.Type().String()})
+		if err != nil {
+			i.errors = append(i.errors, err)
 			continue
 		}
-		i.values[it] = to.Convert(it)
+		if !register {
+			continue
+		}
+		i.values[it] = to
 	}
 }
 
 // Provide registers new provider injector functions.
 func (i *Injector) resolveProvideFunctions() error {
 	i.matchProviderFuncs()
+	i.resolveBindFuncs()
+	i.resolveContext()
+	i.resolveNonSharedBindings()
+	i.resolveGroups()
+	i.resolveNamed()
+	i.resolveFields()
+	i.resolveNoOps()
+	i.resolveCurries()
+	i.matchDecorators()
+	i.decorateNonSharedBindings()
+	i.checkPrimitives()
+	i.checkInjectorDependencies()
+	i.checkShadowing()
 	if len(i.errors) > 0 {
 		return i.errors
 	}
@@ -338,182 +984,338 @@ func (i *Injector) resolveProvideFunctions() error {
 		return err
 	}
 
-	providers := make([]*providerFunc, len(i.providersMap))
-	for _, p := range i.providersMap {
-		providers[p.id-1] = p
-	}
-	visited, dfsVisited := make([]bool, len(i.providersMap)), make([]bool, len(i.providersMap))
+	providers := i.allProviders()
+	visited, dfsVisited := map[*providerFunc]bool{}, map[*providerFunc]bool{}
 	for _, p := range providers {
-		if !visited[p.id-1] {
+		if !visited[p] {
 			trace, hasCycles := checkCycles(p, visited, dfsVisited)
 			if hasCycles {
-				return fmt.Errorf("dependenc cycle detected %s", strings.Join(trace, "<-"))
+				return cycleError{trace: trace}
 			}
 		}
 	}
 	return nil
 }
 
-func checkCycles(p *providerFunc, visited []bool, dfsVisited []bool) ([]string, bool) {
-	visited[p.id-1] = true
-	dfsVisited[p.id-1] = true
+func checkCycles(p *providerFunc, visited, dfsVisited map[*providerFunc]bool) ([]string, bool) {
+	visited[p] = true
+	dfsVisited[p] = true
 	max := -1
 	for _, dep := range p.dependencies {
-		if !visited[dep.id-1] {
+		if !visited[dep] {
 			trace, hasCycle := checkCycles(dep, visited, dfsVisited)
 			if hasCycle {
 				return append(trace, p.out.String()), true
 			}
-		} else if dfsVisited[dep.id-1] {
-			return []string{dep.out.String()}, true
+		} else if dfsVisited[dep] {
+			return []string{dep.out.String(), p.out.String()}, true
 		}
 		max = maxInt(max, dep.depth)
 	}
 	p.depth = max + 1
-	dfsVisited[p.id-1] = false
+	dfsVisited[p] = false
 	return nil, false
 }
 
 func (i *Injector) resolveProvidersDependencies() error {
-	for _, p := range i.providersMap {
-		p.in = make([]interface{}, len(p.inTypes))
-		for j, in := range p.inTypes {
-			vt, ok := i.values[in]
-			if ok {
-				p.in[j] = vt
-				continue
-			}
+	for _, p := range i.allProviders() {
+		if err := i.resolveProviderDependencies(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			pf, ok := i.providersMap[in]
-			if ok {
-				p.in[j] = pf
-				p.dependencies = append(p.dependencies, pf)
-				continue
+func (i *Injector) resolveProviderDependencies(p *providerFunc) error {
+	p.in = make([]interface{}, len(p.inTypes))
+	for j, in := range p.inTypes {
+		if j == 0 && in == contextType {
+			p.in[j] = injectorContext{}
+			continue
+		}
+		if err := i.resolveDependency(p, p.in, j, in); err != nil {
+			return err
+		}
+	}
+	// The first argument of the decorator is the decorated value, and the rest are its dependencies.
+	for _, d := range p.decorators {
+		d.in = make([]interface{}, len(d.inTypes))
+		for j := 1; j < len(d.inTypes); j++ {
+			if err := i.resolveDependency(p, d.in, j, d.inTypes[j]); err != nil {
+				return err
 			}
+		}
+	}
+	p.depth = -1
+	return nil
+}
 
-			// Check if the input is an interface bound to some other type.
-			bt, ok := i.bindings[in]
-			if ok {
-				// Check if the bound interface is a registered value.
-				vt, ok = i.values[bt]
-				if ok {
-					p.in[j] = vt.Convert(in)
-					continue
-				}
+// resolveDependency sets up the j-th input of the provider function p and registers its dependencies.
+func (i *Injector) resolveDependency(p *providerFunc, ins []interface{}, j int, in reflect.Type) error {
+	vt, ok := i.values[in]
+	if ok {
+		ins[j] = vt
+		return nil
+	}
 
-				// Check if the bound interface is a result of the provider function.
-				pf, ok = i.providersMap[bt]
-				if ok {
-					p.in[j] = boundProviderFunc{f: pf, boundAs: in}
-					p.dependencies = append(p.dependencies, pf)
-					continue
-				}
-			}
+	pf, ok := i.providersMap[in]
+	if ok {
+		ins[j] = pf
+		p.dependencies = append(p.dependencies, pf)
+		return nil
+	}
+
+	// Check if the input is an interface bound to some other type.
+	bt, ok := i.bindings[in]
+	if ok {
+		// Check if the bound interface is a registered value.
+		vt, ok = i.values[bt]
+		if ok {
+			ins[j] = vt.Convert(in)
+			return nil
+		}
 
-			return fmt.Errorf("no provider found for the %s type", in.String())
+		// Check if the bound interface is a result of the provider function.
+		pf, ok = i.providersMap[bt]
+		if ok {
+			ins[j] = boundProviderFunc{f: pf, boundAs: in}
+			p.dependencies = append(p.dependencies, pf)
+			return nil
 		}
-		p.depth = -1
 	}
-	return nil
+
+	// Check if the interface is implemented by the single provided type.
+	bt, err := i.autoBound(in)
+	if err != nil {
+		return fmt.Errorf("provider: %s dependency %w", p.name(), err)
+	}
+	if bt != nil {
+		i.bindings[in] = bt
+		return i.resolveDependency(p, ins, j, in)
+	}
+
+	// Check if the input is provided by the parent scope.
+	if i.parent != nil && i.parent.hasProvider(in) {
+		pf = i.parentProviderFunc(in)
+		ins[j] = pf
+		p.dependencies = append(p.dependencies, pf)
+		return nil
+	}
+
+	if err := i.scopedError(in); err != nil {
+		return fmt.Errorf("provider: %s dependency %w", p.name(), err)
+	}
+	return fmt.Errorf("no provider found for the %s type", in.String())
 }
 
 func (i *Injector) matchProviderFuncs() {
 	for _, fp := range i.funcProviders {
-		rv := reflect.ValueOf(fp.v)
-		if rv.Kind() != reflect.Func {
-			i.errors = append(i.errors, fmt.Errorf("provider %T is not a function ", fp.v))
-			continue
-		}
-		rvt := rv.Type()
-		pf := providerFunc{id: i.nextID(), value: rv, errOut: -1, cleanupOut: -1}
-
-		numDependencies := rv.Type().NumIn()
-		for j := 0; j < numDependencies; j++ {
-			pf.inTypes = append(pf.inTypes, rvt.In(j))
-		}
-
-		numOut := rvt.NumOut()
-		switch numOut {
-		case 1:
-			// Only provided type.
-			pf.out = rvt.Out(0)
-		case 2:
-			// Provided type and error or provided type and cleanup func.
-			pf.out = rvt.Out(0)
-			second := rvt.Out(1)
-			switch {
-			case second.AssignableTo(errorType):
-				pf.errOut = 1
-			case second.AssignableTo(cleanupFunc):
-				pf.cleanupOut = 1
-			default:
-				i.errors = append(i.errors, fmt.Errorf("provider: %T has invalid out second variable type %s", fp.v, second))
-				continue
-			}
-		case 3:
-			// Provided type error and cleanup type.
-			pf.out = rvt.Out(0)
-			// Provided type and error or provided type and cleanup func.
-			pf.cleanupOut = 1
-			if !rvt.Out(1).AssignableTo(cleanupFunc) {
-				i.errors = append(i.errors, fmt.Errorf("provider: %T has invalid out second variable type expected to be a cancel function but is: %s", fp.v, rvt.Out(1)))
-				pf.cleanupOut = 0
-				continue
-			}
+		pf, err := fp.providerFunc()
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if fp.scope != "" && fp.scope != i.kind {
+			i.scoped[pf.out] = fp
+			continue
+		}
+		register, err := i.conflict(pf.out, fp.providerOptions, ProviderSite{Provider: pf.name(), Signature: pf.value.Type().String()})
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if !register {
+			continue
+		}
+		pf.id = i.nextID()
+		i.providersMap[pf.out] = pf
+	}
+	i.matchScopedProviders()
+}
 
-			pf.errOut = 2
-			if !rvt.Out(2).AssignableTo(errorType) {
-				i.errors = append(i.errors, fmt.Errorf("provider: %T has invalid out second variable type expected to be an error but is: %s", fp.v, rvt.Out(1)))
-				pf.errOut = 0
-				continue
-			}
-		default:
-			i.errors = append(i.errors, fmt.Errorf("provider: %T have invalid returned variables number", fp.v))
+func (i *Injector) matchDecorators() {
+	for _, dp := range i.decoratorProviders {
+		d, err := newProviderFunc(dp.v)
+		if err != nil {
+			i.errors = append(i.errors, err)
 			continue
 		}
-		_, ok := i.providersMap[pf.out]
-		if ok {
-			if fp.ifNotExists {
-				continue
-			}
-			i.errors = append(i.errors, fmt.Errorf("provider already registered for type: %s", pf.out.String()))
+		if len(d.inTypes) == 0 || d.inTypes[0] != d.out {
+			i.errors = append(i.errors, fmt.Errorf("decorator: %T first argument is expected to be of decorated type: %s", dp.v, d.out))
+			continue
+		}
+		pf, err := i.decoratedProvider(d.out)
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		d.weight = dp.weight
+		pf.decorators = append(pf.decorators, d)
+	}
+	for _, ip := range i.interceptorProviders {
+		d, err := ip.decorator()
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		pf, err := i.decoratedProvider(d.out)
+		if err != nil {
+			i.errors = append(i.errors, err)
 			continue
 		}
-		i.providersMap[pf.out] = &pf
+		pf.decorators = append(pf.decorators, d)
 	}
+	for _, pf := range i.providersMap {
+		sort.SliceStable(pf.decorators, func(j, k int) bool {
+			return pf.decorators[j].weight < pf.decorators[k].weight
+		})
+	}
+}
+
+// decoratedProvider gets the provider function of given type. A value or a binding of that type is replaced with
+// the provider function, so that the decorators are applied once for all the dependents.
+func (i *Injector) decoratedProvider(t reflect.Type) (*providerFunc, error) {
+	if pf, ok := i.providersMap[t]; ok {
+		return pf, nil
+	}
+	if v, ok := i.values[t]; ok {
+		delete(i.values, t)
+		return i.syntheticProviderFunc(t, nil, func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{v}
+		}), nil
+	}
+	if bt, ok := i.bindings[t]; ok {
+		delete(i.bindings, t)
+		return i.syntheticProviderFunc(t, []reflect.Type{bt}, func(in []reflect.Value) []reflect.Value {
+			return []reflect.Value{in[0].Convert(t)}
+		}), nil
+	}
+	return nil, fmt.Errorf("no provider found for the decorated type: %s", t)
+}
+
+// syntheticProviderFunc registers provider function of the out type implemented by the fn function.
+func (i *Injector) syntheticProviderFunc(out reflect.Type, in []reflect.Type, fn func([]reflect.Value) []reflect.Value) *providerFunc {
+	pf := &providerFunc{
+		id:         i.nextID(),
+		value:      reflect.MakeFunc(reflect.FuncOf(in, []reflect.Type{out}, false), fn),
+		inTypes:    in,
+		out:        out,
+		errOut:     -1,
+		cleanupOut: -1,
+	}
+	i.providersMap[out] = pf
+	return pf
+}
+
+func newProviderFunc(v interface{}) (*providerFunc, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Func {
+		return nil, fmt.Errorf("provider %T is not a function ", v)
+	}
+	rvt := rv.Type()
+	pf := &providerFunc{value: rv, errOut: -1, cleanupOut: -1}
+
+	numDependencies := rvt.NumIn()
+	for j := 0; j < numDependencies; j++ {
+		pf.inTypes = append(pf.inTypes, rvt.In(j))
+	}
+
+	numOut := rvt.NumOut()
+	switch numOut {
+	case 1:
+		// Only provided type.
+		pf.out = rvt.Out(0)
+	case 2:
+		// Provided type and error or provided type and cleanup func.
+		pf.out = rvt.Out(0)
+		second := rvt.Out(1)
+		switch {
+		case second.AssignableTo(errorType):
+			pf.errOut = 1
+		case second.AssignableTo(cleanupFunc):
+			pf.cleanupOut = 1
+		default:
+			return nil, fmt.Errorf("provider: %T has invalid out second variable type %s", v, second)
+		}
+	case 3:
+		// Provided type error and cleanup type.
+		pf.out = rvt.Out(0)
+		pf.cleanupOut = 1
+		if !rvt.Out(1).AssignableTo(cleanupFunc) {
+			return nil, fmt.Errorf("provider: %T has invalid out second variable type expected to be a cancel function but is: %s", v, rvt.Out(1))
+		}
+
+		pf.errOut = 2
+		if !rvt.Out(2).AssignableTo(errorType) {
+			return nil, fmt.Errorf("provider: %T has invalid out second variable type expected to be an error but is: %s", v, rvt.Out(1))
+		}
+	default:
+		return nil, fmt.Errorf("provider: %T have invalid returned variables number", v)
+	}
+	return pf, nil
 }
 
 func (i *Injector) resolveBindings() {
 	for _, binding := range i.bindingProviders {
-		it := reflect.TypeOf(binding.iface)
-		to := reflect.TypeOf(binding.to)
-		if it.Kind() != reflect.Ptr || to.Kind() != reflect.Ptr {
-			i.errors = append(i.errors, fmt.Errorf("one of provided bindings are not defining values with `new` statement: %T -> %T", binding.iface, binding.to))
+		it, to, err := binding.types()
+		if err != nil {
+			i.errors = append(i.errors, err)
 			continue
 		}
-		it = it.Elem()
-		to = to.Elem()
-		if it.Kind() != reflect.Interface {
-			i.errors = append(i.errors, fmt.Errorf("one of provided bindings are not using interface as type: %s -> %s", it.String(), to.String()))
+		check := checkBindable
+		if binding.convertible {
+			check = checkConvertible
+		}
+		if err := check(it, to); err != nil {
+			i.errors = append(i.errors, fmt.Errorf("one of provided bindings %w", err))
 			continue
 		}
-		if !to.Implements(it) {
-			i.errors = append(i.errors, fmt.Errorf("one of provided bindings type does not implement interface type: %s -> %s", it.String(), to.String()))
+		if err := binding.checkConstraints(it, to); err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if binding.nonShared {
+			i.nonShared = append(i.nonShared, nonSharedBinding{it: it, to: to, options: binding.providerOptions})
 			continue
 		}
 
-		_, ok := i.bindings[it]
-		if ok {
-			if binding.ifNotExists {
-				continue
-			}
-			i.errors = append(i.errors, fmt.Errorf("binding between: %s and %s is already defined", it, to))
+		register, err := i.conflict(it, binding.providerOptions, ProviderSite{Provider: "binding to " + to.String()})
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if !register {
 			continue
 		}
 		i.bindings[it] = to
 	}
 }
 
+// checkBindable checks if the values of type 'to' might be injected as the interface or function type 'it'.
+func checkBindable(it, to reflect.Type) error {
+	switch it.Kind() {
+	case reflect.Interface:
+		if !to.Implements(it) {
+			return fmt.Errorf("type does not implement interface type: %s -> %s", it, to)
+		}
+	case reflect.Func:
+		if !to.ConvertibleTo(it) {
+			return fmt.Errorf("type is not convertible to function type: %s -> %s", it, to)
+		}
+	default:
+		return fmt.Errorf("are not using interface nor function as type: %s -> %s", it, to)
+	}
+	return nil
+}
+
+// checkConvertible checks if the values of type 'from' might be converted to the type 'to'.
+func checkConvertible(to, from reflect.Type) error {
+	if to.Kind() != from.Kind() || !from.ConvertibleTo(to) {
+		return fmt.Errorf("type is not convertible to the type with the same underlying type: %s -> %s", from, to)
+	}
+	return nil
+}
+
 func (i *Injector) nextID() int64 {
 	i.id++
 	return i.id
@@ -525,12 +1327,57 @@ type providerFunc struct {
 	inTypes      []reflect.Type
 	in           []interface{}
 	dependencies []*providerFunc
+	decorators   []*providerFunc
 	out          reflect.Type
 	errOut       int
 	cleanupOut   int
 	outValue     reflect.Value
-	cleanup      reflect.Value
-	depth        int
+	cleanups     []reflect.Value
+	pool         *sync.Pool
+	poolLock     sync.Mutex
+	buildLock    buildMutex
+	// stateLock guards the outValue and err written by the construction under the injector read lock, so that
+	// they might be read without waiting for the construction.
+	stateLock  sync.RWMutex
+	cleanLock  sync.Mutex
+	pooledUsed bool
+	weak       *weakInstance
+	ttl        *ttlState
+	retry      *retryPolicy
+	breaker    *breaker
+	source     *funcProvider
+	inherited  bool
+	err        error
+	depth      int
+	weight     int
+	// selected is the provider of the implementation chosen by the BindFunc selector, known once it is injected.
+	selected atomic.Pointer[providerFunc]
+}
+
+// buildMutex is the lock of the provider function construction, whose waiters give up once their context is done.
+type buildMutex struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+func (m *buildMutex) lock(ctx context.Context) error {
+	m.once.Do(func() { m.ch = make(chan struct{}, 1) })
+	// The free lock is taken even if the context is done.
+	select {
+	case m.ch <- struct{}{}:
+		return nil
+	default:
+	}
+	select {
+	case m.ch <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *buildMutex) unlock() {
+	<-m.ch
 }
 
 func (p *providerFunc) getProviders() []*providerFunc {
@@ -542,6 +1389,65 @@ func (p *providerFunc) getProviders() []*providerFunc {
 	return providers
 }
 
+// name returns the name of the provider function.
+func (p *providerFunc) name() string {
+	if !p.value.IsValid() {
+		return p.out.String()
+	}
+	if fn := runtime.FuncForPC(p.value.Pointer()); fn != nil && !strings.HasPrefix(fn.Name(), "reflect.") {
+		return fn.Name()
+	}
+	return p.out.String()
+}
+
+// injectorContext is the placeholder of the leading context.Context argument of the provider function.
+type injectorContext struct{}
+
+// args returns the input arguments of the provider function call.
+func (p *providerFunc) args(ctx context.Context) []reflect.Value {
+	ins := make([]reflect.Value, len(p.in))
+	for j, in := range p.in {
+		switch it := in.(type) {
+		case injectorContext:
+			ins[j] = reflect.ValueOf(&ctx).Elem()
+		case reflect.Value:
+			ins[j] = it
+			if it.Type() == lifecyclePtrType && !it.IsNil() {
+				// The hooks appended by the provider function are named after it.
+				ins[j] = reflect.ValueOf(it.Interface().(*Lifecycle).ownedBy(p.name()))
+			}
+		case boundProviderFunc:
+			ins[j] = it.f.outValue.Convert(it.boundAs)
+		case *providerFunc:
+			ins[j] = it.outValue
+		}
+	}
+	return ins
+}
+
+// call executes the provider function and returns its provided value along with an optional cleanup function.
+func (p *providerFunc) call(ins []reflect.Value) (reflect.Value, reflect.Value, error) {
+	var outs []reflect.Value
+	if p.value.Type().IsVariadic() {
+		// The variadic arguments are injected as the slice.
+		outs = p.value.CallSlice(ins)
+	} else {
+		outs = p.value.Call(ins)
+	}
+	if p.errOut > 0 {
+		if errVal := outs[p.errOut]; !errVal.IsNil() {
+			return reflect.Value{}, reflect.Value{}, errVal.Interface().(error)
+		}
+	}
+	var cleanup reflect.Value
+	if p.cleanupOut > 0 {
+		if cf := outs[p.cleanupOut]; !cf.IsNil() {
+			cleanup = cf
+		}
+	}
+	return outs[0], cleanup, nil
+}
+
 type boundProviderFunc struct {
 	f       *providerFunc
 	boundAs reflect.Type
@@ -566,3 +1472,45 @@ func (m multiError) Error() string {
 	}
 	return sb.String()
 }
+
+// sortedTypes returns the types of the map keys sorted by their names, so that the errors and reports
+// do not depend on the map iteration order.
+func sortedTypes[V any](m map[reflect.Type]V) []reflect.Type {
+	types := make([]reflect.Type, 0, len(m))
+	for t := range m {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(j, k int) bool {
+		return types[j].String() < types[k].String()
+	})
+	return types
+}
+
+// notFoundError is returned when there is no provider for the injected type.
+type notFoundError struct {
+	t reflect.Type
+}
+
+func (e notFoundError) Error() string {
+	return fmt.Sprintf("injector not found for the type: %s", e.t)
+}
+
+// Is matches the ErrProviderNotFound.
+func (e notFoundError) Is(target error) bool {
+	return target == ErrProviderNotFound
+}
+
+// cycleError is returned when the provider functions depend on each other.
+type cycleError struct {
+	// trace are the names of the types in the cycle, starting from the dependency.
+	trace []string
+}
+
+func (e cycleError) Error() string {
+	return fmt.Sprintf("dependenc cycle detected %s", strings.Join(e.trace, "<-"))
+}
+
+// Unwrap returns the errors, so that they could be matched with errors.Is and errors.As.
+func (m multiError) Unwrap() []error {
+	return m
+}
@@ -1,6 +1,15 @@
 package wireless
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -15,6 +24,18 @@ type testType struct {
 
 func (t testType) isInterfacer() {}
 
+type initType struct {
+	initialized int
+	injected    int
+}
+
+func (it *initType) Init(context.Context) error {
+	it.initialized++
+	return nil
+}
+
+func (it *initType) AfterInject() { it.injected++ }
+
 func TestInjector(t *testing.T) {
 	t.Run("Pointer", func(t *testing.T) {
 		i := New()
@@ -204,4 +225,708 @@ func TestInjector(t *testing.T) {
 			t.Errorf("Expected all true, got A: %t, B: %t, C: %t", dv.A.started, dv.B.started, dv.C.started)
 		}
 	})
+
+	t.Run("Decorate", func(t *testing.T) {
+		var cleaned []string
+		newType := func() (testType, func()) {
+			return testType{v: "base"}, func() { cleaned = append(cleaned, "base") }
+		}
+		decorate := func(it interfaceType, suffix string) (interfaceType, func(), error) {
+			return testType{v: it.(testType).v + suffix}, func() { cleaned = append(cleaned, "decorator") }, nil
+		}
+
+		i := New()
+		i.Provide(
+			Func(newType),
+			Bind(new(interfaceType), new(testType)),
+			Value("-decorated"),
+			Decorate(decorate),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var it interfaceType
+		err = i.InjectAs(&it)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		if tt, ok := it.(testType); !ok || tt.v != "base-decorated" {
+			t.Errorf("Expected %v, got %v", "base-decorated", it)
+		}
+
+		i.Clean()
+		if len(cleaned) != 2 || cleaned[0] != "decorator" || cleaned[1] != "base" {
+			t.Errorf("Expected decorator cleaned before base, got %v", cleaned)
+		}
+	})
+
+	t.Run("DecorateWeight", func(t *testing.T) {
+		suffix := func(s string) func(testType) testType {
+			return func(tt testType) testType { return testType{v: tt.v + s} }
+		}
+
+		i := New()
+		i.Provide(
+			Value(testType{v: "base"}),
+			Weight(3, Decorate(suffix("-logging"))),
+			Weight(1, Decorate(suffix("-retry"))),
+			Weight(2, Decorate(suffix("-metrics"))),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt testType
+		err = i.InjectAs(&tt)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		if expected := "base-retry-metrics-logging"; tt.v != expected {
+			t.Errorf("Expected %v, got %v", expected, tt.v)
+		}
+	})
+
+	t.Run("PostProcess", func(t *testing.T) {
+		var processed []reflect.Type
+		i := New()
+		i.Provide(
+			Func(func() testType { return testType{v: "base"} }),
+			Bind(new(interfaceType), new(testType)),
+			PostProcess(func(t reflect.Type, v interface{}) (interface{}, error) {
+				processed = append(processed, t)
+				if tt, ok := v.(testType); ok {
+					return testType{v: tt.v + "-processed"}, nil
+				}
+				return v, nil
+			}),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var it interfaceType
+		err = i.InjectAs(&it)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		if tt, ok := it.(testType); !ok || tt.v != "base-processed" {
+			t.Errorf("Expected %v, got %v", "base-processed", it)
+		}
+		if len(processed) != 1 {
+			t.Errorf("Expected single processed value, got %v", processed)
+		}
+	})
+
+	t.Run("Initialize", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Func(func() *initType { return &initType{} }),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var it *initType
+		err = i.InjectAs(&it)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if it.initialized != 1 || it.injected != 1 {
+			t.Errorf("Expected initialized once, got Init: %d, AfterInject: %d", it.initialized, it.injected)
+		}
+
+		var holder struct {
+			*initType
+			Dep *initType
+		}
+		holder.initType = &initType{}
+		err = i.Inject(&holder)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if holder.Dep != it || holder.initialized != 1 || holder.injected != 1 {
+			t.Errorf("Expected injected struct initialized, got Init: %d, AfterInject: %d", holder.initialized, holder.injected)
+		}
+	})
+
+	t.Run("DisallowNilOutputs", func(t *testing.T) {
+		i := New(DisallowNilOutputs())
+		i.Provide(
+			Func(func() *testType { return nil }),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt *testType
+		if err = i.InjectAs(&tt); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("Dive", func(t *testing.T) {
+		type db struct{ Addr string }
+		type cache struct{ Size int }
+		type nested struct {
+			DB    *db
+			Cache *cache `wireless:"dive"`
+		}
+		type config struct {
+			Nested nested `wireless:"dive"`
+			Skip   *db    `wireless:"-"`
+		}
+
+		dbValue := &db{Addr: "localhost"}
+		i := New()
+		i.Provide(
+			Value(dbValue),
+			Value(42),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var cfg config
+		err = i.Inject(&cfg)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if cfg.Nested.DB != dbValue || cfg.Nested.Cache == nil || cfg.Nested.Cache.Size != 42 || cfg.Skip != nil {
+			t.Errorf("Expected nested fields injected, got %+v", cfg)
+		}
+	})
+
+	t.Run("Dive cycle", func(t *testing.T) {
+		type node struct {
+			Value int
+			Next  *node `wireless:"dive"`
+		}
+		i := New()
+		i.Provide(Value(42))
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var n node
+		err = i.Inject(&n)
+		if err == nil || !strings.Contains(err.Error(), "field Next tagged with 'dive' refers to the enclosing type") {
+			t.Errorf("Expected dive cycle error, got %v", err)
+		}
+	})
+
+	t.Run("Embedded", func(t *testing.T) {
+		type Deps struct {
+			Value *testType
+		}
+		type embedded struct {
+			Number int
+		}
+		type Whole struct {
+			Name string
+		}
+		type service struct {
+			Deps
+			embedded
+			*Whole
+		}
+
+		ptr := &testType{v: "embedded"}
+		whole := &Whole{Name: "whole"}
+		i := New()
+		i.Provide(
+			Value(ptr),
+			Value(42),
+			Value(whole),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var s service
+		err = i.Inject(&s)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if s.Value != ptr || s.Number != 42 || s.Whole != whole {
+			t.Errorf("Expected embedded fields injected, got %+v", s)
+		}
+	})
+
+	t.Run("Unexported", func(t *testing.T) {
+		type service struct {
+			ptr    *testType `wireless:"inject"`
+			number int
+		}
+
+		ptr := &testType{v: "unexported"}
+		i := New()
+		i.Provide(
+			Value(ptr),
+			Value(42),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var s service
+		err = i.Inject(&s)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if s.ptr != ptr || s.number != 0 {
+			t.Errorf("Expected only tagged field injected, got %+v", s)
+		}
+
+		i = New(WithUnexportedFields())
+		i.Provide(
+			Value(ptr),
+			Value(42),
+		)
+		err = i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		s = service{}
+		err = i.Inject(&s)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if s.ptr != ptr || s.number != 42 {
+			t.Errorf("Expected all fields injected, got %+v", s)
+		}
+	})
+
+	t.Run("Optional", func(t *testing.T) {
+		type service struct {
+			Ptr      *testType
+			Optional *initType `wireless:"optional"`
+		}
+
+		ptr := &testType{v: "required"}
+		i := New()
+		i.Provide(Value(ptr))
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var s service
+		err = i.Inject(&s)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if s.Ptr != ptr || s.Optional != nil {
+			t.Errorf("Expected optional field left empty, got %+v", s)
+		}
+
+		var it *initType
+		if err = i.InjectAs(&it); !errors.Is(err, ErrProviderNotFound) {
+			t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "resolved")
+
+		i := New()
+		i.Provide(
+			Value(&testType{v: "ctx-"}),
+			Func(func(ctx context.Context, p *testType) testType {
+				return testType{v: p.v + ctx.Value(ctxKey{}).(string)}
+			}),
+		)
+		err := i.ResolveContext(ctx)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt testType
+		err = i.InjectAs(&tt)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if tt.v != "ctx-resolved" {
+			t.Errorf("Expected %v, got %v", "ctx-resolved", tt.v)
+		}
+	})
+
+	t.Run("LifecycleContext", func(t *testing.T) {
+		type worker struct{ done <-chan struct{} }
+
+		i := New()
+		i.Provide(
+			Func(func(p *testType, ctx context.Context) *worker { return &worker{done: ctx.Done()} }),
+			Value(&testType{}),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var w *worker
+		err = i.InjectAs(&w)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		select {
+		case <-w.done:
+			t.Error("Expected context not cancelled before Clean")
+		default:
+		}
+		i.Clean()
+		select {
+		case <-w.done:
+		default:
+			t.Error("Expected context cancelled after Clean")
+		}
+	})
+
+	t.Run("Provided context", func(t *testing.T) {
+		type ctxKey struct{}
+		provided := context.WithValue(context.Background(), ctxKey{}, "provided")
+
+		i := New()
+		i.Provide(InterfaceValue(new(context.Context), provided))
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var ctx context.Context
+		err = i.InjectAs(&ctx)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if ctx.Value(ctxKey{}) != "provided" {
+			t.Errorf("Expected %v, got %v", "provided", ctx.Value(ctxKey{}))
+		}
+	})
+
+	t.Run("Failed resolve cancels context", func(t *testing.T) {
+		i := New()
+		i.Provide(Func(func(*initType) *testType { return &testType{} }))
+		if err := i.Resolve(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+		if i.ctx.Err() == nil {
+			t.Error("Expected context cancelled after the failed Resolve")
+		}
+	})
+}
+
+func TestInjectAsContext(t *testing.T) {
+	type deadlineKey struct{}
+
+	t.Run("Context", func(t *testing.T) {
+		i := New()
+		i.Provide(Func(func(ctx context.Context) *testType {
+			v, _ := ctx.Value(deadlineKey{}).(string)
+			return &testType{v: v}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt *testType
+		ctx := context.WithValue(context.Background(), deadlineKey{}, "request")
+		if err := i.InjectAsContext(ctx, &tt); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if tt == nil || tt.v != "request" {
+			t.Errorf("Expected %v, got %v", "request", tt)
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		i := New()
+		i.Provide(Func(func(ctx context.Context) *testType {
+			close(started)
+			<-release
+			return &testType{v: strconv.FormatBool(ctx.Done() == nil)}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		done := make(chan error, 1)
+		go func() {
+			var tt *testType
+			done <- i.InjectAs(&tt)
+		}()
+		<-started
+
+		// The construction by the other injection is not waited for once the context is done.
+		var tt *testType
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := i.InjectAsContext(ctx, &tt); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+		}
+		if tt != nil {
+			t.Errorf("Expected nil, got %v", tt)
+		}
+		close(release)
+		if err := <-done; err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err := i.InjectAs(&tt); err != nil || tt.v != "true" {
+			t.Errorf("Expected the shared instance constructed with the context without cancellation, got %v, %v", tt, err)
+		}
+		i.Clean()
+	})
+
+	t.Run("Group and named", func(t *testing.T) {
+		fromCtx := func(ctx context.Context) *testType {
+			v, _ := ctx.Value(deadlineKey{}).(string)
+			return &testType{v: v}
+		}
+		i := New()
+		i.Provide(Group("g", Func(fromCtx)), Named("n", Func(fromCtx)), Func(fromCtx))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		ctx := context.WithValue(context.Background(), deadlineKey{}, "request")
+		var group []*testType
+		if err := i.InjectGroupContext(ctx, "g", &group); err != nil || len(group) != 1 || group[0].v != "request" {
+			t.Errorf("Expected %v, got %v, %v", "request", group, err)
+		}
+		var named *testType
+		if err := i.InjectNamedContext(ctx, "n", &named); err != nil || named.v != "request" {
+			t.Errorf("Expected %v, got %v, %v", "request", named, err)
+		}
+		var fields struct{ T *testType }
+		if err := i.InjectContext(ctx, &fields); err != nil || fields.T.v != "request" {
+			t.Errorf("Expected %v, got %v, %v", "request", fields.T, err)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		i := New()
+		i.Provide(Retry(3, time.Hour, Func(func() (*testType, error) {
+			return nil, errors.New("unavailable")
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var tt *testType
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := i.InjectAsContext(ctx, &tt); err == nil {
+			t.Error("Expected the error of the abandoned retries")
+		}
+	})
+}
+
+func TestWithIdempotentResolve(t *testing.T) {
+	i := New()
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if err := i.Resolve(); !errors.Is(err, ErrAlreadyResolved) {
+		t.Errorf("Expected %v, got %v", ErrAlreadyResolved, err)
+	}
+
+	i = New(WithIdempotentResolve())
+	i.Provide(Value(&testType{v: "value"}))
+	for j := 0; j < 2; j++ {
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	}
+	var tt *testType
+	if err := i.InjectAs(&tt); err != nil || tt.v != "value" {
+		t.Errorf("Expected %v, got %v, %v", "value", tt, err)
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		i := New()
+		i.Provide(Values(&testType{v: "value"}, 42, "text"))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var n int
+		if err := i.InjectAs(&n); err != nil || n != 42 {
+			t.Errorf("Expected %v, got %v, %v", 42, n, err)
+		}
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		i := New()
+		i.Provide(Values(&testType{}, nil, 42, nil))
+		err := i.Resolve()
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		for _, pos := range []string{"position: 2 ", "position: 4 "} {
+			if !strings.Contains(err.Error(), pos) {
+				t.Errorf("Expected %q in %v", pos, err)
+			}
+		}
+	})
+}
+
+func TestTypedNilValues(t *testing.T) {
+	var nilPtr *testType
+	var nilReader *strings.Reader
+
+	for typ, p := range map[string]Provider{
+		"*wireless.testType": Value(nilPtr),
+		"*strings.Reader":    InterfaceValue(new(io.Reader), nilReader),
+	} {
+		i := New()
+		i.Provide(p, Value([]string(nil)))
+		if err := i.Resolve(); err == nil || !strings.Contains(err.Error(), "type: "+typ+" is a typed nil") {
+			t.Errorf("Expected typed nil error of %s, got %v", typ, err)
+		}
+	}
+
+	i := New(WithTypedNilValues())
+	i.Provide(Value(nilPtr))
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+}
+
+func TestBindTypes(t *testing.T) {
+	for name, p := range map[string]Provider{
+		"New":         Bind(new(io.Reader), new(*strings.Reader)),
+		"ReflectType": Bind(reflect.TypeOf(new(io.Reader)).Elem(), reflect.TypeOf(new(strings.Reader))),
+		"Generic":     BindOf[io.Reader, *strings.Reader](),
+	} {
+		t.Run(name, func(t *testing.T) {
+			i := New()
+			i.Provide(p, Value(strings.NewReader("text")))
+			if err := i.Resolve(); err != nil {
+				t.Fatal("Expected no error, got", err)
+			}
+			var r io.Reader
+			if err := i.InjectAs(&r); err != nil {
+				t.Error("Expected no error, got", err)
+			}
+		})
+	}
+
+	for name, c := range map[string]struct {
+		p        Provider
+		expected string
+	}{
+		"InterfaceValue": {Bind(io.Reader(nil), new(*strings.Reader)), "binding interface type is nil"},
+		"ImplValue":      {Bind(strings.NewReader(""), new(*strings.Reader)), "strings.Reader is neither an interface nor a function type"},
+		"TargetValue":    {Bind(new(io.Reader), strings.Reader{}), "needs the bound type declared with the `new` statement, e.g. new(strings.Reader)"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			i := New()
+			i.Provide(c.p)
+			if err := i.Resolve(); err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("Expected %q, got %v", c.expected, err)
+			}
+		})
+	}
+}
+
+func TestBindWithConstraint(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(strings.NewReader("text")),
+		BindWithConstraint(new(io.Reader), new(*strings.Reader), MustImplement(new(io.Seeker))),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	i = New()
+	i.Provide(
+		Value(strings.NewReader("text")),
+		BindWithConstraint(new(io.Reader), new(*strings.Reader), MustImplement(new(io.Closer))),
+	)
+	err := i.Resolve()
+	if err == nil || !strings.Contains(err.Error(), "does not implement the required interface: io.Closer") {
+		t.Errorf("Expected constraint violation, got %v", err)
+	}
+}
+
+func TestAlias(t *testing.T) {
+	i := New()
+	i.Provide(
+		Func(func() *bytes.Buffer { return new(bytes.Buffer) }),
+		Alias(new(*bytes.Buffer), new(io.Reader), new(io.Writer)),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	var (
+		r io.Reader
+		w io.Writer
+		b *bytes.Buffer
+	)
+	for _, as := range []interface{}{&r, &w, &b} {
+		if err := i.InjectAs(as); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	}
+	if r != io.Reader(b) || w != io.Writer(b) {
+		t.Errorf("Expected the same instance, got %p, %p and %p", r, w, b)
+	}
+
+	i = New()
+	i.Provide(
+		Func(func() *bytes.Buffer { return new(bytes.Buffer) }),
+		Alias(new(*bytes.Buffer), new(io.Reader), new(io.Closer)),
+	)
+	if err := i.Resolve(); err == nil {
+		t.Error("Expected error for the alias not implemented by the type, got nil")
+	}
+}
+
+func TestCleanIdempotent(t *testing.T) {
+	var calls int32
+	i := New()
+	i.Provide(Func(func() (*testType, func()) {
+		return &testType{}, func() {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&calls, 1)
+		}
+	}))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var tt *testType
+	if err := i.InjectAs(&tt); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var wg sync.WaitGroup
+	for j := 0; j < 3; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			i.Clean()
+			if atomic.LoadInt32(&calls) != 1 {
+				t.Error("Expected Clean to return after the cleanup completed")
+			}
+		}()
+	}
+	wg.Wait()
+	i.Clean()
+	if calls != 1 {
+		t.Errorf("Expected %v, got %v", 1, calls)
+	}
 }
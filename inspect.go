@@ -0,0 +1,148 @@
+package wireless
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ProviderKind is the kind of the declared provider described by ProviderInfo.
+type ProviderKind int
+
+// Provider kinds described by Inspect.
+const (
+	// KindFunc is the provider function declared with Func or FuncOf.
+	KindFunc ProviderKind = iota + 1
+	// KindValue is the value declared with Value.
+	KindValue
+	// KindBinding is the binding declared with Bind or Convertible.
+	KindBinding
+	// KindInterfaceValue is the value declared with InterfaceValue.
+	KindInterfaceValue
+	// KindDecorator is the decorator function declared with Decorate.
+	KindDecorator
+	// KindOther is any other provider, e.g. Curry, NoOp or Flags, which is not described further.
+	KindOther
+)
+
+// ProviderInfo describes the declared provider.
+type ProviderInfo struct {
+	Kind ProviderKind
+	// Func is the function of the KindFunc and KindDecorator providers. The function of the provider declared
+	// with FuncOf returns the type T.
+	Func interface{}
+	// Value is the value of the KindValue and KindInterfaceValue providers.
+	Value interface{}
+	// Type is the provided type, the interface type of the bindings or the decorated type of the decorators.
+	// It is nil if the provider is invalid.
+	Type reflect.Type
+	// Target is the type the KindBinding provider is bound to.
+	Target reflect.Type
+	// Dependencies are the argument types of the KindFunc providers, and of the KindDecorator providers except for
+	// the decorated type.
+	Dependencies []reflect.Type
+	// Convertible is set for the bindings declared with Convertible.
+	Convertible bool
+	Group       string
+	Name        string
+	Namespace   string
+	Scope       string
+	// Kinds are the scope kinds the provider is allowed in with AllowedIn.
+	Kinds       []string
+	IfNotExists bool
+	Override    bool
+	Weight      int
+	// Pooled is set for the providers declared with Pooled.
+	Pooled bool
+	// Weak is set for the providers declared with Weak, which drop their instance after the Idle duration.
+	Weak bool
+	Idle time.Duration
+	// TTL is the expiration of the instance of the providers declared with TTL.
+	TTL time.Duration
+	// NonShared is set for the bindings declared with NonShared.
+	NonShared bool
+	// Source is the file and line of the provider declaration.
+	Source string
+	// Provider is the described provider.
+	Provider Provider
+}
+
+// Inspect describes the providers, with the provider sets flattened, in the order of declaration.
+// It allows adapting the providers to other dependency injection frameworks or generating code out of them.
+func Inspect(providers ...Provider) []ProviderInfo {
+	var infos []ProviderInfo
+	for _, p := range providers {
+		var (
+			info = ProviderInfo{Kind: KindOther, Provider: p}
+			o    providerOptions
+		)
+		switch pt := p.(type) {
+		case ProviderSet:
+			infos = append(infos, Inspect(pt...)...)
+			continue
+		case *funcProvider:
+			info.Kind, info.Func, o = KindFunc, pt.v, pt.providerOptions
+			if pf, err := pt.providerFunc(); err == nil {
+				info.Func, info.Type, info.Dependencies = pf.value.Interface(), pf.out, pf.inTypes
+			}
+		case *valueProvider:
+			info.Kind, info.Value, info.Type, o = KindValue, pt.v, reflect.TypeOf(pt.v), pt.providerOptions
+		case *bindingProvider:
+			info.Kind, info.Convertible, o = KindBinding, pt.convertible, pt.providerOptions
+			if it, tt, err := pt.types(); err == nil {
+				info.Type, info.Target = it, tt
+			}
+		case *interfaceValueProvider:
+			info.Kind, info.Value, o = KindInterfaceValue, pt.value, pt.providerOptions
+			if it, v, err := pt.convert(); err == nil {
+				info.Type, info.Value = it, v.Interface()
+			}
+		case *decoratorProvider:
+			info.Kind, info.Func, o = KindDecorator, pt.v, pt.providerOptions
+			if ft := reflect.TypeOf(pt.v); ft != nil && ft.Kind() == reflect.Func && ft.NumIn() > 0 {
+				info.Type = ft.In(0)
+				for j := 1; j < ft.NumIn(); j++ {
+					info.Dependencies = append(info.Dependencies, ft.In(j))
+				}
+			}
+		}
+		info.Group, info.Name, info.Namespace, info.Scope = o.group, o.name, o.namespace, o.scope
+		info.Kinds, info.Source = o.kinds, o.source
+		info.IfNotExists, info.Override, info.Weight = o.ifNotExists, o.override, o.weight
+		info.Pooled, info.Weak, info.Idle, info.TTL, info.NonShared = o.pooled, o.weak, o.idle, o.ttl, o.nonShared
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// SortProviders sorts the described providers stably by their namespace, scope, type, name, group and kind, so that
+// the reports generated out of them do not depend on the order in which the provider sets are composed.
+func SortProviders(infos []ProviderInfo) {
+	sort.SliceStable(infos, func(j, k int) bool {
+		a, b := infos[j], infos[k]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Scope != b.Scope {
+			return a.Scope < b.Scope
+		}
+		if at, bt := typeString(a.Type), typeString(b.Type); at != bt {
+			return at < bt
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		return a.Kind < b.Kind
+	})
+}
+
+// typeString returns the name of the type, or an empty string if it is nil.
+func typeString(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
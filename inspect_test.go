@@ -0,0 +1,54 @@
+package wireless
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	set := NewSet(
+		Func(func() *strings.Reader { return strings.NewReader("") }),
+		NewSet(
+			Bind(new(io.Reader), new(*strings.Reader)),
+			Group("readers", Value(&initType{})),
+		),
+		Decorate(func(r *strings.Reader) *strings.Reader { return r }),
+	)
+	infos := Inspect(set)
+	if len(infos) != 4 {
+		t.Fatalf("Expected %v, got %v", 4, len(infos))
+	}
+	readerType := reflect.TypeOf(new(strings.Reader))
+	expected := []ProviderInfo{
+		{Kind: KindFunc, Type: readerType},
+		{Kind: KindBinding, Type: reflect.TypeOf(new(io.Reader)).Elem(), Target: readerType},
+		{Kind: KindValue, Type: reflect.TypeOf(new(initType)), Group: "readers"},
+		{Kind: KindDecorator, Type: readerType},
+	}
+	for j, info := range infos {
+		e := expected[j]
+		if info.Kind != e.Kind || info.Type != e.Type || info.Target != e.Target || info.Group != e.Group {
+			t.Errorf("Expected %v, got %v", e, info)
+		}
+	}
+}
+
+func TestSortProviders(t *testing.T) {
+	infos := Inspect(
+		Named("b", Value(&testType{})),
+		Value(1),
+		Named("a", Value(&testType{})),
+		Namespace("z", Value("text")),
+	)
+	SortProviders(infos)
+	var order []string
+	for _, info := range infos {
+		order = append(order, info.Namespace+"/"+info.Type.String()+"/"+info.Name)
+	}
+	expected := []string{"/*wireless.testType/a", "/*wireless.testType/b", "/int/", "z/string/"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected %v, got %v", expected, order)
+	}
+}
@@ -0,0 +1,168 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// proxies are the factories of the interface proxies registered with RegisterProxy.
+var proxies sync.Map
+
+// Invocation describes a single call of the intercepted function or interface method.
+type Invocation struct {
+	// Name is the name of the called method of the intercepted interface, or the name of the intercepted
+	// function type.
+	Name string
+	// Args are the arguments of the call.
+	Args []interface{}
+	// Duration is the time the call took. It is set only for the After hook.
+	Duration time.Duration
+	// Err is the error returned by the call, if the last returned value is an error. It is set only for the After hook.
+	Err error
+}
+
+// Interceptor defines the hooks executed around every call of the intercepted value.
+type Interceptor struct {
+	Before func(inv *Invocation)
+	After  func(inv *Invocation)
+}
+
+// Intercept wraps the value of the provided interface or function type with the interceptor hooks. The target
+// is defined with the `new` statement, same as in Bind. Interception works as a decorator, thus it might be ordered
+// with Weight.
+//
+// Reflection is not able to create new types implementing interface methods, thus the proxies of the interfaces
+// are generated with wirelessgen.Proxies, which registers them with RegisterProxy in the init function
+// of the generated file. Intercepting the interface without the registered proxy fails the Resolve.
+// Example:
+//
+//	wireless.Intercept(new(HandlerFunc), wireless.Interceptor{After: logCall})
+//	wireless.Intercept(new(Repository), wireless.Interceptor{After: logCall})
+func Intercept(target interface{}, interceptor Interceptor) Provider {
+	return &interceptorProvider{target: target, interceptor: interceptor}
+}
+
+// ProxyHandler is called by the interface proxy for each method call, with the name and arguments of the method
+// and the function calling the method of the proxied value, which returns the error returned by the method, if any.
+type ProxyHandler func(method string, args []interface{}, call func() error)
+
+// RegisterProxy registers the factory of the proxies of the interface defined with the `new` statement, used
+// by Intercept. The factory returns the proxy of the target value calling the handler for each method call.
+// It is called by the code generated with wirelessgen.Proxies.
+// Example:
+//
+//	func init() {
+//		wireless.RegisterProxy(new(Repository), func(target interface{}, h wireless.ProxyHandler) interface{} {
+//			return proxyRepository{target: target.(Repository), h: h}
+//		})
+//	}
+func RegisterProxy(iface interface{}, factory func(target interface{}, h ProxyHandler) interface{}) {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("proxied interface is not defined with `new` statement: %T", iface))
+	}
+	if factory == nil {
+		panic(fmt.Sprintf("proxy factory of the interface: %s is nil", t.Elem()))
+	}
+	proxies.Store(t.Elem(), factory)
+}
+
+type interceptorProvider struct {
+	target      interface{}
+	interceptor Interceptor
+	providerOptions
+}
+
+func (p *interceptorProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&p.providerOptions)
+	}
+}
+
+// decorator creates the decorator provider function wrapping the target function type value.
+func (p *interceptorProvider) decorator() (*providerFunc, error) {
+	t := reflect.TypeOf(p.target)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("intercepted type is not defined with `new` statement: %T", p.target)
+	}
+	t = t.Elem()
+	var wrap func(in []reflect.Value) []reflect.Value
+	switch t.Kind() {
+	case reflect.Interface:
+		factory, ok := proxies.Load(t)
+		if !ok {
+			return nil, fmt.Errorf("proxy of the intercepted interface: %s is not generated, see wirelessgen.Proxies", t)
+		}
+		wrap = p.interfaceWrapper(t, factory.(func(interface{}, ProxyHandler) interface{}))
+	case reflect.Func:
+		wrap = p.funcWrapper(t)
+	default:
+		return nil, fmt.Errorf("intercepted type: %s is neither an interface nor a function type", t)
+	}
+	return &providerFunc{
+		value:      reflect.MakeFunc(reflect.FuncOf([]reflect.Type{t}, []reflect.Type{t}, false), wrap),
+		inTypes:    []reflect.Type{t},
+		out:        t,
+		errOut:     -1,
+		cleanupOut: -1,
+		weight:     p.weight,
+	}, nil
+}
+
+// invoke calls the function between the interceptor hooks.
+func (p *interceptorProvider) invoke(inv *Invocation, call func() error) {
+	ic := p.interceptor
+	if ic.Before != nil {
+		ic.Before(inv)
+	}
+	start := time.Now()
+	err := call()
+	inv.Duration = time.Since(start)
+	inv.Err = err
+	if ic.After != nil {
+		ic.After(inv)
+	}
+}
+
+// interfaceWrapper returns the function wrapping the value of the interface with its proxy.
+func (p *interceptorProvider) interfaceWrapper(t reflect.Type, factory func(interface{}, ProxyHandler) interface{}) func(in []reflect.Value) []reflect.Value {
+	h := func(method string, args []interface{}, call func() error) {
+		p.invoke(&Invocation{Name: method, Args: args}, call)
+	}
+	return func(in []reflect.Value) []reflect.Value {
+		if in[0].IsNil() {
+			return in
+		}
+		proxy := reflect.New(t).Elem()
+		proxy.Set(reflect.ValueOf(factory(in[0].Interface(), h)))
+		return []reflect.Value{proxy}
+	}
+}
+
+// funcWrapper returns the function wrapping the value of the function type with the function calling the hooks.
+func (p *interceptorProvider) funcWrapper(t reflect.Type) func(in []reflect.Value) []reflect.Value {
+	return func(in []reflect.Value) []reflect.Value {
+		fn := in[0]
+		return []reflect.Value{reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+			inv := &Invocation{Name: t.String(), Args: make([]interface{}, len(args))}
+			for j, a := range args {
+				inv.Args[j] = a.Interface()
+			}
+			var outs []reflect.Value
+			p.invoke(inv, func() error {
+				if t.IsVariadic() {
+					outs = fn.CallSlice(args)
+				} else {
+					outs = fn.Call(args)
+				}
+				if n := len(outs); n > 0 && t.Out(n-1) == errorType && !outs[n-1].IsNil() {
+					return outs[n-1].Interface().(error)
+				}
+				return nil
+			})
+			return outs
+		})}
+	}
+}
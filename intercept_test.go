@@ -0,0 +1,137 @@
+package wireless
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type greetFunc func(name string) (string, error)
+
+func TestIntercept(t *testing.T) {
+	var before, after []*Invocation
+	greet := greetFunc(func(name string) (string, error) {
+		if name == "" {
+			return "", errors.New("empty name")
+		}
+		return "hello " + name, nil
+	})
+
+	i := New()
+	i.Provide(
+		Value(greet),
+		Intercept(new(greetFunc), Interceptor{
+			Before: func(inv *Invocation) { before = append(before, inv) },
+			After:  func(inv *Invocation) { after = append(after, inv) },
+		}),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var fn greetFunc
+	err = i.InjectAs(&fn)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	if s, _ := fn("world"); s != "hello world" {
+		t.Errorf("Expected %v, got %v", "hello world", s)
+	}
+	if _, err = fn(""); err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	if len(before) != 2 || len(after) != 2 {
+		t.Fatalf("Expected 2 invocations, got before: %d, after: %d", len(before), len(after))
+	}
+	if before[0].Args[0] != "world" || after[0].Err != nil {
+		t.Errorf("Expected first call with 'world' and no error, got %v", after[0])
+	}
+	if after[1].Err == nil {
+		t.Error("Expected second call error, got nil")
+	}
+}
+
+type greeter interface {
+	Greet(name string) (string, error)
+}
+
+type englishGreeter struct{}
+
+func (englishGreeter) Greet(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("empty name")
+	}
+	return "hello " + name, nil
+}
+
+// proxyGreeter is the proxy of the greeter as generated by wirelessgen.Proxies.
+type proxyGreeter struct {
+	target greeter
+	h      ProxyHandler
+}
+
+func (p proxyGreeter) Greet(a0 string) (r0 string, r1 error) {
+	p.h("Greet", []interface{}{a0}, func() error {
+		r0, r1 = p.target.Greet(a0)
+		return r1
+	})
+	return
+}
+
+func TestInterceptInterface(t *testing.T) {
+	t.Run("Proxy", func(t *testing.T) {
+		RegisterProxy(new(greeter), func(target interface{}, h ProxyHandler) interface{} {
+			return proxyGreeter{target: target.(greeter), h: h}
+		})
+		t.Cleanup(func() { proxies.Delete(reflect.TypeOf(new(greeter)).Elem()) })
+
+		var after []*Invocation
+		i := New()
+		i.Provide(
+			Value(englishGreeter{}),
+			Bind(new(greeter), new(englishGreeter)),
+			Intercept(new(greeter), Interceptor{After: func(inv *Invocation) { after = append(after, inv) }}),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+
+		var g greeter
+		if err := i.InjectAs(&g); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if s, _ := g.Greet("world"); s != "hello world" {
+			t.Errorf("Expected %v, got %v", "hello world", s)
+		}
+		if _, err := g.Greet(""); err == nil {
+			t.Error("Expected error, got nil")
+		}
+
+		if len(after) != 2 {
+			t.Fatalf("Expected 2 invocations, got %d", len(after))
+		}
+		if after[0].Name != "Greet" || after[0].Args[0] != "world" || after[0].Err != nil {
+			t.Errorf("Expected first call of Greet with 'world' and no error, got %+v", after[0])
+		}
+		if after[1].Err == nil {
+			t.Error("Expected second call error, got nil")
+		}
+	})
+
+	t.Run("Not generated", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Value(testType{}),
+			Bind(new(interfaceType), new(testType)),
+			Intercept(new(interfaceType), Interceptor{}),
+		)
+		err := i.Resolve()
+		if err == nil || !strings.Contains(err.Error(), "proxy of the intercepted interface: wireless.interfaceType is not generated") {
+			t.Errorf("Expected the not generated proxy error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package wireless
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The scope kinds known to every injector with the scope kinds registry. The root injector is of the ScopeRoot kind,
+// while the child scopes are created with NewScope of any other registered kind.
+const (
+	ScopeRoot    = "root"
+	ScopeRequest = "request"
+	ScopeSession = "session"
+	ScopeJob     = "job"
+)
+
+// WithScopeKinds enables the registry of the scope kinds, which makes NewScope refuse the kinds other than
+// ScopeRequest, ScopeSession, ScopeJob and the custom kinds passed to the option. The registry is inherited
+// by the child scopes, which might register additional kinds. It only validates the kind names, used by NewScope,
+// ScopedTo and AllowedIn, so that a misspelled kind fails the Resolve instead of never matching any scope.
+func WithScopeKinds(kinds ...string) Option {
+	return func(i *Injector) {
+		registered := map[string]bool{ScopeRequest: true, ScopeSession: true, ScopeJob: true}
+		for k := range i.scopeKinds {
+			registered[k] = true
+		}
+		for _, k := range kinds {
+			registered[k] = true
+		}
+		i.scopeKinds = registered
+	}
+}
+
+// AllowedIn restricts the provider to the scopes of the given kinds, so that e.g. the per-request state is not
+// provided to the long-lived scopes by mistake. Providing it to the scope of other kind fails the Resolve.
+// Unlike ScopedTo, AllowedIn does not change where the provider is resolved nor how many instances it constructs:
+// the provider is registered in the scope it is provided to, and AllowedIn only checks the kind of that scope.
+// ScopedTo declares the provider function once in an ancestor, so that every child scope of the kind constructs
+// its own instance. The two might be combined, in which case the provider scoped with ScopedTo is checked
+// against the kind it is scoped to rather than the kind of the declaring scope.
+// Example:
+//
+//	wireless.AllowedIn(wireless.Func(NewPrincipal), wireless.ScopeRequest, wireless.ScopeJob)
+func AllowedIn(p Provider, kinds ...string) Provider {
+	p.setOptions(func(o *providerOptions) { o.kinds = kinds })
+	return p
+}
+
+// scopeKind returns the kind of the scope, or ScopeRoot for the root injector.
+func (i *Injector) scopeKind() string {
+	if i.kind == "" {
+		return ScopeRoot
+	}
+	return i.kind
+}
+
+// checkScopeKind checks the kind of the child scope against the registry of the scope kinds.
+func (i *Injector) checkScopeKind() {
+	if i.scopeKinds == nil {
+		return
+	}
+	if !i.scopeKinds[i.kind] {
+		i.errors = append(i.errors, fmt.Errorf("scope kind: %q is not registered", i.kind))
+	}
+}
+
+// allowedIn checks whether the provider with given options might be provided to the scope, reporting an error
+// otherwise.
+func (i *Injector) allowedIn(p Provider, o providerOptions) bool {
+	kind := i.scopeKind()
+	if o.scope != "" {
+		kind = o.scope
+		if i.scopeKinds != nil && !i.scopeKinds[kind] {
+			i.errors = append(i.errors, fmt.Errorf("provider of type: %s is scoped to the not registered scope kind: %q", Inspect(p)[0].Type, kind))
+			return false
+		}
+	}
+	if len(o.kinds) == 0 {
+		return true
+	}
+	for _, k := range o.kinds {
+		if i.scopeKinds != nil && k != ScopeRoot && !i.scopeKinds[k] {
+			i.errors = append(i.errors, fmt.Errorf("provider of type: %s is allowed in the not registered scope kind: %q", Inspect(p)[0].Type, k))
+			return false
+		}
+	}
+	for _, k := range o.kinds {
+		if k == kind {
+			return true
+		}
+	}
+	i.errors = append(i.errors, fmt.Errorf("provider of type: %s is allowed only in the scopes of kinds: %s and could not be provided to the %q scope", Inspect(p)[0].Type, strings.Join(o.kinds, ", "), kind))
+	return false
+}
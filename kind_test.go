@@ -0,0 +1,83 @@
+package wireless
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScopeKinds(t *testing.T) {
+	t.Run("Registry", func(t *testing.T) {
+		i := New(WithScopeKinds("tenant"))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		for _, kind := range []string{ScopeRequest, ScopeSession, ScopeJob, "tenant"} {
+			if err := i.NewScope(kind).Resolve(); err != nil {
+				t.Error("Expected no error, got", err)
+			}
+		}
+		err := i.NewScope("command").Resolve()
+		if err == nil || !strings.Contains(err.Error(), `scope kind: "command" is not registered`) {
+			t.Errorf("Expected not registered scope kind error, got %v", err)
+		}
+		if err := i.NewScope("command", WithScopeKinds("command")).Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+
+	t.Run("NoRegistry", func(t *testing.T) {
+		i := New()
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.NewScope("command").Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+
+	t.Run("AllowedIn", func(t *testing.T) {
+		i := New()
+		i.Provide(AllowedIn(Value(&testType{}), ScopeRequest))
+		err := i.Resolve()
+		if err == nil || !strings.Contains(err.Error(), `could not be provided to the "root" scope`) {
+			t.Errorf("Expected not allowed provider error, got %v", err)
+		}
+
+		i = New()
+		i.Provide(ScopedTo(ScopeRequest, AllowedIn(Func(func() *testType { return &testType{} }), ScopeRequest)))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		s := i.NewScope(ScopeRequest)
+		s.Provide(AllowedIn(Value(&initType{}), ScopeRequest, ScopeJob))
+		if err := s.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := s.InjectAs(&tt); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		s = i.NewScope(ScopeSession)
+		s.Provide(AllowedIn(Named("init", Value(&initType{})), ScopeRequest))
+		if err := s.Resolve(); err == nil {
+			t.Error("Expected error for the provider not allowed in the session scope, got nil")
+		}
+	})
+
+	t.Run("ScopedTo", func(t *testing.T) {
+		i := New(WithScopeKinds())
+		i.Provide(ScopedTo("command", Func(func() *testType { return &testType{} })))
+		err := i.Resolve()
+		if err == nil || !strings.Contains(err.Error(), `not registered scope kind: "command"`) {
+			t.Errorf("Expected not registered scope kind error, got %v", err)
+		}
+
+		i = New(WithScopeKinds())
+		i.Provide(AllowedIn(Value(&testType{}), ScopeRoot, "comand"))
+		err = i.Resolve()
+		if err == nil || !strings.Contains(err.Error(), `allowed in the not registered scope kind: "comand"`) {
+			t.Errorf("Expected not registered scope kind error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+module github.com/routercore/wireless/koanfwireless
+
+go 1.23.0
+
+replace github.com/routercore/wireless => ../
+
+require (
+	github.com/knadh/koanf/providers/confmap v1.0.1
+	github.com/knadh/koanf/v2 v2.3.7
+	github.com/routercore/wireless v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
+	github.com/knadh/koanf/maps v0.1.2 // indirect
+	github.com/mitchellh/copystructure v1.2.0 // indirect
+	github.com/mitchellh/reflectwalk v1.0.2 // indirect
+)
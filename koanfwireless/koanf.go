@@ -0,0 +1,58 @@
+// Package koanfwireless binds the sections of the github.com/knadh/koanf configuration to the typed config structs
+// provided by wireless.Injector.
+package koanfwireless
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/routercore/wireless"
+)
+
+// Binder binds the koanf configuration sections to the config structs.
+type Binder struct {
+	k         *koanf.Koanf
+	lock      sync.Mutex
+	reloaders []func(i *wireless.Injector) error
+}
+
+// New creates a new Binder of the koanf instance.
+func New(k *koanf.Koanf) *Binder {
+	return &Binder{k: k}
+}
+
+// Section declares the provider of the *T config struct decoded from the koanf configuration under given path.
+// An empty path decodes the whole configuration.
+func Section[T any](b *Binder, path string) wireless.Provider {
+	decode := func() (*T, error) {
+		c := new(T)
+		if err := b.k.Unmarshal(path, c); err != nil {
+			return nil, fmt.Errorf("decoding koanf section: %q failed: %w", path, err)
+		}
+		return c, nil
+	}
+	b.lock.Lock()
+	b.reloaders = append(b.reloaders, func(i *wireless.Injector) error {
+		c, err := decode()
+		if err != nil {
+			return err
+		}
+		return i.Swap(c)
+	})
+	b.lock.Unlock()
+	return wireless.Func(decode)
+}
+
+// Reload decodes all the sections again and swaps them in the injector.
+// It is meant to be called after the koanf configuration is reloaded, e.g. from the provider's Watch callback.
+func (b *Binder) Reload(i *wireless.Injector) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, r := range b.reloaders {
+		if err := r(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package koanfwireless
+
+import (
+	"testing"
+
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+	"github.com/routercore/wireless"
+)
+
+type dbConfig struct {
+	DSN string `koanf:"dsn"`
+}
+
+func TestSection(t *testing.T) {
+	k := koanf.New(".")
+	if err := k.Load(confmap.Provider(map[string]interface{}{"db.dsn": "postgres://primary"}, "."), nil); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	b := New(k)
+
+	i := wireless.New()
+	i.Provide(Section[dbConfig](b, "db"))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var c *dbConfig
+	if err := i.InjectAs(&c); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if c.DSN != "postgres://primary" {
+		t.Errorf("Expected %v, got %v", "postgres://primary", c.DSN)
+	}
+
+	if err := k.Load(confmap.Provider(map[string]interface{}{"db.dsn": "postgres://replica"}, "."), nil); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := b.Reload(i); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.InjectAs(&c); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if c.DSN != "postgres://replica" {
+		t.Errorf("Expected %v, got %v", "postgres://replica", c.DSN)
+	}
+}
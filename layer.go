@@ -0,0 +1,194 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// ErrLayerViolation is matched by the LayerViolationError.
+var ErrLayerViolation = errors.New("layer violation")
+
+// LayerRule allows or denies the dependencies of the providers declared in the packages matching the From pattern
+// on the providers declared in the packages matching the To pattern. The patterns are the globs of the package
+// paths, with '*' matching within the single path element and '**' matching any number of the elements,
+// e.g. 'example.com/app/handlers/**' or '**/repo/**'.
+type LayerRule struct {
+	From  string
+	To    string
+	Allow bool
+}
+
+// String returns the description of the rule, e.g. 'handlers/** -> repo/** denied'.
+func (r LayerRule) String() string {
+	if r.Allow {
+		return r.From + " -> " + r.To + " allowed"
+	}
+	return r.From + " -> " + r.To + " denied"
+}
+
+// DenyLayer returns the rule denying the dependencies between the packages matching the patterns.
+func DenyLayer(from, to string) LayerRule {
+	return LayerRule{From: from, To: to}
+}
+
+// AllowLayer returns the rule allowing the dependencies between the packages matching the patterns, which takes
+// precedence over the following rules, e.g. to make an exception of the denied dependencies.
+func AllowLayer(from, to string) LayerRule {
+	return LayerRule{From: from, To: to, Allow: true}
+}
+
+// LayerViolationError is reported by Validate for the dependency denied by the LayerRule.
+type LayerViolationError struct {
+	Rule LayerRule
+	// From is the declaration of the dependent provider, and FromPackage is the package it is declared in.
+	From        ProviderSite
+	FromPackage string
+	// To is the declaration of the provider of the dependency, and ToPackage is the package it is declared in.
+	To        ProviderSite
+	ToPackage string
+}
+
+// Error implements error interface.
+func (e *LayerViolationError) Error() string {
+	return fmt.Sprintf("dependency of the package: %s on the package: %s is denied by the rule: %s, dependent: %s, dependency: %s",
+		e.FromPackage, e.ToPackage, e.Rule, e.From, e.To)
+}
+
+// Is matches the ErrLayerViolation.
+func (e *LayerViolationError) Is(target error) bool {
+	return target == ErrLayerViolation
+}
+
+// WithLayerRules enables the check, reported by Validate, of the dependencies between the packages the providers are
+// declared in. The first rule matching both packages decides whether the dependency is allowed, while
+// the dependencies not matched by any rule are allowed. The values are considered to be declared in the packages
+// of their types.
+// Example:
+//
+//	wireless.New(wireless.WithLayerRules(
+//		wireless.AllowLayer("**/handlers/**", "**/repo/readonly"),
+//		wireless.DenyLayer("**/handlers/**", "**/repo/**"),
+//	))
+func WithLayerRules(rules ...LayerRule) Option {
+	return func(i *Injector) {
+		i.layerRules = append(i.layerRules, rules...)
+	}
+}
+
+// lintLayers reports the dependencies denied by the layer rules.
+func (i *Injector) lintLayers() []error {
+	if len(i.layerRules) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, p := range i.allProviders() {
+		from := p.sourcePackage()
+		if from == "" {
+			continue
+		}
+		for _, in := range p.dependencyTypes() {
+			to, site := i.dependencySource(in)
+			if to == "" {
+				continue
+			}
+			if rule, ok := i.layerRule(from, to); ok && !rule.Allow {
+				errs = append(errs, &LayerViolationError{Rule: rule, From: i.providerSite(p), FromPackage: from, To: site, ToPackage: to})
+			}
+		}
+	}
+	return errs
+}
+
+// layerRule returns the first rule matching the packages.
+func (i *Injector) layerRule(from, to string) (LayerRule, bool) {
+	for _, r := range i.layerRules {
+		if matchPackage(r.From, from) && matchPackage(r.To, to) {
+			return r, true
+		}
+	}
+	return LayerRule{}, false
+}
+
+// dependencySource returns the package the provider of the dependency is declared in, and its declaration site.
+// The package is empty for the types provided by the injector or by the ancestors of the scope.
+func (i *Injector) dependencySource(in reflect.Type) (string, ProviderSite) {
+	t := in
+	if bt, ok := i.bindings[in]; ok {
+		t = bt
+	}
+	if p, ok := i.providersMap[t]; ok {
+		return p.sourcePackage(), i.site(t)
+	}
+	if _, ok := i.values[t]; ok && !isBuiltin(t) {
+		return typePackage(t), i.site(t)
+	}
+	return "", ProviderSite{}
+}
+
+// providerSite returns the declaration of the registered, group member or named provider function.
+func (i *Injector) providerSite(p *providerFunc) ProviderSite {
+	if i.providersMap[p.out] == p {
+		return i.site(p.out)
+	}
+	return memberSite(p)
+}
+
+// sourcePackage returns the package path the provider function is declared in, or an empty string if it is
+// not known.
+func (p *providerFunc) sourcePackage() string {
+	if !p.value.IsValid() {
+		return ""
+	}
+	fn := runtime.FuncForPC(p.value.Pointer())
+	if fn == nil || strings.HasPrefix(fn.Name(), "reflect.") {
+		return ""
+	}
+	return funcPackage(fn.Name())
+}
+
+// funcPackage returns the package path of the qualified function name, e.g. 'gopkg.in/yaml%2ev3.(*Decoder).Decode'
+// or 'example.com/app.NewServer.func1'. The symbol, including the receiver, closure and generic suffixes,
+// follows the first dot of the last path element, as the dots of the last path element are escaped by the linker.
+func funcPackage(name string) string {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	pkg := name[:slash+1+dot]
+	if unescaped, err := url.PathUnescape(pkg); err == nil {
+		return unescaped
+	}
+	return pkg
+}
+
+// matchPackage reports whether the package path matches the glob pattern.
+func matchPackage(pattern, pkg string) bool {
+	return matchElements(strings.Split(pattern, "/"), strings.Split(pkg, "/"))
+}
+
+func matchElements(pattern, elems []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for j := 0; j <= len(elems); j++ {
+				if matchElements(pattern[1:], elems[j:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+			return false
+		}
+		pattern, elems = pattern[1:], elems[1:]
+	}
+	return len(elems) == 0
+}
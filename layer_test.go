@@ -0,0 +1,116 @@
+package wireless
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLayerRules(t *testing.T) {
+	provide := func(i *Injector) {
+		i.Provide(
+			Value(&xml.Name{}),
+			Func(func(n *xml.Name, lc *Lifecycle) *testType { return &testType{} }),
+			Func(func(tt *testType) testType { return *tt }),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+	}
+
+	t.Run("denied", func(t *testing.T) {
+		i := New(WithLayerRules(DenyLayer("github.com/routercore/**", "encoding/*")))
+		provide(i)
+		err := i.Validate()
+		if !errors.Is(err, ErrLayerViolation) {
+			t.Fatalf("Expected the layer violation, got %v", err)
+		}
+		if strings.Count(err.Error(), "is denied") != 1 {
+			t.Errorf("Expected the single layer violation, got %v", err)
+		}
+		var lv *LayerViolationError
+		if !errors.As(err, &lv) {
+			t.Fatalf("Expected the LayerViolationError, got %v", err)
+		}
+		if lv.FromPackage != "github.com/routercore/wireless" || lv.ToPackage != "encoding/xml" {
+			t.Errorf("Expected %v, got %v", "github.com/routercore/wireless -> encoding/xml", lv.FromPackage+" -> "+lv.ToPackage)
+		}
+		if lv.From.Signature != "func(*xml.Name, *wireless.Lifecycle) *wireless.testType" || !strings.Contains(lv.From.Source, "layer_test.go") {
+			t.Errorf("Expected the dependent declaration, got %v", lv.From)
+		}
+		if !strings.Contains(lv.To.Source, "layer_test.go") {
+			t.Errorf("Expected the dependency declaration, got %v", lv.To)
+		}
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		i := New(WithLayerRules(
+			AllowLayer("**/wireless", "encoding/xml"),
+			DenyLayer("**", "encoding/**"),
+		))
+		provide(i)
+		if err := i.Validate(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+
+	t.Run("same package", func(t *testing.T) {
+		i := New(WithLayerRules(DenyLayer("**/wireless", "**/wireless")))
+		provide(i)
+		err := i.Validate()
+		var lv *LayerViolationError
+		if !errors.As(err, &lv) || !strings.Contains(lv.To.Signature, "*wireless.testType") {
+			t.Errorf("Expected the violation of the dependency on the provider of: *wireless.testType, got %v", err)
+		}
+	})
+
+	t.Run("source package", func(t *testing.T) {
+		for _, c := range []struct {
+			fn  interface{}
+			pkg string
+		}{
+			{xml.Marshal, "encoding/xml"},
+			{(*xml.Decoder).Decode, "encoding/xml"},
+			{func() *testType { return &testType{} }, "github.com/routercore/wireless"},
+			{newMemoryRepository[testType], "github.com/routercore/wireless"},
+		} {
+			p, err := newProviderFunc(c.fn)
+			if err != nil {
+				t.Fatal("Expected no error, got", err)
+			}
+			if got := p.sourcePackage(); got != c.pkg {
+				t.Errorf("Expected %v, got %v", c.pkg, got)
+			}
+		}
+	})
+
+	t.Run("escaped package", func(t *testing.T) {
+		for name, want := range map[string]string{
+			"gopkg.in/yaml%2ev3.(*Decoder).Decode": "gopkg.in/yaml.v3",
+			"gopkg.in/yaml%2ev3.Marshal":           "gopkg.in/yaml.v3",
+			"example.com/app.NewServer.func1":      "example.com/app",
+			"main.main":                            "main",
+		} {
+			if got := funcPackage(name); got != want {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		}
+	})
+
+	t.Run("match", func(t *testing.T) {
+		for pattern, want := range map[string]bool{
+			"example.com/app/handlers/**":   true,
+			"**/handlers/**":                true,
+			"**/handlers":                   false,
+			"example.com/*/handlers/*":      true,
+			"example.com/*/handlers":        false,
+			"example.com/app/**/users":      true,
+			"example.com/app/handlers/user": false,
+		} {
+			if got := matchPackage(pattern, "example.com/app/handlers/users"); got != want {
+				t.Errorf("Expected %v, got %v for the pattern: %s", want, got, pattern)
+			}
+		}
+	})
+}
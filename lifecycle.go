@@ -0,0 +1,284 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Hook is the pair of functions called when the injector is started and stopped.
+// Any of the functions might be nil.
+type Hook struct {
+	// Name identifies the hook in the errors. Defaults to the name of the provider function appending the hook,
+	// or to the index of the hook if it is appended outside of the provider functions.
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// name returns the name of the hook at the index.
+func (h Hook) name(index int) string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return strconv.Itoa(index)
+}
+
+var lifecyclePtrType = reflect.TypeOf(new(Lifecycle))
+
+// Lifecycle collects the hooks of the provided instances. It is injected by the injector, so that
+// the provider functions might append the hooks of the instances they create.
+// Example:
+//
+//	func NewServer(lc *wireless.Lifecycle) *Server {
+//		s := &Server{}
+//		lc.Append(wireless.Hook{OnStart: s.Listen, OnStop: s.Shutdown})
+//		return s
+//	}
+type Lifecycle struct {
+	lock    sync.Mutex
+	hooks   []Hook
+	started int
+	group   *runGroup
+	emit    func(e Event)
+	// of is the lifecycle of the injector appended by the provider function named owner.
+	of    *Lifecycle
+	owner string
+}
+
+// ownedBy returns the view of the lifecycle naming the appended hooks after the provider function.
+func (l *Lifecycle) ownedBy(owner string) *Lifecycle {
+	return &Lifecycle{of: l, owner: owner, emit: l.emit}
+}
+
+// runGroup is the group of the started hooks and runners, canceled on the first runner failure.
+type runGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+	err    error
+}
+
+func newRunGroup(ctx context.Context) *runGroup {
+	g := &runGroup{}
+	g.ctx, g.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	return g
+}
+
+// fail records the first failure and cancels the group. It reports whether the failure was the first one.
+func (g *runGroup) fail(err error) bool {
+	first := false
+	g.once.Do(func() {
+		g.err, first = err, true
+		g.cancel()
+	})
+	return first
+}
+
+// failure returns the first failure of the group, if any.
+func (g *runGroup) failure() error {
+	select {
+	case <-g.ctx.Done():
+		return g.err
+	default:
+		return nil
+	}
+}
+
+// Append adds the hook to the lifecycle. The hooks are started in the order they are appended
+// and stopped in reverse order.
+func (l *Lifecycle) Append(h Hook) {
+	if l.of != nil {
+		if h.Name == "" {
+			h.Name = l.owner
+		}
+		l.of.Append(h)
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.hooks = append(l.hooks, h)
+}
+
+// Start calls the OnStart functions of the lifecycle hooks appended by the already executed provider functions.
+// The hooks and the Runners are started as a group, which fails fast: if any hook fails, or any Runner fails
+// while the hooks are being started, the context of the hook being started and of all the Runners is canceled,
+// the hooks started so far are stopped in reverse order and the error naming the failed hook is returned along
+// with the stop errors. Calling Start again starts only the hooks appended since the previous call.
+func (i *Injector) Start(ctx context.Context) error {
+	if !i.resolved {
+		return ErrNotResolved
+	}
+	if i.cleaned {
+		return ErrAlreadyCleaned
+	}
+	l := i.lifecycle
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	defer i.recordStart(time.Now())
+	if l.group == nil {
+		l.group = newRunGroup(ctx)
+	}
+	startCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(l.group.ctx, cancel)()
+
+	for ; l.started < len(l.hooks); l.started++ {
+		h := l.hooks[l.started]
+		if err := l.group.failure(); err != nil {
+			return l.abort(ctx, err)
+		}
+		if h.OnStart == nil {
+			continue
+		}
+		if err := h.OnStart(startCtx); err != nil {
+			if gerr := l.group.failure(); gerr != nil {
+				return l.abort(ctx, gerr)
+			}
+			err = fmt.Errorf("starting lifecycle hook: %s failed: %w", h.name(l.started), err)
+			l.group.fail(err)
+			return l.abort(ctx, err)
+		}
+	}
+	if err := l.group.failure(); err != nil {
+		return l.abort(ctx, err)
+	}
+	return nil
+}
+
+// Wait blocks until any of the started Runners fails, returning its error, or until the context is done.
+func (i *Injector) Wait(ctx context.Context) error {
+	l := i.lifecycle
+	l.lock.Lock()
+	g := l.group
+	l.lock.Unlock()
+	if g == nil {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	select {
+	case <-g.ctx.Done():
+		if err := g.failure(); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// abort stops the started hooks after the start failure and returns the failure along with the stop errors.
+func (l *Lifecycle) abort(ctx context.Context, err error) error {
+	if serr := l.stop(ctx); serr != nil {
+		return multiError{err, serr}
+	}
+	return err
+}
+
+// Stop calls the OnStop functions of the started lifecycle hooks in reverse order. All of the hooks are stopped,
+// even if any of them fails, and the errors are returned together.
+func (i *Injector) Stop(ctx context.Context) error {
+	l := i.lifecycle
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.stop(ctx)
+}
+
+// stop stops the started hooks in reverse order.
+func (l *Lifecycle) stop(ctx context.Context) error {
+	var errs multiError
+	for ; l.started > 0; l.started-- {
+		h := l.hooks[l.started-1]
+		if h.OnStop == nil {
+			continue
+		}
+		start := time.Now()
+		err := h.OnStop(ctx)
+		if l.emit != nil {
+			l.emit(Event{Kind: EventHookStopped, Provider: h.name(l.started - 1), Duration: time.Since(start), Err: err})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stopping lifecycle hook: %s failed: %w", h.name(l.started-1), err))
+		}
+	}
+	if l.group != nil {
+		l.group.cancel()
+		l.group = nil
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Runner is implemented by the long running components, run in the background between Start and Stop.
+// The context passed to Run is canceled when the injector is stopped.
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+// RunnerFunc is the function implementing Runner.
+type RunnerFunc func(ctx context.Context) error
+
+// Run implements Runner.
+func (f RunnerFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+// AppendRunner adds the hook running the Runner in the background when the injector is started.
+// The first Runner failing with an error other than context.Canceled cancels the contexts of all the Runners
+// and its error is returned by Wait, or by Start if it is still starting the hooks.
+// When the injector is stopped the Runner context is canceled and the hook waits until the Run returns
+// or the stop context is done. The error returned by Run, other than context.Canceled, is returned by Stop,
+// unless it was already returned by Start or Wait.
+func (l *Lifecycle) AppendRunner(r Runner) {
+	l.appendRunner(fmt.Sprintf("%T", r), r)
+}
+
+func (l *Lifecycle) appendRunner(name string, r Runner) {
+	if l.of != nil {
+		l.of.appendRunner(name, r)
+		return
+	}
+	var (
+		cancel   context.CancelFunc
+		done     chan struct{}
+		err      error
+		reported bool
+	)
+	l.Append(Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			g := l.group
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(g.ctx)
+			done, reported = make(chan struct{}), false
+			go func() {
+				defer close(done)
+				err = r.Run(runCtx)
+				if err != nil && !errors.Is(err, context.Canceled) {
+					err = fmt.Errorf("runner: %s failed: %w", name, err)
+					reported = g.fail(err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				if reported || errors.Is(err, context.Canceled) {
+					return nil
+				}
+				return err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
@@ -0,0 +1,224 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type lifecycleType struct {
+	name  string
+	calls *[]string
+}
+
+func TestLifecycle(t *testing.T) {
+	newProvider := func(name string, startErr error) func(lc *Lifecycle, calls *[]string) *lifecycleType {
+		return func(lc *Lifecycle, calls *[]string) *lifecycleType {
+			lc.Append(Hook{
+				OnStart: func(ctx context.Context) error {
+					*calls = append(*calls, "start "+name)
+					return startErr
+				},
+				OnStop: func(ctx context.Context) error {
+					*calls = append(*calls, "stop "+name)
+					return nil
+				},
+			})
+			return &lifecycleType{name: name, calls: calls}
+		}
+	}
+	type second struct{ *lifecycleType }
+
+	t.Run("StartStop", func(t *testing.T) {
+		calls := &[]string{}
+		i := New()
+		i.Provide(Value(calls), Func(newProvider("first", nil)), Func(func(lc *Lifecycle, calls *[]string, _ *lifecycleType) *second {
+			return &second{newProvider("second", nil)(lc, calls)}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var s *second
+		if err := i.InjectAs(&s); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Stop(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		expected := []string{"start first", "start second", "stop second", "stop first"}
+		if !reflect.DeepEqual(*calls, expected) {
+			t.Errorf("Expected %v, got %v", expected, *calls)
+		}
+	})
+
+	t.Run("StartFailure", func(t *testing.T) {
+		calls := &[]string{}
+		startErr := errors.New("start failed")
+		i := New()
+		i.Provide(Value(calls), Func(newProvider("first", nil)), Func(func(lc *Lifecycle, calls *[]string, _ *lifecycleType) *second {
+			return &second{newProvider("second", startErr)(lc, calls)}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var s *second
+		if err := i.InjectAs(&s); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); !errors.Is(err, startErr) {
+			t.Errorf("Expected %v, got %v", startErr, err)
+		}
+		expected := []string{"start first", "start second", "stop first"}
+		if !reflect.DeepEqual(*calls, expected) {
+			t.Errorf("Expected %v, got %v", expected, *calls)
+		}
+	})
+
+	t.Run("Runner", func(t *testing.T) {
+		i := New()
+		running := make(chan struct{})
+		i.Provide(Func(func(lc *Lifecycle) *lifecycleType {
+			lc.AppendRunner(RunnerFunc(func(ctx context.Context) error {
+				close(running)
+				<-ctx.Done()
+				return ctx.Err()
+			}))
+			return &lifecycleType{}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var lt *lifecycleType
+		if err := i.InjectAs(&lt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		<-running
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+
+	t.Run("RunnerFailure", func(t *testing.T) {
+		runErr := errors.New("consumer failed")
+		canceled := make(chan struct{})
+		i := New()
+		i.Provide(Func(func(lc *Lifecycle) *lifecycleType {
+			lc.AppendRunner(RunnerFunc(func(ctx context.Context) error {
+				return runErr
+			}))
+			lc.AppendRunner(RunnerFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				close(canceled)
+				return ctx.Err()
+			}))
+			return &lifecycleType{}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var lt *lifecycleType
+		if err := i.InjectAs(&lt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		err := i.Start(context.Background())
+		if err == nil {
+			err = i.Wait(context.Background())
+			if serr := i.Stop(context.Background()); serr != nil {
+				t.Error("Expected no error, got", serr)
+			}
+		}
+		if !errors.Is(err, runErr) || !strings.Contains(err.Error(), "runner: wireless.RunnerFunc failed") {
+			t.Errorf("Expected %v, got %v", runErr, err)
+		}
+		<-canceled
+	})
+
+	t.Run("NamedHookFailure", func(t *testing.T) {
+		startErr := errors.New("listen failed")
+		i := New()
+		i.Provide(Func(func(lc *Lifecycle) *lifecycleType {
+			lc.Append(Hook{Name: "http server", OnStart: func(ctx context.Context) error { return startErr }})
+			return &lifecycleType{}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var lt *lifecycleType
+		if err := i.InjectAs(&lt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		err := i.Start(context.Background())
+		if !errors.Is(err, startErr) || !strings.Contains(err.Error(), "lifecycle hook: http server failed") {
+			t.Errorf("Expected %v, got %v", startErr, err)
+		}
+	})
+
+	t.Run("UnnamedHookFailure", func(t *testing.T) {
+		startErr := errors.New("listen failed")
+		i := New()
+		i.Provide(Func(func(lc *Lifecycle) *lifecycleType {
+			lc.Append(Hook{OnStart: func(ctx context.Context) error { return startErr }})
+			return &lifecycleType{}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var lt *lifecycleType
+		if err := i.InjectAs(&lt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		err := i.Start(context.Background())
+		if !errors.Is(err, startErr) || !strings.Contains(err.Error(), "lifecycle hook: github.com/routercore/wireless.TestLifecycle.func") {
+			t.Errorf("Expected the hook named after the provider, got %v", err)
+		}
+	})
+
+	t.Run("HookStoppedEvent", func(t *testing.T) {
+		stopErr := errors.New("shutdown failed")
+		var events []Event
+		i := New(WithEventHandler(func(e Event) { events = append(events, e) }))
+		i.Provide(Func(func(lc *Lifecycle) *lifecycleType {
+			lc.Append(Hook{Name: "http server", OnStop: func(ctx context.Context) error { return stopErr }})
+			return &lifecycleType{}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var lt *lifecycleType
+		if err := i.InjectAs(&lt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Stop(context.Background()); !errors.Is(err, stopErr) {
+			t.Errorf("Expected %v, got %v", stopErr, err)
+		}
+		if len(events) != 1 || events[0].Kind != EventHookStopped || events[0].Provider != "http server" || events[0].Err != stopErr {
+			t.Errorf("Expected %v event, got %v", EventHookStopped, events)
+		}
+	})
+
+	t.Run("CheckHealth", func(t *testing.T) {
+		checkErr := errors.New("unhealthy")
+		i := New()
+		i.Provide(
+			Group(HealthGroup, Value(HealthCheck{Name: "ok", Check: func(ctx context.Context) error { return nil }})),
+			Group(HealthGroup, Value(HealthCheck{Name: "failing", Check: func(ctx context.Context) error { return checkErr }})),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.CheckHealth(context.Background()); !errors.Is(err, checkErr) {
+			t.Errorf("Expected %v, got %v", checkErr, err)
+		}
+	})
+}
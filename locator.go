@@ -0,0 +1,53 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var injectorType = reflect.TypeOf(new(Injector))
+
+// DisallowInjectorDependencies makes the injector refuse the provider functions and decorators depending directly
+// on the *Injector, as the service locator hides their real dependencies from the graph. The providers of
+// the allowed types, defined with the `new` statement, are still accepted. The providers needing the late lookup
+// might depend on the Resolver instead.
+// Example:
+//
+//	wireless.New(wireless.DisallowInjectorDependencies(new(*JobRunner)))
+func DisallowInjectorDependencies(allow ...interface{}) Option {
+	return func(i *Injector) {
+		i.injectorAllowlist = map[reflect.Type]bool{}
+		for _, a := range allow {
+			if t := reflect.TypeOf(a); t != nil && t.Kind() == reflect.Ptr {
+				i.injectorAllowlist[t.Elem()] = true
+			}
+		}
+	}
+}
+
+// checkInjectorDependencies verifies that the providers do not depend on the *Injector outside the allowlist.
+func (i *Injector) checkInjectorDependencies() {
+	if i.injectorAllowlist == nil {
+		return
+	}
+	check := func(p *providerFunc) {
+		if i.injectorAllowlist[p.out] {
+			return
+		}
+		for _, in := range p.inTypes {
+			if in == injectorType {
+				i.errors = append(i.errors, fmt.Errorf("provider: %s of type: %s depends on the *wireless.Injector", p.name(), p.out))
+			}
+		}
+		for _, d := range p.decorators {
+			for _, in := range d.inTypes[1:] {
+				if in == injectorType {
+					i.errors = append(i.errors, fmt.Errorf("decorator: %s of type: %s depends on the *wireless.Injector", d.name(), p.out))
+				}
+			}
+		}
+	}
+	for _, p := range i.allProviders() {
+		check(p)
+	}
+}
@@ -0,0 +1,28 @@
+package wireless
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisallowInjectorDependencies(t *testing.T) {
+	newProviders := func() []Provider {
+		return []Provider{
+			Func(func(i *Injector) *initType { return &initType{} }),
+			Func(func(r Resolver) *resolverUser { return &resolverUser{r: r} }),
+		}
+	}
+
+	i := New(DisallowInjectorDependencies())
+	i.Provide(newProviders()...)
+	err := i.Resolve()
+	if err == nil || !strings.Contains(err.Error(), "depends on the *wireless.Injector") {
+		t.Errorf("Expected injector dependency error, got %v", err)
+	}
+
+	i = New(DisallowInjectorDependencies(new(*initType)))
+	i.Provide(newProviders()...)
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+}
@@ -0,0 +1,76 @@
+package wireless
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogField is the field of the event logged by the logging adapters, e.g. SlogEventHandler.
+type LogField struct {
+	Key string
+	// Value is either the string, int, time.Duration or error.
+	Value interface{}
+}
+
+// LogFields returns the fields of the event logged by the logging adapters, so that all of them log the events
+// with the same keys: 'event', 'scope', 'type', 'provider', 'shadowed', 'attempt', 'age', 'duration' and 'error'.
+// The empty fields are omitted, except for the scope, which is 'root' for the root injector.
+func (e Event) LogFields() []LogField {
+	scope := e.Scope
+	if scope == "" {
+		scope = ScopeRoot
+	}
+	fields := []LogField{{Key: "event", Value: string(e.Kind)}, {Key: "scope", Value: scope}}
+	if e.Type != nil {
+		fields = append(fields, LogField{Key: "type", Value: e.Type.String()})
+	}
+	if e.Provider != "" {
+		fields = append(fields, LogField{Key: "provider", Value: e.Provider})
+	}
+	if e.Shadowed != "" {
+		fields = append(fields, LogField{Key: "shadowed", Value: e.Shadowed})
+	}
+	if e.Attempt != 0 {
+		fields = append(fields, LogField{Key: "attempt", Value: e.Attempt})
+	}
+	if e.Age != 0 {
+		fields = append(fields, LogField{Key: "age", Value: e.Age})
+	}
+	if e.Duration != 0 {
+		fields = append(fields, LogField{Key: "duration", Value: e.Duration})
+	}
+	if e.Err != nil {
+		fields = append(fields, LogField{Key: "error", Value: e.Err})
+	}
+	return fields
+}
+
+// Failed reports whether the event reports the failure, which the logging adapters log as the warning.
+func (e Event) Failed() bool {
+	switch e.Kind {
+	case EventCircuitOpened, EventPanic, EventCleanupFailed, EventScopeLeaked, EventStaleInjection, EventRefreshFailed,
+		EventJobFailed, EventRequestFailed:
+		return true
+	}
+	return e.Err != nil
+}
+
+// SlogEventHandler creates the handler of the injector events logging them with the logger, with the LogFields
+// as the attributes. The failures are logged as warnings, while the other events are logged as info.
+// Example:
+//
+//	i := wireless.New(wireless.WithEventHandler(wireless.SlogEventHandler(slog.Default())))
+func SlogEventHandler(l *slog.Logger) func(Event) {
+	return func(e Event) {
+		level := slog.LevelInfo
+		if e.Failed() {
+			level = slog.LevelWarn
+		}
+		fields := e.LogFields()
+		attrs := make([]slog.Attr, len(fields))
+		for j, f := range fields {
+			attrs[j] = slog.Any(f.Key, f.Value)
+		}
+		l.LogAttrs(context.Background(), level, e.String(), attrs...)
+	}
+}
@@ -0,0 +1,43 @@
+package wireless
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSlogEventHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := SlogEventHandler(slog.New(slog.NewJSONHandler(&buf, nil)))
+	h(Event{Kind: EventRetry, Scope: ScopeRequest, Type: reflect.TypeOf(&testType{}), Provider: "newTestType",
+		Attempt: 2, Err: errors.New("connection refused")})
+	h(Event{Kind: EventHookStopped, Provider: "http server", Duration: time.Second})
+
+	dec := json.NewDecoder(&buf)
+	var retry, stopped map[string]interface{}
+	if err := dec.Decode(&retry); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := dec.Decode(&stopped); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := map[string]interface{}{
+		"level": "WARN", "event": "retry", "scope": "request", "type": "*wireless.testType", "provider": "newTestType",
+		"attempt": float64(2), "error": "connection refused",
+	}
+	for k, v := range expected {
+		if retry[k] != v {
+			t.Errorf("Expected %v: %v, got %v", k, v, retry[k])
+		}
+	}
+	if stopped["level"] != "INFO" || stopped["scope"] != ScopeRoot || stopped["duration"] != float64(time.Second) {
+		t.Errorf("Expected the info with the root scope and duration, got %v", stopped)
+	}
+	if _, ok := stopped["error"]; ok {
+		t.Errorf("Expected no error field, got %v", stopped)
+	}
+}
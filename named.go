@@ -0,0 +1,162 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Named registers the value or provider function under given name, so that multiple providers of the same type
+// might coexist. Named providers are injected with InjectNamed, into a struct field tagged with
+// 'wireless:"name=<name>"' or all at once into a 'map[string]T' field tagged with 'wireless:"named"'.
+// Example:
+//
+//	wireless.NewSet(
+//		wireless.Named("primary", wireless.Func(NewPrimaryDB)),
+//		wireless.Named("replica", wireless.Func(NewReplicaDB)),
+//	)
+func Named(name string, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.name = name })
+	return p
+}
+
+// InjectNamed injects the provider registered with given name into the input pointer to type.
+func (i *Injector) InjectNamed(name string, as interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), as, "", name, &err)
+	}
+	return i.injectNamedContext(i.context(), name, as)
+}
+
+// InjectNamedContext injects the named provider, same as InjectNamed, with the input context passed to its
+// construction as by InjectAsContext.
+func (i *Injector) InjectNamedContext(ctx context.Context, name string, as interface{}) (err error) {
+	if i.trail != nil {
+		defer i.audit(time.Now(), as, "", name, &err)
+	}
+	return i.injectNamedContext(ctx, name, as)
+}
+
+func (i *Injector) injectNamedContext(ctx context.Context, name string, as interface{}) error {
+	if err := i.admit(); err != nil {
+		return err
+	}
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if !i.resolved {
+		return ErrNotResolved
+	}
+	if i.cleaned {
+		return ErrAlreadyCleaned
+	}
+	if len(i.errors) > 0 {
+		return i.errors
+	}
+	if as == nil {
+		return errors.New("input injection type is nil")
+	}
+	rVal := reflect.ValueOf(as)
+	if rVal.Kind() != reflect.Ptr {
+		return errors.New("input injection type is not a pointer")
+	}
+	return i.injectNamed(ctx, name, rVal)
+}
+
+// namedKey is the key of the named provider.
+type namedKey struct {
+	t    reflect.Type
+	name string
+}
+
+func (i *Injector) injectNamed(ctx context.Context, name string, rVal reflect.Value) error {
+	elem := rVal.Type().Elem()
+	pf, ok := i.named[namedKey{t: elem, name: name}]
+	if !ok {
+		// The interface might be satisfied by the named provider of other type.
+		var candidates []*providerFunc
+		if elem.Kind() == reflect.Interface {
+			for _, k := range i.sortedNamedKeys() {
+				if k.name == name && k.t.AssignableTo(elem) {
+					candidates = append(candidates, i.named[k])
+				}
+			}
+		}
+		if len(candidates) > 1 {
+			err := &AmbiguousProviderError{Type: elem, Name: name}
+			for _, c := range candidates {
+				err.Candidates = append(err.Candidates, Candidate{Type: c.out, Site: memberSite(c)})
+			}
+			sort.Slice(err.Candidates, func(j, k int) bool {
+				return err.Candidates[j].Type.String() < err.Candidates[k].Type.String()
+			})
+			return err
+		}
+		if len(candidates) == 0 {
+			return fmt.Errorf("named: %q %w", name, notFoundError{t: elem})
+		}
+		pf = candidates[0]
+	}
+	v, err := i.instance(ctx, pf)
+	if err != nil {
+		return err
+	}
+	rVal.Elem().Set(v)
+	return nil
+}
+
+func (i *Injector) injectNamedMap(ctx context.Context, rVal reflect.Value) error {
+	mt := rVal.Type().Elem()
+	if mt.Kind() != reflect.Map || mt.Key().Kind() != reflect.String {
+		return fmt.Errorf("named providers could not be injected into non map[string]T type: %s", mt)
+	}
+	m := reflect.MakeMap(mt)
+	for _, k := range i.sortedNamedKeys() {
+		pf := i.named[k]
+		if !k.t.AssignableTo(mt.Elem()) {
+			continue
+		}
+		v, err := i.instance(ctx, pf)
+		if err != nil {
+			return err
+		}
+		m.SetMapIndex(reflect.ValueOf(k.name).Convert(mt.Key()), v)
+	}
+	rVal.Elem().Set(m)
+	return nil
+}
+
+// sortedNamedKeys returns the keys of the named providers in the order of their registration.
+func (i *Injector) sortedNamedKeys() []namedKey {
+	keys := make([]namedKey, 0, len(i.named))
+	for k := range i.named {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(j, k int) bool {
+		return i.named[keys[j]].id < i.named[keys[k]].id
+	})
+	return keys
+}
+
+func (i *Injector) resolveNamed() {
+	for _, p := range i.namedProviders {
+		pf, o, err := i.newMember(p)
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		key := namedKey{t: pf.out, name: o.name}
+		if _, ok := i.named[key]; ok {
+			if o.ifNotExists {
+				i.recordDecision("skipped", pf.out, fmt.Sprintf("%s named: %q", pf.name(), o.name))
+				continue
+			}
+			i.errors = append(i.errors, fmt.Errorf("provider already registered for type: %s with name: %q", pf.out, o.name))
+			continue
+		}
+		i.named[key] = pf
+	}
+}
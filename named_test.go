@@ -0,0 +1,111 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type namedInterfacer struct{}
+
+func (namedInterfacer) isInterfacer() {}
+
+func TestNamed(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(&testType{v: "default"}),
+		Named("primary", Value(&testType{v: "primary"})),
+		Named("replica", Func(func(p *testType) *testType { return &testType{v: p.v + "-replica"} })),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var s struct {
+		Default *testType
+		Primary *testType            `wireless:"name=primary"`
+		All     map[string]*testType `wireless:"named"`
+		Missing *testType            `wireless:"name=missing,optional"`
+	}
+	err = i.Inject(&s)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if s.Default.v != "default" || s.Primary.v != "primary" || s.Missing != nil {
+		t.Errorf("Expected named fields injected, got %+v", s)
+	}
+	if len(s.All) != 2 || s.All["primary"] != s.Primary || s.All["replica"].v != "default-replica" {
+		t.Errorf("Expected all named providers, got %v", s.All)
+	}
+
+	var replica *testType
+	err = i.InjectNamed("replica", &replica)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if replica != s.All["replica"] {
+		t.Errorf("Expected %v, got %v", s.All["replica"], replica)
+	}
+
+	var missing *testType
+	if err = i.InjectNamed("missing", &missing); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+	}
+}
+
+func TestNamedCandidatesOrder(t *testing.T) {
+	var order string
+	for n := 0; n < 20; n++ {
+		i := New()
+		i.Provide(
+			Named("handler", Value(testType{v: "handler"})),
+			Named("handler", Value(namedInterfacer{})),
+			Named("handler", Value(&namedInterfacer{})),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var it interfaceType
+		var aerr *AmbiguousProviderError
+		if err := i.InjectNamed("handler", &it); !errors.As(err, &aerr) {
+			t.Fatalf("Expected %v, got %v", ErrAmbiguousProvider, err)
+		}
+		candidates := fmt.Sprint(aerr.Candidates)
+		if order == "" {
+			order = candidates
+		}
+		if candidates != order {
+			t.Fatalf("Expected %v, got %v", order, candidates)
+		}
+	}
+}
+
+func TestNamedPooled(t *testing.T) {
+	var constructed int
+	i := New()
+	i.Provide(Named("buffer", Pooled(Func(func() *pooledBuffer {
+		constructed++
+		return &pooledBuffer{}
+	}))))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var buffer *pooledBuffer
+	if err := i.InjectNamed("buffer", &buffer); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var s struct {
+		All map[string]*pooledBuffer `wireless:"named"`
+	}
+	if err := i.Inject(&s); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if buffer == nil || s.All["buffer"] == nil || s.All["buffer"] == buffer {
+		t.Errorf("Expected separate pooled instances, got %v and %v", buffer, s.All)
+	}
+	if constructed != 2 {
+		t.Errorf("Expected %v, got %v", 2, constructed)
+	}
+}
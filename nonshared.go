@@ -0,0 +1,105 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NonShared makes the binding construct its own instance of the bound type, instead of sharing the single instance
+// with the other bindings and the consumers of the bound type. The bound type needs to be provided by the provider
+// function, which is called separately for each non-shared binding.
+// Example:
+//
+//	wireless.NewSet(
+//		wireless.Func(NewConn),
+//		wireless.NonShared(wireless.Bind(new(ReadConn), new(*Conn))),
+//		wireless.NonShared(wireless.Bind(new(WriteConn), new(*Conn))),
+//	)
+func NonShared(p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.nonShared = true })
+	return p
+}
+
+// nonSharedBinding is the binding constructing its own instance of the bound type.
+type nonSharedBinding struct {
+	it, to  reflect.Type
+	options providerOptions
+	clone   *providerFunc
+}
+
+// resolveNonSharedBindings registers the copies of the provider functions of the bound types for the interface
+// types of the non-shared bindings.
+func (i *Injector) resolveNonSharedBindings() {
+	for j, b := range i.nonShared {
+		bound, ok := i.providersMap[b.to]
+		if !ok || bound.source == nil {
+			i.errors = append(i.errors, fmt.Errorf("non-shared binding between: %s and %s requires the provider function of type: %s", b.it, b.to, b.to))
+			continue
+		}
+		fp := &funcProvider{v: bound.source.v, as: b.it, providerOptions: bound.source.providerOptions}
+		pf, err := fp.providerFunc()
+		if err != nil {
+			i.errors = append(i.errors, fmt.Errorf("non-shared binding between: %s and %s %w", b.it, b.to, err))
+			continue
+		}
+		register, err := i.conflict(b.it, b.options, ProviderSite{Provider: "non-shared binding to " + b.to.String()})
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if !register {
+			continue
+		}
+		pf.id = i.nextID()
+		i.providersMap[b.it] = pf
+		i.nonShared[j].clone = pf
+	}
+}
+
+// decorateNonSharedBindings applies the decorators of the bound types to the instances of the non-shared bindings,
+// before the decorators of the interface types.
+func (i *Injector) decorateNonSharedBindings() {
+	for _, b := range i.nonShared {
+		if b.clone == nil {
+			continue
+		}
+		var decorators []*providerFunc
+		for _, d := range i.providersMap[b.to].decorators {
+			decorators = append(decorators, nonSharedDecorator(b.it, d))
+		}
+		b.clone.decorators = append(decorators, b.clone.decorators...)
+	}
+}
+
+// nonSharedDecorator adapts the decorator of the bound type to the interface type of the non-shared binding.
+func nonSharedDecorator(it reflect.Type, d *providerFunc) *providerFunc {
+	ft := d.value.Type()
+	ins, outs := make([]reflect.Type, ft.NumIn()), make([]reflect.Type, ft.NumOut())
+	for j := range ins {
+		ins[j] = ft.In(j)
+	}
+	for j := range outs {
+		outs[j] = ft.Out(j)
+	}
+	ins[0], outs[0] = it, it
+	to, fn := d.out, d.value
+	value := reflect.MakeFunc(reflect.FuncOf(ins, outs, ft.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		args[0] = args[0].Elem().Convert(to)
+		var res []reflect.Value
+		if ft.IsVariadic() {
+			res = fn.CallSlice(args)
+		} else {
+			res = fn.Call(args)
+		}
+		res[0] = res[0].Convert(it)
+		return res
+	})
+	return &providerFunc{
+		value:      value,
+		inTypes:    append([]reflect.Type{it}, d.inTypes[1:]...),
+		out:        it,
+		errOut:     d.errOut,
+		cleanupOut: d.cleanupOut,
+		weight:     d.weight,
+	}
+}
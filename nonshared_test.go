@@ -0,0 +1,85 @@
+package wireless
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNonShared(t *testing.T) {
+	t.Run("Separate", func(t *testing.T) {
+		var calls int
+		i := New()
+		i.Provide(
+			Func(func() *bytes.Buffer { calls++; return new(bytes.Buffer) }),
+			NonShared(Bind(new(io.Reader), new(*bytes.Buffer))),
+			NonShared(Bind(new(io.Writer), new(*bytes.Buffer))),
+			Bind(new(io.ByteReader), new(*bytes.Buffer)),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var (
+			r  io.Reader
+			w  io.Writer
+			br io.ByteReader
+			b  *bytes.Buffer
+		)
+		for _, as := range []interface{}{&r, &w, &br, &b} {
+			if err := i.InjectAs(as); err != nil {
+				t.Error("Expected no error, got", err)
+			}
+		}
+		if r == io.Reader(b) || w == io.Writer(b) || r == io.Reader(w.(*bytes.Buffer)) {
+			t.Error("Expected separate instances of the non-shared bindings")
+		}
+		if br != io.ByteReader(b) {
+			t.Error("Expected the shared binding to inject the same instance")
+		}
+		if calls != 3 {
+			t.Errorf("Expected %v, got %v", 3, calls)
+		}
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Value("prefix"),
+			Func(func() *bytes.Buffer { return new(bytes.Buffer) }),
+			Decorate(func(b *bytes.Buffer, prefix string) *bytes.Buffer {
+				b.WriteString(prefix + "-decorated")
+				return b
+			}),
+			NonShared(Bind(new(io.Reader), new(*bytes.Buffer))),
+			Decorate(func(r io.Reader) io.Reader {
+				return io.MultiReader(r, strings.NewReader("-reader"))
+			}),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var r io.Reader
+		if err := i.InjectAs(&r); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if expected := "prefix-decorated-reader"; string(data) != expected {
+			t.Errorf("Expected %v, got %v", expected, string(data))
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Value(new(bytes.Buffer)),
+			NonShared(Bind(new(io.Reader), new(*bytes.Buffer))),
+		)
+		if err := i.Resolve(); err == nil {
+			t.Error("Expected error for the non-shared binding of the value, got nil")
+		}
+	})
+}
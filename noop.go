@@ -0,0 +1,104 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// noOps are the no-op implementations of the interfaces registered with RegisterNoOp.
+var noOps sync.Map
+
+// NoOp provides a no-op implementation of the type defined with the `new` statement, if no other provider for that
+// type exists. The no-op function returns zero values of its results, so do the methods of the no-op interface
+// implementation.
+//
+// Reflection is not able to create new types implementing interface methods, thus the no-op implementations of
+// interfaces are generated with wirelessgen.NoOps, which registers them with RegisterNoOp in the init function
+// of the generated file.
+// Example:
+//
+//	wireless.NoOp(new(TraceFunc))
+//	wireless.NoOp(new(Tracer))
+func NoOp(target interface{}) Provider {
+	return &noOpProvider{target: target}
+}
+
+// RegisterNoOp registers the no-op implementation of the interface defined with the `new` statement, used by NoOp.
+// It is called by the code generated with wirelessgen.NoOps.
+// Example:
+//
+//	func init() {
+//		wireless.RegisterNoOp(new(Tracer), noOpTracer{})
+//	}
+func RegisterNoOp(iface, impl interface{}) {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("no-op interface is not defined with `new` statement: %T", iface))
+	}
+	v := reflect.ValueOf(impl)
+	if !v.IsValid() || !v.Type().Implements(t.Elem()) {
+		panic(fmt.Sprintf("no-op implementation: %T does not implement the interface: %s", impl, t.Elem()))
+	}
+	noOps.Store(t.Elem(), v.Convert(t.Elem()))
+}
+
+type noOpProvider struct {
+	target interface{}
+	providerOptions
+}
+
+func (n *noOpProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&n.providerOptions)
+	}
+}
+
+// value creates the no-op value of the target type.
+func (n *noOpProvider) value() (reflect.Type, reflect.Value, error) {
+	t := reflect.TypeOf(n.target)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, reflect.Value{}, fmt.Errorf("no-op type is not defined with `new` statement: %T", n.target)
+	}
+	t = t.Elem()
+	switch {
+	case t.Kind() == reflect.Func:
+		return t, reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+			outs := make([]reflect.Value, t.NumOut())
+			for j := range outs {
+				outs[j] = reflect.Zero(t.Out(j))
+			}
+			return outs
+		}), nil
+	case t.Kind() == reflect.Interface && t.NumMethod() == 0:
+		return t, reflect.Zero(t), nil
+	case t.Kind() == reflect.Interface:
+		v, ok := noOps.Load(t)
+		if !ok {
+			return nil, reflect.Value{}, fmt.Errorf("no-op implementation of the interface: %s is not generated, see wirelessgen.NoOps", t)
+		}
+		return t, v.(reflect.Value), nil
+	default:
+		return nil, reflect.Value{}, fmt.Errorf("no-op implementation could not be created for the type: %s, only function types and interfaces are supported", t)
+	}
+}
+
+func (i *Injector) resolveNoOps() {
+	for _, n := range i.noOpProviders {
+		t, v, err := n.value()
+		if err != nil {
+			i.errors = append(i.errors, err)
+			continue
+		}
+		if _, ok := i.values[t]; ok {
+			continue
+		}
+		if _, ok := i.providersMap[t]; ok {
+			continue
+		}
+		if _, ok := i.bindings[t]; ok {
+			continue
+		}
+		i.values[t] = v
+	}
+}
@@ -0,0 +1,92 @@
+package wireless
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type traceFunc func(name string) (func(), error)
+
+type tracer interface {
+	Start(name string) (func(), error)
+}
+
+type noOpTracer struct{}
+
+func (noOpTracer) Start(string) (end func(), err error) { return }
+
+func TestNoOp(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		i := New()
+		i.Provide(NoOp(new(traceFunc)))
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var fn traceFunc
+		err = i.InjectAs(&fn)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		end, err := fn("span")
+		if end != nil || err != nil {
+			t.Errorf("Expected zero values, got %t, %v", end != nil, err)
+		}
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		var called bool
+		i := New()
+		i.Provide(
+			NoOp(new(traceFunc)),
+			Value(traceFunc(func(string) (func(), error) { called = true; return nil, nil })),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var fn traceFunc
+		err = i.InjectAs(&fn)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		_, _ = fn("span")
+		if !called {
+			t.Error("Expected provided function to be called")
+		}
+	})
+
+	t.Run("Interface", func(t *testing.T) {
+		RegisterNoOp(new(tracer), noOpTracer{})
+		t.Cleanup(func() { noOps.Delete(reflect.TypeOf(new(tracer)).Elem()) })
+
+		i := New()
+		i.Provide(NoOp(new(tracer)))
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tr tracer
+		err = i.InjectAs(&tr)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		end, err := tr.Start("span")
+		if end != nil || err != nil {
+			t.Errorf("Expected zero values, got %t, %v", end != nil, err)
+		}
+	})
+
+	t.Run("Interface not generated", func(t *testing.T) {
+		i := New()
+		i.Provide(NoOp(new(interfaceType)))
+		err := i.Resolve()
+		if err == nil || !strings.Contains(err.Error(), "wirelessgen.NoOps") {
+			t.Errorf("Expected error pointing to wirelessgen.NoOps, got %v", err)
+		}
+	})
+}
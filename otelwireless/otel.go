@@ -0,0 +1,88 @@
+// Package otelwireless records the OpenTelemetry metrics of the wireless injector lifecycle, so that the shutdown
+// health, e.g. the slow stop hooks, the failed cleanups and the restarting runners, could be tracked on dashboards.
+package otelwireless
+
+import (
+	"context"
+
+	"github.com/routercore/wireless"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ScopeName is the instrumentation scope name of the meter recording the metrics.
+const ScopeName = "github.com/routercore/wireless/otelwireless"
+
+// Metric names recorded by the event handler returned by EventHandler.
+const (
+	// StopDurationMetric is the histogram of the durations of the OnStop functions of the lifecycle hooks,
+	// with the 'hook' and 'error' attributes.
+	StopDurationMetric = "wireless.lifecycle.stop.duration"
+	// CleanupFailuresMetric is the counter of the failed teardown functions and panicked cleanup functions,
+	// with the 'provider', 'type' and 'reason' attributes.
+	CleanupFailuresMetric = "wireless.cleanup.failures"
+	// RestartsMetric is the counter of the restarts of the supervised runners, with the 'runner' attribute.
+	RestartsMetric = "wireless.supervisor.restarts"
+)
+
+// EventHandler creates the handler of the injector events recording the metrics with the meter of the provider.
+// All the metrics have the 'scope' attribute with the kind of the scope emitting the event. The events are passed
+// to the next handler, if any, so that the metrics might be recorded along with e.g. the logging of the events.
+// Example:
+//
+//	h, err := otelwireless.EventHandler(otel.GetMeterProvider(), nil)
+//	if err != nil {
+//		return err
+//	}
+//	i := wireless.New(wireless.WithEventHandler(h))
+func EventHandler(mp metric.MeterProvider, next func(wireless.Event)) (func(wireless.Event), error) {
+	meter := mp.Meter(ScopeName)
+	stopDuration, err := meter.Float64Histogram(StopDurationMetric,
+		metric.WithDescription("Duration of the OnStop functions of the lifecycle hooks."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	cleanupFailures, err := meter.Int64Counter(CleanupFailuresMetric,
+		metric.WithDescription("Number of the failed teardown and cleanup functions."), metric.WithUnit("{failure}"))
+	if err != nil {
+		return nil, err
+	}
+	restarts, err := meter.Int64Counter(RestartsMetric,
+		metric.WithDescription("Number of the restarts of the supervised runners."), metric.WithUnit("{restart}"))
+	if err != nil {
+		return nil, err
+	}
+	return func(e wireless.Event) {
+		ctx := context.Background()
+		scope := attribute.String("scope", scopeKind(e.Scope))
+		switch e.Kind {
+		case wireless.EventHookStopped:
+			stopDuration.Record(ctx, e.Duration.Seconds(), metric.WithAttributes(
+				scope, attribute.String("hook", e.Provider), attribute.Bool("error", e.Err != nil)))
+		case wireless.EventCleanupFailed, wireless.EventPanic:
+			cleanupFailures.Add(ctx, 1, metric.WithAttributes(
+				scope, attribute.String("provider", e.Provider), attribute.String("type", typeString(e)),
+				attribute.String("reason", string(e.Kind))))
+		case wireless.EventRestart:
+			restarts.Add(ctx, 1, metric.WithAttributes(scope, attribute.String("runner", e.Provider)))
+		}
+		if next != nil {
+			next(e)
+		}
+	}, nil
+}
+
+// scopeKind returns the kind of the scope emitting the event, with the root injector named explicitly.
+func scopeKind(kind string) string {
+	if kind == "" {
+		return wireless.ScopeRoot
+	}
+	return kind
+}
+
+func typeString(e wireless.Event) string {
+	if e.Type == nil {
+		return ""
+	}
+	return e.Type.String()
+}
@@ -0,0 +1,89 @@
+package otelwireless
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/routercore/wireless"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type server struct{}
+
+func TestEventHandler(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	var forwarded int
+	h, err := EventHandler(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), func(wireless.Event) { forwarded++ })
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	i := wireless.New(wireless.WithEventHandler(h))
+	i.Provide(wireless.Func(func(lc *wireless.Lifecycle) *server {
+		lc.Append(wireless.Hook{Name: "http server", OnStop: func(ctx context.Context) error { return nil }})
+		return &server{}
+	}))
+	if err = i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var s *server
+	if err = i.InjectAs(&s); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err = i.Start(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err = i.Stop(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	h(wireless.Event{Kind: wireless.EventCleanupFailed, Type: reflect.TypeOf(s), Provider: "NewServer", Err: errors.New("close failed")})
+	h(wireless.Event{Kind: wireless.EventRestart, Scope: wireless.ScopeJob, Provider: "worker", Attempt: 1})
+	h(wireless.Event{Kind: wireless.EventRestart, Scope: wireless.ScopeJob, Provider: "worker", Attempt: 2})
+	h(wireless.Event{Kind: wireless.EventRefreshed, Age: time.Second})
+
+	var rm metricdata.ResourceMetrics
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if forwarded != 5 {
+		t.Errorf("Expected %v, got %v", 5, forwarded)
+	}
+	if len(rm.ScopeMetrics) != 1 || rm.ScopeMetrics[0].Scope.Name != ScopeName {
+		t.Fatalf("Expected the %v scope, got %v", ScopeName, rm.ScopeMetrics)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	stops := metrics[StopDurationMetric].(metricdata.Histogram[float64]).DataPoints
+	if len(stops) != 1 || stops[0].Count != 1 {
+		t.Fatalf("Expected one stop, got %v", stops)
+	}
+	if hook, _ := stops[0].Attributes.Value("hook"); hook.AsString() != "http server" {
+		t.Errorf("Expected %v, got %v", "http server", hook.AsString())
+	}
+	if scope, _ := stops[0].Attributes.Value("scope"); scope.AsString() != wireless.ScopeRoot {
+		t.Errorf("Expected %v, got %v", wireless.ScopeRoot, scope.AsString())
+	}
+
+	failures := metrics[CleanupFailuresMetric].(metricdata.Sum[int64]).DataPoints
+	if len(failures) != 1 || failures[0].Value != 1 {
+		t.Fatalf("Expected one failure, got %v", failures)
+	}
+	if typ, _ := failures[0].Attributes.Value("type"); typ.AsString() != "*otelwireless.server" {
+		t.Errorf("Expected %v, got %v", "*otelwireless.server", typ.AsString())
+	}
+
+	restarts := metrics[RestartsMetric].(metricdata.Sum[int64]).DataPoints
+	if len(restarts) != 1 || restarts[0].Value != 2 {
+		t.Fatalf("Expected two restarts, got %v", restarts)
+	}
+	if scope, _ := restarts[0].Attributes.Value("scope"); scope.AsString() != wireless.ScopeJob {
+		t.Errorf("Expected %v, got %v", wireless.ScopeJob, scope.AsString())
+	}
+}
@@ -0,0 +1,86 @@
+package wireless
+
+import (
+	"reflect"
+)
+
+// Override makes the value, interface value, binding or provider function replace any other provider of the same
+// type, regardless of the registration order, instead of failing with the duplicate provider error. It allows
+// the applications to replace the defaults provided by the libraries, or the tests to replace the real
+// implementations. Each type might be overridden only once.
+// Example:
+//
+//	i.Provide(
+//		storage.Providers,
+//		wireless.Override(wireless.Value(&storage.Config{DSN: "sqlite://:memory:"})),
+//	)
+func Override(p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.override = true })
+	return p
+}
+
+// conflict decides on registering the provider of the type, which might be already provided by the value,
+// provider function or binding registered before. It reports whether the provider should be registered, replacing
+// the existing one, or the DuplicateProviderError if it conflicts with the existing one. The IfNotExists and
+// overridden providers are skipped, while the IfNotExists provider registered before is replaced by the provider
+// of any kind, as is any provider replaced by the overriding one.
+func (i *Injector) conflict(t reflect.Type, o providerOptions, site ProviderSite) (bool, error) {
+	if i.overridden == nil {
+		i.overridden = map[reflect.Type]bool{}
+	}
+	if i.sites == nil {
+		i.sites = map[reflect.Type]ProviderSite{}
+	}
+	if i.defaults == nil {
+		i.defaults = map[reflect.Type]bool{}
+	}
+	site.Source, site.Namespace = o.source, o.namespace
+	exists := i.provided(t)
+	switch {
+	case o.override && i.overridden[t]:
+		return false, &DuplicateProviderError{Type: t, Existing: i.sites[t], Duplicate: site}
+	case o.override:
+		i.overridden[t] = true
+		if exists {
+			i.recordDecision("override", t, site.Provider)
+			i.unprovide(t)
+		}
+	case !exists:
+	case i.overridden[t]:
+		i.recordDecision("overridden", t, site.Provider)
+		return false, nil
+	case o.ifNotExists:
+		i.recordDecision("skipped", t, site.Provider)
+		return false, nil
+	case i.defaults[t]:
+		i.recordDecision("skipped", t, i.sites[t].Provider)
+		i.unprovide(t)
+	default:
+		return false, &DuplicateProviderError{Type: t, Existing: i.sites[t], Duplicate: site}
+	}
+	delete(i.defaults, t)
+	if o.ifNotExists && !exists {
+		i.defaults[t] = true
+	}
+	i.sites[t] = site
+	return true, nil
+}
+
+// provided reports whether the type is provided by the value, provider function or binding of the injector itself.
+func (i *Injector) provided(t reflect.Type) bool {
+	if _, ok := i.values[t]; ok {
+		return true
+	}
+	if _, ok := i.providersMap[t]; ok {
+		return true
+	}
+	_, ok := i.bindings[t]
+	return ok
+}
+
+// unprovide removes the provider of the type replaced by other provider, which might be of other kind.
+func (i *Injector) unprovide(t reflect.Type) {
+	delete(i.values, t)
+	delete(i.providersMap, t)
+	delete(i.bindings, t)
+}
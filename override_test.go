@@ -0,0 +1,112 @@
+package wireless
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOverride(t *testing.T) {
+	t.Run("Value", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Override(Value(&testType{v: "app"})),
+			Value(&testType{v: "library"}),
+			IfNotExists(Value(&testType{v: "fallback"})),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil || tt.v != "app" {
+			t.Errorf("Expected %v, got %v, %v", "app", tt, err)
+		}
+		decisions := i.StartupReport().Decisions
+		if len(decisions) != 2 || decisions[0].Kind != "overridden" || decisions[1].Kind != "overridden" {
+			t.Errorf("Expected overridden decisions, got %v", decisions)
+		}
+	})
+
+	t.Run("InterfaceValue", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			InterfaceValue(new(io.Reader), strings.NewReader("library")),
+			IfNotExists(InterfaceValue(new(io.Reader), strings.NewReader("fallback"))),
+			Override(InterfaceValue(new(io.Reader), strings.NewReader("app"))),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var r io.Reader
+		if err := i.InjectAs(&r); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if b, _ := io.ReadAll(r); string(b) != "app" {
+			t.Errorf("Expected %v, got %v", "app", string(b))
+		}
+	})
+
+	t.Run("Func", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Func(func() *testType { return &testType{v: "library"} }),
+			Override(Func(func() *testType { return &testType{v: "test"} })),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil || tt.v != "test" {
+			t.Errorf("Expected %v, got %v, %v", "test", tt, err)
+		}
+	})
+
+	t.Run("Mixed kinds", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			IfNotExists(Value(&testType{v: "default"})),
+			Func(func() *testType { return &testType{v: "app"} }),
+			Value(&scopeRequest{ID: "library"}),
+			Override(Func(func() *scopeRequest { return &scopeRequest{ID: "app"} })),
+			Override(Func(func() io.Reader { return strings.NewReader("app") })),
+			InterfaceValue(new(io.Reader), strings.NewReader("library")),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil || tt.v != "app" {
+			t.Errorf("Expected %v, got %v, %v", "app", tt, err)
+		}
+		var sr *scopeRequest
+		if err := i.InjectAs(&sr); err != nil || sr.ID != "app" {
+			t.Errorf("Expected %v, got %v, %v", "app", sr, err)
+		}
+		var r io.Reader
+		if err := i.InjectAs(&r); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if data, _ := io.ReadAll(r); string(data) != "app" {
+			t.Errorf("Expected %v, got %v", "app", string(data))
+		}
+
+		i = New()
+		i.Provide(Value(&testType{}), Func(func() *testType { return &testType{} }))
+		var dpe *DuplicateProviderError
+		if err := i.Resolve(); !errors.As(err, &dpe) {
+			t.Errorf("Expected duplicate provider error, got %v", err)
+		}
+	})
+
+	t.Run("Twice", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			Override(Value(&testType{v: "first"})),
+			Override(Value(&testType{v: "second"})),
+		)
+		if err := i.Resolve(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
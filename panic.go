@@ -0,0 +1,94 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// PanicError is the error the panic of the provider function, decorator or cleanup function is recovered into.
+type PanicError struct {
+	// Provider is the name of the provider function the panic occurred in.
+	Provider string
+	// Value is the value the function panicked with.
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+// Error implements error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("provider: %s panicked: %v", e.Provider, e.Value)
+}
+
+// Unwrap returns the value the function panicked with, if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// WithPanicPropagation disables the recovery of the panics of the provider functions, decorators and cleanup
+// functions. By default, the panics are recovered into the PanicError, which is returned by the construction of
+// the value, or emitted with the EventPanic and returned by Close if the cleanup function panics.
+func WithPanicPropagation() Option {
+	return func(i *Injector) {
+		i.propagatePanics = true
+	}
+}
+
+// call calls the provider function, recovering its panic into the PanicError.
+func (i *Injector) call(p *providerFunc, ins []reflect.Value) (out reflect.Value, cleanup reflect.Value, err error) {
+	if !i.propagatePanics {
+		defer func() {
+			if r := recover(); r != nil {
+				out, cleanup, err = reflect.Value{}, reflect.Value{}, &PanicError{Provider: p.name(), Value: r, Stack: debug.Stack()}
+			}
+		}()
+	}
+	return p.call(ins)
+}
+
+// clean executes the cleanup functions of the provider in reverse order to which they were created.
+// Each cleanup function is executed at most once, as the cleanups are taken from the provider function before
+// they are executed.
+func (i *Injector) clean(p *providerFunc) {
+	p.cleanLock.Lock()
+	cleanups := p.cleanups
+	p.cleanups = nil
+	p.cleanLock.Unlock()
+	i.runCleanups(p, cleanups)
+}
+
+// addCleanup registers the cleanup function of the provider function instance.
+func (p *providerFunc) addCleanup(cleanup reflect.Value) {
+	p.cleanLock.Lock()
+	defer p.cleanLock.Unlock()
+	p.cleanups = append(p.cleanups, cleanup)
+}
+
+// runCleanups executes the cleanup functions in reverse order, recovering their panics into the EventPanic.
+func (i *Injector) runCleanups(p *providerFunc, cleanups []reflect.Value) {
+	for j := len(cleanups) - 1; j >= 0; j-- {
+		i.runCleanup(p, cleanups[j])
+	}
+}
+
+func (i *Injector) runCleanup(p *providerFunc, cleanup reflect.Value) {
+	if !i.propagatePanics {
+		defer func() {
+			if r := recover(); r != nil {
+				err := &PanicError{Provider: p.name(), Value: r, Stack: debug.Stack()}
+				i.addCleanErrors(err)
+				i.emit(Event{Kind: EventPanic, Type: p.out, Provider: p.name(), Err: err})
+			}
+		}()
+	}
+	cleanup.Call(nil)
+}
+
+// addCleanErrors records the errors of the cleanups returned by Close.
+func (i *Injector) addCleanErrors(errs ...error) {
+	i.cleanErrorsLock.Lock()
+	defer i.cleanErrorsLock.Unlock()
+	i.cleanErrors = append(i.cleanErrors, errs...)
+}
@@ -0,0 +1,108 @@
+package wireless
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type panicConsumer struct{}
+
+func panickingProvider() *testType {
+	panic("misconfigured")
+}
+
+func TestPanicRecovery(t *testing.T) {
+	t.Run("Provider", func(t *testing.T) {
+		i := New()
+		i.Provide(Func(panickingProvider))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt *testType
+		err := i.InjectAs(&tt)
+		var pe *PanicError
+		if !errors.As(err, &pe) {
+			t.Fatalf("Expected %T, got %v", pe, err)
+		}
+		if !strings.HasSuffix(pe.Provider, "panickingProvider") || pe.Value != "misconfigured" || len(pe.Stack) == 0 {
+			t.Errorf("Expected panic of panickingProvider, got %+v", pe)
+		}
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		var events []Event
+		var cleaned bool
+		i := New(WithEventHandler(func(e Event) { events = append(events, e) }))
+		i.Provide(
+			Func(func() (*testType, func()) {
+				return &testType{}, func() { cleaned = true }
+			}),
+			Func(func(*testType) (*panicConsumer, func()) {
+				return &panicConsumer{}, func() { panic(errors.New("close failed")) }
+			}),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var ts *panicConsumer
+		if err := i.InjectAs(&ts); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		i.Clean()
+		if !cleaned {
+			t.Error("Expected remaining cleanups to be executed")
+		}
+		if len(events) != 1 || events[0].Kind != EventPanic {
+			t.Errorf("Expected panic event, got %v", events)
+		}
+	})
+
+	t.Run("Concurrent cleanups", func(t *testing.T) {
+		i := New(DisallowNilOutputs())
+		i.Provide(
+			Func(func() (*testType, func()) { return nil, func() { panic("testType") } }),
+			Func(func() (*panicConsumer, func()) { return nil, func() { panic("panicConsumer") } }),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var wg sync.WaitGroup
+		for j := 0; j < 10; j++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				var tt *testType
+				_ = i.InjectAs(&tt)
+			}()
+			go func() {
+				defer wg.Done()
+				var pc *panicConsumer
+				_ = i.InjectAs(&pc)
+			}()
+		}
+		wg.Wait()
+		var errs multiError
+		if !errors.As(i.Close(), &errs) || len(errs) != 20 {
+			t.Errorf("Expected %v, got %v", 20, len(errs))
+		}
+	})
+
+	t.Run("Propagation", func(t *testing.T) {
+		i := New(WithPanicPropagation())
+		i.Provide(Func(panickingProvider))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		defer func() {
+			if r := recover(); r != "misconfigured" {
+				t.Errorf("Expected %v, got %v", "misconfigured", r)
+			}
+		}()
+		var tt *testType
+		_ = i.InjectAs(&tt)
+	})
+}
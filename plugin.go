@@ -0,0 +1,43 @@
+//go:build (linux || darwin || freebsd) && cgo
+
+package wireless
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// PluginSymbol is the name of the function that a Go plugin needs to export in order to be loaded by the injector.
+// The function is expected to have a signature:
+//
+//	func WirelessProviders() wireless.ProviderSet
+const PluginSymbol = "WirelessProviders"
+
+// LoadPlugin opens the Go plugin file at given path, looks up its PluginSymbol function and provides
+// returned providers to the injector.
+func (i *Injector) LoadPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening plugin %s failed: %w", path, err)
+	}
+	return i.loadSymbols(path, p)
+}
+
+// symbols is the set of the symbols exported by the plugin.
+type symbols interface {
+	Lookup(name string) (plugin.Symbol, error)
+}
+
+// loadSymbols looks up the PluginSymbol function of the plugin and provides returned providers to the injector.
+func (i *Injector) loadSymbols(path string, p symbols) error {
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return fmt.Errorf("plugin %s does not export %s: %w", path, PluginSymbol, err)
+	}
+	providers, ok := sym.(func() ProviderSet)
+	if !ok {
+		return fmt.Errorf("plugin %s symbol %s is not a func() wireless.ProviderSet but: %T", path, PluginSymbol, sym)
+	}
+	i.Provide(providers())
+	return nil
+}
@@ -0,0 +1,64 @@
+//go:build (linux || darwin || freebsd) && cgo
+
+package wireless
+
+import (
+	"errors"
+	"plugin"
+	"strings"
+	"testing"
+)
+
+type pluginSymbols map[string]plugin.Symbol
+
+func (s pluginSymbols) Lookup(name string) (plugin.Symbol, error) {
+	sym, ok := s[name]
+	if !ok {
+		return nil, errors.New("symbol " + name + " not found")
+	}
+	return sym, nil
+}
+
+func TestLoadPlugin(t *testing.T) {
+	t.Run("Symbol", func(t *testing.T) {
+		provider := &testType{v: "plugin"}
+		i := New()
+		err := i.loadSymbols("test.so", pluginSymbols{PluginSymbol: func() ProviderSet {
+			return NewSet(Value(provider))
+		}})
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err = i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		var ptr *testType
+		if err = i.InjectAs(&ptr); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if ptr != provider {
+			t.Errorf("Expected %v, got %v", provider, ptr)
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		err := New().LoadPlugin("missing.so")
+		if err == nil || !strings.Contains(err.Error(), "opening plugin missing.so failed") {
+			t.Errorf("Expected opening error, got %v", err)
+		}
+	})
+
+	t.Run("Missing symbol", func(t *testing.T) {
+		err := New().loadSymbols("test.so", pluginSymbols{})
+		if err == nil || !strings.Contains(err.Error(), "plugin test.so does not export WirelessProviders") {
+			t.Errorf("Expected missing symbol error, got %v", err)
+		}
+	})
+
+	t.Run("Invalid symbol", func(t *testing.T) {
+		err := New().loadSymbols("test.so", pluginSymbols{PluginSymbol: func() []Provider { return nil }})
+		if err == nil || !strings.Contains(err.Error(), "is not a func() wireless.ProviderSet but: func() []wireless.Provider") {
+			t.Errorf("Expected invalid symbol error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package wireless
+
+import "errors"
+
+// PluginSymbol is the name of the function that a Go plugin needs to export in order to be loaded by the injector.
+const PluginSymbol = "WirelessProviders"
+
+// LoadPlugin is not supported on this platform and always returns an error.
+func (i *Injector) LoadPlugin(path string) error {
+	return errors.New("go plugins are not supported on this platform")
+}
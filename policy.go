@@ -0,0 +1,115 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// dependencyPolicy is the list of the modules or types the module may depend on.
+type dependencyPolicy struct {
+	module  string
+	allowed []string
+}
+
+// WithDependencyPolicy declares the modules and types the module may depend on, reported by Validate if any
+// provider of the module depends on other ones, so that the architectural boundaries are enforced by the graph.
+// The modules are the package paths of the provided types, matching the packages nested in them as well,
+// and the types are matched by their names, e.g. '*db.Conn'. The module may always depend on itself,
+// on the standard library and on the types injected by the injector. The policies of the same module are merged.
+// Example:
+//
+//	wireless.New(
+//		wireless.WithDependencyPolicy("example.com/app/billing", "example.com/app/payments", "*auth.User"),
+//	)
+func WithDependencyPolicy(module string, allowed ...string) Option {
+	return func(i *Injector) {
+		i.policies = append(i.policies, dependencyPolicy{module: module, allowed: allowed})
+	}
+}
+
+// lintPolicies reports the dependencies forbidden by the dependency policies.
+func (i *Injector) lintPolicies() []error {
+	if len(i.policies) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, p := range i.allProviders() {
+		module := typePackage(p.out)
+		allowed, ok := i.allowedDependencies(module)
+		if !ok {
+			continue
+		}
+		for _, in := range p.dependencyTypes() {
+			if !dependencyAllowed(module, in, allowed) {
+				errs = append(errs, fmt.Errorf("provider: %s of type: %s in the module: %s depends on the type: %s "+
+					"not allowed by the dependency policy", p.name(), p.out, module, in))
+			}
+		}
+	}
+	return errs
+}
+
+// dependencyTypes returns the input types of the provider function and of its decorators, except for
+// the decorated values.
+func (p *providerFunc) dependencyTypes() []reflect.Type {
+	ins := p.inTypes
+	for _, d := range p.decorators {
+		ins = append(ins[:len(ins):len(ins)], d.inTypes[1:]...)
+	}
+	return ins
+}
+
+// allowedDependencies returns the merged allowed dependencies of the policies matching the module, reporting
+// whether any of them matches.
+func (i *Injector) allowedDependencies(module string) ([]string, bool) {
+	var (
+		allowed []string
+		matched bool
+	)
+	for _, p := range i.policies {
+		if inModule(module, p.module) {
+			allowed, matched = append(allowed, p.allowed...), true
+		}
+	}
+	return allowed, matched
+}
+
+// dependencyAllowed reports whether the module may depend on the type.
+func dependencyAllowed(module string, t reflect.Type, allowed []string) bool {
+	pkg := typePackage(t)
+	if pkg == "" || isStandard(pkg) || isBuiltin(t) || inModule(pkg, module) {
+		return true
+	}
+	for _, a := range allowed {
+		if a == t.String() || inModule(pkg, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// typePackage returns the package path of the named type, or of the element of the unnamed pointer, slice,
+// array, map or channel type.
+func typePackage(t reflect.Type) string {
+	for t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			t = t.Elem()
+		default:
+			return ""
+		}
+	}
+	return t.PkgPath()
+}
+
+// inModule reports whether the package is the module or is nested in it.
+func inModule(pkg, module string) bool {
+	return pkg == module || strings.HasPrefix(pkg, module+"/")
+}
+
+// isStandard reports whether the package belongs to the standard library, which has no dot in its first element.
+func isStandard(pkg string) bool {
+	first, _, _ := strings.Cut(pkg, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,53 @@
+package wireless
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestDependencyPolicy(t *testing.T) {
+	// The standard library package depends on this module, so that the test needs no foreign module.
+	const module = "encoding/xml"
+	provide := func(i *Injector) {
+		i.Provide(
+			Value(&testType{}),
+			Func(func(ctx context.Context, tt *testType, lc *Lifecycle) *xml.Name { return &xml.Name{} }),
+			Func(func(n *xml.Name) xml.Name { return *n }),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+	}
+
+	t.Run("forbidden", func(t *testing.T) {
+		i := New(WithDependencyPolicy(module))
+		provide(i)
+		err := i.Validate()
+		if err == nil || !strings.Contains(err.Error(), "depends on the type: *wireless.testType not allowed") {
+			t.Errorf("Expected the forbidden dependency, got %v", err)
+		}
+		if strings.Count(err.Error(), "not allowed") != 1 {
+			t.Errorf("Expected the single forbidden dependency, got %v", err)
+		}
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		for name, allowed := range map[string]string{"module": "github.com/routercore/wireless", "type": "*wireless.testType"} {
+			i := New(WithDependencyPolicy(module), WithDependencyPolicy(module, allowed))
+			provide(i)
+			if err := i.Validate(); err != nil {
+				t.Errorf("Expected no error for the allowed %v, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("other module", func(t *testing.T) {
+		i := New(WithDependencyPolicy("example.com/billing"))
+		provide(i)
+		if err := i.Validate(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package wireless
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Pooled makes the provider function construct the instances kept in the sync.Pool, rather than a single
+// instance. Each injection of the type, as well as each construction of its dependents, gets an instance from
+// the pool, constructing a new one only if the pool is empty. The instances are returned to the pool with
+// the injected ReleaseFunc, which first resets the instances implementing Resetter.
+// Example:
+//
+//	wireless.Pooled(wireless.Func(func() *bytes.Buffer { return new(bytes.Buffer) }))
+//
+//	func (h *Handler) Handle(...) {
+//		var buf *bytes.Buffer
+//		_ = h.resolver.InjectAs(&buf)
+//		defer h.release(buf)
+//		...
+//	}
+func Pooled(p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.pooled = true })
+	return p
+}
+
+// Resetter is implemented by the pooled types which need to be reset before they are returned to the pool.
+type Resetter interface {
+	Reset()
+}
+
+// ReleaseFunc returns the instance of the pooled type to its pool. The instances of other types are ignored.
+// It is injectable from every injector and scope.
+type ReleaseFunc func(obj interface{})
+
+var releaseFuncType = reflect.TypeOf(ReleaseFunc(nil))
+
+// release returns the instance to the pool of its type in the scope or its nearest ancestor.
+func (i *Injector) release(obj interface{}) {
+	if obj == nil {
+		return
+	}
+	t := reflect.TypeOf(obj)
+	for s := i; s != nil; s = s.parent {
+		p, ok := s.providersMap[t]
+		if !ok {
+			continue
+		}
+		if p.pool == nil {
+			return
+		}
+		if r, ok := obj.(Resetter); ok {
+			r.Reset()
+		}
+		p.pool.Put(obj)
+		return
+	}
+}
+
+// pooledInstance returns the instance of the pooled provider, constructing it if the pool is empty.
+func (i *Injector) pooledInstance(ctx context.Context, p *providerFunc) (reflect.Value, error) {
+	if v := p.pool.Get(); v != nil {
+		return reflect.ValueOf(v).Convert(p.out), nil
+	}
+	p.poolLock.Lock()
+	defer p.poolLock.Unlock()
+	out, err := i.construct(ctx, p)
+	if err != nil {
+		return out, err
+	}
+	if !p.pooledUsed {
+		p.pooledUsed = true
+		i.funcsLock.Lock()
+		i.providerFuncs = append(i.providerFuncs, p)
+		i.funcsLock.Unlock()
+	}
+	return out, nil
+}
+
+// newPool returns the pool of the pooled provider, or nil.
+func newPool(o providerOptions) *sync.Pool {
+	if !o.pooled {
+		return nil
+	}
+	return &sync.Pool{}
+}
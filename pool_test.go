@@ -0,0 +1,99 @@
+package wireless
+
+import (
+	"testing"
+)
+
+type pooledBuffer struct {
+	data  []byte
+	reset int
+}
+
+func (b *pooledBuffer) Reset() {
+	b.data = b.data[:0]
+	b.reset++
+}
+
+type pooledConsumer struct {
+	buf *pooledBuffer
+}
+
+func TestPooled(t *testing.T) {
+	t.Run("Reuse", func(t *testing.T) {
+		var constructed int
+		i := New()
+		i.Provide(Pooled(Func(func() *pooledBuffer {
+			constructed++
+			return &pooledBuffer{}
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var first, second *pooledBuffer
+		if err := i.InjectAs(&first); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err := i.InjectAs(&second); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if first == second {
+			t.Error("Expected distinct instances while none is released")
+		}
+
+		var release ReleaseFunc
+		if err := i.InjectAs(&release); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		first.data = append(first.data, 'x')
+		release(first)
+		if first.reset != 1 || len(first.data) != 0 {
+			t.Errorf("Expected reset instance, got %+v", first)
+		}
+		// Releasing the instances of not pooled types is ignored.
+		release(&testType{})
+		release(nil)
+
+		var third *pooledBuffer
+		if err := i.InjectAs(&third); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if third == nil || constructed < 2 {
+			t.Errorf("Expected at least %v constructions, got %v", 2, constructed)
+		}
+	})
+
+	t.Run("Dependency", func(t *testing.T) {
+		var cleaned int
+		i := New()
+		i.Provide(
+			Pooled(Func(func() (*pooledBuffer, func()) {
+				return &pooledBuffer{}, func() { cleaned++ }
+			})),
+			Func(func(b *pooledBuffer) *pooledConsumer { return &pooledConsumer{buf: b} }),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var c *pooledConsumer
+		if err := i.InjectAs(&c); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if c.buf == nil {
+			t.Error("Expected pooled dependency, got nil")
+		}
+		var b *pooledBuffer
+		if err := i.InjectAs(&b); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if b == c.buf {
+			t.Error("Expected distinct pooled instances")
+		}
+
+		i.Clean()
+		if cleaned != 2 {
+			t.Errorf("Expected %v cleanups, got %v", 2, cleaned)
+		}
+	})
+}
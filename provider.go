@@ -1,22 +1,109 @@
 package wireless
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
 // Bind provides interface type binding for the type 'to' to the interface type 'iface'.
+// The 'iface' might also be a function type, in which case the 'to' function type needs to be convertible to it.
+// Both types are declared either with the `new` statement or as the reflect.Type.
 // Example:
 // 	wireless.Bind(new(io.Reader), new(*bytes.Reader))
+// 	wireless.Bind(reflect.TypeOf((*io.Reader)(nil)).Elem(), reflect.TypeOf(new(bytes.Reader)))
 func Bind(iface interface{}, to interface{}) Provider {
-	return &bindingProvider{iface: iface, to: to}
+	return &bindingProvider{iface: iface, to: to, providerOptions: declared()}
+}
+
+// BindOf provides interface type binding for the type T to the interface or function type I.
+// Example:
+//
+//	wireless.BindOf[io.Reader, *bytes.Reader]()
+func BindOf[I, T any]() Provider {
+	return &bindingProvider{iface: new(I), to: new(T), providerOptions: declared()}
+}
+
+// BindWithConstraint provides the interface type binding same as Bind, with the constraints the type 'to'
+// needs to satisfy, verified by Resolve.
+// Example:
+//
+//	wireless.BindWithConstraint(new(Repository), new(*PostgresRepository), wireless.MustImplement(new(io.Closer)))
+func BindWithConstraint(iface interface{}, to interface{}, constraints ...BindingConstraint) Provider {
+	return &bindingProvider{iface: iface, to: to, constraints: constraints, providerOptions: declared()}
+}
+
+// Alias registers the single instance of the type 'to' under each of the interface or function types 'aliases',
+// so that all of them are injected with the same instance without the wrapper providers.
+// Example:
+//
+//	wireless.Alias(new(*PostgresStore), new(ReadStore), new(WriteStore))
+func Alias(to interface{}, aliases ...interface{}) ProviderSet {
+	set, o := make(ProviderSet, len(aliases)), declared()
+	for j, a := range aliases {
+		set[j] = &bindingProvider{iface: a, to: to, providerOptions: o}
+	}
+	return set
+}
+
+// BindingConstraint is the requirement on the type bound to the interface type, returning an error if the type
+// does not satisfy it.
+type BindingConstraint func(iface, to reflect.Type) error
+
+// MustImplement is the BindingConstraint requiring the bound type to implement the interface type declared
+// with the `new` statement or as the reflect.Type.
+func MustImplement(iface interface{}) BindingConstraint {
+	return func(_, to reflect.Type) error {
+		it, err := bindingType(iface)
+		if err != nil {
+			return fmt.Errorf("constraint interface %w", err)
+		}
+		if it.Kind() != reflect.Interface {
+			return fmt.Errorf("constraint type: %s is not an interface", it)
+		}
+		if !to.Implements(it) {
+			return fmt.Errorf("type: %s does not implement the required interface: %s", to, it)
+		}
+		return nil
+	}
+}
+
+// Convertible declares that the type 'to' might be injected with the value of the type 'from' converted to it.
+// Both types need to have the same underlying type. Without it, defined types are never converted implicitly.
+// Example:
+//
+//	type Port int
+//
+//	wireless.Convertible(new(Port), new(int))
+func Convertible(to interface{}, from interface{}) Provider {
+	return &bindingProvider{iface: to, to: from, convertible: true, providerOptions: declared()}
 }
 
 // Value is the direct value provider type. This function is used to provide the
 func Value(value interface{}) Provider {
-	return &valueProvider{v: value}
+	return &valueProvider{v: value, providerOptions: declared()}
+}
+
+// Values provides multiple values at once, same as the Value called for each of them.
+// The nil values are reported with their positions.
+// Example:
+//
+//	wireless.Values(cfg, logger, clock, metrics)
+func Values(values ...interface{}) ProviderSet {
+	set, o := make(ProviderSet, len(values)), declared()
+	for j, v := range values {
+		set[j] = &valueProvider{v: v, position: j + 1, providerOptions: o}
+	}
+	return set
 }
 
 // InterfaceValue defines interface value casting that could be done for proper injection.
+// The 'iface' might also be a function type, in which case the value is converted to that type.
 // Example:
 //	wireless.InterfaceValue(new(io.Reader), new(*bytes.Reader))
 func InterfaceValue(iface interface{}, to interface{}) Provider {
-	return &interfaceValueProvider{iface: iface, value: to}
+	return &interfaceValueProvider{iface: iface, value: to, providerOptions: declared()}
 }
 
 // NewSet creates a new ProviderSet.
@@ -25,11 +112,46 @@ func NewSet(providers ...Provider) ProviderSet {
 }
 
 // Func declares a provider function that creates and optionally cleans a new value.
+// The leading context.Context argument of the function is not injected from other providers, but it gets
+// the context of the injector passed to ResolveContext, or the one passed to InjectAsContext.
 func Func(in interface{}) Provider {
-	return &funcProvider{v: in}
+	return &funcProvider{v: in, providerOptions: declared()}
+}
+
+// FuncOf declares a provider function registered for the type T. The first returned value of the function needs
+// to be assignable to T, which allows registering instantiated generic constructors, or constructors returning
+// the implementation of an interface, explicitly as the type T.
+// Example:
+//
+//	wireless.FuncOf[Repository[User]](NewRepository[User])
+func FuncOf[T any](fn interface{}) Provider {
+	return &funcProvider{v: fn, as: reflect.TypeOf(new(T)).Elem(), providerOptions: declared()}
+}
+
+// Decorate declares a decorator function that wraps the value of an existing provider before it is injected anywhere.
+// The first argument and the first returned value of the decorator are the decorated type, the other arguments
+// are injected as dependencies. Same as in Func, the decorator might also return a cleanup function and an error.
+// Example:
+//
+//	wireless.Decorate(func(r Repository, log *Logger) (Repository, error) {
+//		return &loggingRepository{Repository: r, log: log}, nil
+//	})
+func Decorate(fn interface{}) Provider {
+	return &decoratorProvider{v: fn}
+}
+
+// PostProcessor is the function that processes each value constructed by the provider functions before it is cached.
+// It returns the value that replaces the constructed one, which needs to be assignable to the provided type.
+type PostProcessor func(t reflect.Type, v interface{}) (interface{}, error)
+
+// PostProcess registers the post processor executed over every value constructed by the provider functions.
+// Post processors are executed in the order of registration, after all the decorators of the value are applied.
+func PostProcess(fn PostProcessor) Provider {
+	return &postProcessorProvider{fn: fn}
 }
 
 // IfNotExists sets up input provider in the injector only no provider is defined for given type.
+// It applies to the values, interface values, bindings and provider functions.
 func IfNotExists(p Provider) Provider {
 	p.setOptions(func(o *providerOptions) { o.ifNotExists = true })
 	return p
@@ -41,11 +163,53 @@ func Namespace(namespace string, p Provider) Provider {
 	return p
 }
 
+// Weight sets up the order in which the decorators of the same type are applied. The decorators with lower weight
+// are applied first, so that they wrap the value closer to its provider. Decorators with equal weight are applied
+// in the order of registration.
+// Example:
+//
+//	wireless.NewSet(
+//		wireless.Weight(30, wireless.Decorate(WithLogging)),
+//		wireless.Weight(10, wireless.Decorate(WithRetry)),
+//		wireless.Weight(20, wireless.Decorate(WithMetrics)),
+//	)
+func Weight(weight int, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.weight = weight })
+	return p
+}
+
+// ScopedTo makes the provider function resolvable only inside the child scopes of the given kind, created with
+// NewScope. Each such scope constructs its own instance, while injecting the type outside of them fails.
+// Only the provider functions might be scoped, the values and bindings scoped with ScopedTo fail the resolution,
+// as they have no instance to be constructed per scope. To only guard the kind of the scope the provider
+// is declared in, without constructing it per scope, use AllowedIn instead.
+// Example:
+//
+//	wireless.ScopedTo("request", wireless.Func(NewPrincipal))
+func ScopedTo(kind string, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.scope = kind })
+	return p
+}
+
 type providerOption func(o *providerOptions)
 
 type providerOptions struct {
 	ifNotExists bool
+	override    bool
 	namespace   string
+	weight      int
+	group       string
+	name        string
+	scope       string
+	pooled      bool
+	weak        bool
+	idle        time.Duration
+	ttl         time.Duration
+	retry       *retryPolicy
+	breaker     *breakerPolicy
+	nonShared   bool
+	kinds       []string
+	source      string
 }
 
 // Provider is the interface that defines a provider.
@@ -64,11 +228,64 @@ func (ps ProviderSet) setOptions(op ...providerOption) {
 
 // bindingProvider is the injection binding of interface to some value.
 type bindingProvider struct {
-	iface interface{}
-	to    interface{}
+	iface       interface{}
+	to          interface{}
+	convertible bool
+	constraints []BindingConstraint
 	providerOptions
 }
 
+// types returns the bound interface type and the type it is bound to.
+func (b *bindingProvider) types() (reflect.Type, reflect.Type, error) {
+	it, err := bindingType(b.iface)
+	if err != nil {
+		if b.iface != nil && reflect.TypeOf(b.iface).Kind() != reflect.Ptr {
+			return nil, nil, fmt.Errorf("binding interface is given as the value of type: %T, declare the interface type with the `new` statement, e.g. new(io.Reader)", b.iface)
+		}
+		return nil, nil, fmt.Errorf("binding interface %w", err)
+	}
+	if !b.convertible && it.Kind() != reflect.Interface && it.Kind() != reflect.Func {
+		return nil, nil, fmt.Errorf("binding interface: %s is neither an interface nor a function type, declare the interface type with the `new` statement, e.g. new(io.Reader)", it)
+	}
+	to, err := bindingType(b.to)
+	if err != nil {
+		if b.to != nil && reflect.TypeOf(b.to).Kind() != reflect.Ptr {
+			return nil, nil, fmt.Errorf("binding of the interface: %s to the value of type: %T needs the bound type declared with the `new` statement, e.g. new(%T), or the value provided with InterfaceValue", it, b.to, b.to)
+		}
+		return nil, nil, fmt.Errorf("binding of the interface: %s %w", it, err)
+	}
+	return it, to, nil
+}
+
+// checkConstraints verifies the constraints of the binding.
+func (b *bindingProvider) checkConstraints(it, to reflect.Type) error {
+	var errs multiError
+	for _, c := range b.constraints {
+		if err := c(it, to); err != nil {
+			errs = append(errs, fmt.Errorf("binding between: %s and %s violates the constraint: %w", it, to, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// bindingType returns the type declared with the `new` statement or as the reflect.Type.
+func bindingType(v interface{}) (reflect.Type, error) {
+	switch vt := v.(type) {
+	case nil:
+		return nil, errors.New("type is nil")
+	case reflect.Type:
+		return vt, nil
+	}
+	t := reflect.TypeOf(v)
+	if t.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("type: %s is not defined with the `new` statement", t)
+	}
+	return t.Elem(), nil
+}
+
 func (b *bindingProvider) setOptions(options ...providerOption) {
 	for _, os := range options {
 		os(&b.providerOptions)
@@ -81,6 +298,20 @@ type interfaceValueProvider struct {
 	providerOptions
 }
 
+// convert returns the interface type and the value converted to it.
+func (i *interfaceValueProvider) convert() (reflect.Type, reflect.Value, error) {
+	to := reflect.ValueOf(i.value)
+	it := reflect.TypeOf(i.iface)
+	if it == nil || it.Kind() != reflect.Ptr {
+		return nil, reflect.Value{}, fmt.Errorf("one of provided interface values is not defining type with `new` statement: %T -> %s", i.iface, to.Type())
+	}
+	it = it.Elem()
+	if err := checkBindable(it, to.Type()); err != nil {
+		return nil, reflect.Value{}, fmt.Errorf("one of provided interface values %w", err)
+	}
+	return it, to.Convert(it), nil
+}
+
 func (i *interfaceValueProvider) setOptions(options ...providerOption) {
 	for _, os := range options {
 		os(&i.providerOptions)
@@ -89,6 +320,8 @@ func (i *interfaceValueProvider) setOptions(options ...providerOption) {
 
 type valueProvider struct {
 	v interface{}
+	// position is the position of the value in the Values call, starting at 1.
+	position int
 	providerOptions
 }
 
@@ -100,12 +333,78 @@ func (v *valueProvider) setOptions(options ...providerOption) {
 
 // funcProvider is the provider function used by the
 type funcProvider struct {
-	v interface{}
+	v  interface{}
+	as reflect.Type
 	providerOptions
 }
 
+// providerFunc parses the provider function, registered as the type 'as' if it is defined.
+func (f *funcProvider) providerFunc() (*providerFunc, error) {
+	pf, err := newProviderFunc(f.v)
+	if err != nil {
+		return nil, err
+	}
+	pf.pool = newPool(f.providerOptions)
+	pf.weak = newWeakInstance(f.providerOptions)
+	pf.ttl = newTTLState(f.providerOptions)
+	pf.retry = f.retry
+	pf.breaker = newBreaker(f.providerOptions)
+	pf.source = f
+	if f.as == nil || pf.out == f.as {
+		return pf, nil
+	}
+	if !pf.out.AssignableTo(f.as) {
+		return nil, fmt.Errorf("provider: %T returned type: %s is not assignable to: %s", f.v, pf.out, f.as)
+	}
+	ft := pf.value.Type()
+	ins, outs := make([]reflect.Type, ft.NumIn()), make([]reflect.Type, ft.NumOut())
+	for j := range ins {
+		ins[j] = ft.In(j)
+	}
+	for j := range outs {
+		outs[j] = ft.Out(j)
+	}
+	outs[0] = f.as
+	fn, as := pf.value, f.as
+	pf.value = reflect.MakeFunc(reflect.FuncOf(ins, outs, ft.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		var res []reflect.Value
+		if ft.IsVariadic() {
+			res = fn.CallSlice(args)
+		} else {
+			res = fn.Call(args)
+		}
+		res[0] = res[0].Convert(as)
+		return res
+	})
+	pf.out = f.as
+	return pf, nil
+}
+
 func (f *funcProvider) setOptions(options ...providerOption) {
 	for _, os := range options {
 		os(&f.providerOptions)
 	}
 }
+
+// decoratorProvider is the provider of a function that wraps the value of other provider.
+type decoratorProvider struct {
+	v interface{}
+	providerOptions
+}
+
+func (d *decoratorProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&d.providerOptions)
+	}
+}
+
+type postProcessorProvider struct {
+	fn PostProcessor
+	providerOptions
+}
+
+func (p *postProcessorProvider) setOptions(options ...providerOption) {
+	for _, os := range options {
+		os(&p.providerOptions)
+	}
+}
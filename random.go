@@ -0,0 +1,108 @@
+package wireless
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// IDGenerator generates the unique identifiers, injected into the components instead of calling the random
+// generators directly, so that the tests might make them reproducible with the SeededRandom.
+type IDGenerator interface {
+	// NewID returns the random, version 4, UUID.
+	NewID() string
+}
+
+// RandomIDs is the IDGenerator reading the crypto/rand.
+var RandomIDs IDGenerator = &uuidGenerator{read: readRandom}
+
+// Random is the provider set of the RandomIDs injected as the IDGenerator, and of the rand.Source safe for
+// the concurrent use seeded randomly, unless they are provided otherwise. The tests replace them
+// with the SeededRandom.
+// Example:
+//
+//	wireless.NewSet(wireless.Random, wireless.Func(NewOrderService))
+var Random = NewSet(
+	IfNotExists(InterfaceValue(new(IDGenerator), RandomIDs)),
+	IfNotExists(Func(func() rand.Source {
+		var seed [8]byte
+		readRandom(seed[:])
+		return NewSeededSource(int64(binary.LittleEndian.Uint64(seed[:])))
+	})),
+)
+
+// SeededRandom overrides the IDGenerator and the rand.Source with the deterministic ones, generating the same
+// sequences for the same seed.
+// Example:
+//
+//	i.Provide(app.Providers, wireless.SeededRandom(42))
+func SeededRandom(seed int64) Provider {
+	return NewSet(
+		Override(InterfaceValue(new(IDGenerator), NewSeededIDs(seed))),
+		Override(InterfaceValue(new(rand.Source), NewSeededSource(seed))),
+	)
+}
+
+// NewSeededIDs returns the IDGenerator generating the same UUIDs for the same seed.
+func NewSeededIDs(seed int64) IDGenerator {
+	src := NewSeededSource(seed).(rand.Source64)
+	return &uuidGenerator{read: func(b []byte) {
+		for j := 0; j < len(b); j += 8 {
+			var u [8]byte
+			binary.LittleEndian.PutUint64(u[:], src.Uint64())
+			copy(b[j:], u[:])
+		}
+	}}
+}
+
+// NewSeededSource returns the rand.Source, safe for the concurrent use, generating the same values for the same seed.
+func NewSeededSource(seed int64) rand.Source {
+	return &lockedSource{src: rand.NewSource(seed).(rand.Source64)}
+}
+
+// readRandom fills the bytes from the crypto/rand, which does not fail on the supported platforms.
+func readRandom(b []byte) {
+	if _, err := crand.Read(b); err != nil {
+		panic(fmt.Sprintf("reading random bytes failed: %s", err))
+	}
+}
+
+type uuidGenerator struct {
+	lock sync.Mutex
+	read func(b []byte)
+}
+
+func (g *uuidGenerator) NewID() string {
+	var b [16]byte
+	g.lock.Lock()
+	g.read(b[:])
+	g.lock.Unlock()
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+type lockedSource struct {
+	lock sync.Mutex
+	src  rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.src.Seed(seed)
+}
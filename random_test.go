@@ -0,0 +1,65 @@
+package wireless
+
+import (
+	"math/rand"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	inject := func(t *testing.T, providers ...Provider) (IDGenerator, rand.Source) {
+		i := New()
+		i.Provide(providers...)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var ids IDGenerator
+		if err := i.InjectAs(&ids); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var src rand.Source
+		if err := i.InjectAs(&src); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return ids, src
+	}
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	t.Run("random", func(t *testing.T) {
+		ids, src := inject(t, Random)
+		if ids != RandomIDs {
+			t.Errorf("Expected %v, got %v", RandomIDs, ids)
+		}
+		a, b := ids.NewID(), ids.NewID()
+		if !uuid.MatchString(a) || a == b {
+			t.Errorf("Expected the unique UUIDs, got %v and %v", a, b)
+		}
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = src.Int63()
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("seeded", func(t *testing.T) {
+		ids1, src1 := inject(t, Random, SeededRandom(42))
+		ids2, src2 := inject(t, SeededRandom(42), Random)
+		for j := 0; j < 3; j++ {
+			a, b := ids1.NewID(), ids2.NewID()
+			if a != b || !uuid.MatchString(a) {
+				t.Errorf("Expected %v, got %v", a, b)
+			}
+			if a, b := src1.Int63(), src2.Int63(); a != b {
+				t.Errorf("Expected %v, got %v", a, b)
+			}
+		}
+		if ids3, _ := inject(t, SeededRandom(43)); ids3.NewID() == NewSeededIDs(42).NewID() {
+			t.Error("Expected the different IDs for the different seeds")
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package wireless
+
+import (
+	"reflect"
+	"sync"
+)
+
+// registry is the process wide set of providers registered by the imported packages.
+var registry struct {
+	lock sync.Mutex
+	sets []ProviderSet
+}
+
+// Register adds the provider set to the global registry. It is meant to be called from the package init function,
+// so that a blank import of the package is enough to make its providers available.
+// Example:
+//
+//	func init() {
+//		wireless.Register(wireless.NewSet(wireless.Func(NewDriver)))
+//	}
+func Register(set ProviderSet) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.sets = append(registry.sets, set)
+}
+
+// FromRegistry collects all the provider sets registered with Register in the order of registration.
+// The providers are copied, so that the options set on the collected providers, e.g. with Override or ScopedTo,
+// do not affect the registered ones collected by other injectors.
+func FromRegistry() ProviderSet {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	set := make(ProviderSet, 0, len(registry.sets))
+	for _, s := range registry.sets {
+		set = append(set, copyProvider(s))
+	}
+	return set
+}
+
+// copyProvider returns the copy of the provider, with the provider sets copied recursively.
+func copyProvider(p Provider) Provider {
+	if ps, ok := p.(ProviderSet); ok {
+		set := make(ProviderSet, len(ps))
+		for j, sp := range ps {
+			set[j] = copyProvider(sp)
+		}
+		return set
+	}
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return p
+	}
+	c := reflect.New(v.Type().Elem())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(Provider)
+}
@@ -0,0 +1,48 @@
+package wireless
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	registry.lock.Lock()
+	sets := registry.sets
+	registry.lock.Unlock()
+	t.Cleanup(func() {
+		registry.lock.Lock()
+		registry.sets = sets
+		registry.lock.Unlock()
+	})
+
+	provider := &testType{v: "registered"}
+	Register(NewSet(Value(provider)))
+
+	i := New()
+	i.Provide(FromRegistry())
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var ptr *testType
+	err = i.InjectAs(&ptr)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	if ptr != provider {
+		t.Errorf("Expected %v, got %v", provider, ptr)
+	}
+
+	t.Run("Copied providers", func(t *testing.T) {
+		i := New()
+		i.Provide(Value(&testType{v: "own"}), IfNotExists(FromRegistry()))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		i = New()
+		i.Provide(Value(&testType{v: "own"}), FromRegistry())
+		if err := i.Resolve(); err == nil {
+			t.Error("Expected the registered provider unaffected by the options of the collected one, got nil")
+		}
+	})
+}
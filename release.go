@@ -0,0 +1,105 @@
+package wireless
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Release executes the cleanup functions of the instance of the type provided by the provider function and of all
+// the instances depending on it, and drops them, so that they are constructed again on the next injection.
+// The errors of the failed providers cached with ErrorCache are dropped as well.
+// It allows dropping the expensive resources in the middle of the run. The input is the pointer to the type,
+// e.g. new(*sql.DB). The values injected before the Release, also into the child scopes, are kept by their holders.
+func (i *Injector) Release(as interface{}) error {
+	if as == nil {
+		return errors.New("input release type is nil")
+	}
+	rt := reflect.TypeOf(as)
+	if rt.Kind() != reflect.Ptr {
+		return errors.New("input release type is not a pointer")
+	}
+	return i.ReleaseType(rt.Elem())
+}
+
+// ReleaseType releases the instance of the type same as Release, with the type known only at runtime,
+// e.g. requested by the operator with the admin endpoint.
+func (i *Injector) ReleaseType(t reflect.Type) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.resolved {
+		return ErrNotResolved
+	}
+	if i.cleaned {
+		return ErrAlreadyCleaned
+	}
+	if t == nil {
+		return errors.New("input release type is nil")
+	}
+	pf, ok := i.providersMap[t]
+	if !ok {
+		if bt, bound := i.bindings[t]; bound {
+			pf, ok = i.providersMap[bt]
+		}
+	}
+	if !ok {
+		if _, isValue := i.values[t]; isValue {
+			return fmt.Errorf("value of type: %s could not be released", t)
+		}
+		return notFoundError{t: t}
+	}
+
+	released := map[*providerFunc]bool{pf: true}
+	for _, p := range i.allProviders() {
+		i.dependsOn(p, released)
+	}
+	for _, p := range i.cleanupOrder() {
+		if released[p] {
+			i.drop(p)
+		}
+	}
+	for p, r := range released {
+		if r {
+			p.err = nil
+		}
+	}
+	return nil
+}
+
+// dependsOn reports whether the provider function depends on any of the released ones, marking it released if so.
+func (i *Injector) dependsOn(p *providerFunc, released map[*providerFunc]bool) bool {
+	if r, ok := released[p]; ok {
+		return r
+	}
+	released[p] = false
+	for _, dep := range p.dependencies {
+		if i.dependsOn(dep, released) {
+			released[p] = true
+			break
+		}
+	}
+	return released[p]
+}
+
+// drop executes the cleanup functions of the constructed provider function instance and drops it, so that it is
+// constructed again on the next injection. It needs to be called with the injector lock held.
+func (i *Injector) drop(p *providerFunc) {
+	if p.weak != nil {
+		p.weak.stop()
+	}
+	if p.ttl != nil {
+		p.ttl.stop()
+	}
+	i.clean(p)
+	p.outValue, p.err = reflect.Value{}, nil
+	i.nextGeneration(p.out)
+	i.funcsLock.Lock()
+	defer i.funcsLock.Unlock()
+	for j, pf := range i.providerFuncs {
+		if pf == p {
+			i.providerFuncs = append(i.providerFuncs[:j], i.providerFuncs[j+1:]...)
+			break
+		}
+	}
+}
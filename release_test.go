@@ -0,0 +1,74 @@
+package wireless
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRelease(t *testing.T) {
+	var (
+		built   int
+		cleaned []string
+	)
+	i := New()
+	i.Provide(
+		Func(func() (*orderA, func()) { built++; return &orderA{}, func() { cleaned = append(cleaned, "a") } }),
+		Func(func(*orderA) (*orderB, func()) { return &orderB{}, func() { cleaned = append(cleaned, "b") } }),
+		Func(func() (*orderC, func()) { return &orderC{}, func() { cleaned = append(cleaned, "c") } }),
+		Value(&testType{}),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var (
+		b *orderB
+		c *orderC
+	)
+	for _, as := range []interface{}{&b, &c} {
+		if err := i.InjectAs(as); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+	}
+
+	if err := i.Release(new(*orderA)); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if strings.Join(cleaned, "") != "ba" {
+		t.Errorf("Expected %v, got %v", "ba", cleaned)
+	}
+	var rebuilt *orderB
+	if err := i.InjectAs(&rebuilt); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if built != 2 {
+		t.Errorf("Expected %v, got %v", 2, built)
+	}
+
+	if err := i.Release(new(*testType)); err == nil {
+		t.Error("Expected error for the released value, got nil")
+	}
+	if err := i.Release(new(*orderD)); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+	}
+	if err := i.Release(orderA{}); err == nil {
+		t.Error("Expected error for the non pointer input, got nil")
+	}
+	cleaned = nil
+	if err := i.ReleaseType(reflect.TypeOf(&orderC{})); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if strings.Join(cleaned, "") != "c" {
+		t.Errorf("Expected %v, got %v", "c", cleaned)
+	}
+	if err := i.InjectAs(&c); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	cleaned = nil
+	i.Clean()
+	if strings.Join(cleaned, "") != "bca" {
+		t.Errorf("Expected %v, got %v", "bca", cleaned)
+	}
+}
@@ -0,0 +1,150 @@
+package wireless
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// maxBuildRecords is the maximum number of the constructions recorded in the startup report.
+const maxBuildRecords = 1024
+
+// StartupReport describes how the injector was resolved, started and which values it constructed until
+// it was started. The constructions after Start, e.g. of the pooled or expired values, are not recorded.
+// Example:
+//
+//	if err := i.Start(ctx); err != nil {
+//		return err
+//	}
+//	log.Print(i.StartupReport())
+type StartupReport struct {
+	// Resolve is the duration of the Resolve call.
+	Resolve time.Duration
+	// Start is the duration of the last Start call.
+	Start time.Duration
+	// Built are the constructions of the values by the provider functions, in the order of their execution.
+	Built []BuildRecord
+	// Dropped is the number of the constructions not recorded in Built, as it holds at most 1024 records.
+	Dropped int
+	// Decisions are the providers skipped or replaced during the resolution, in the order of the resolution.
+	Decisions []Decision
+}
+
+// BuildRecord describes the construction of the value by the provider function.
+type BuildRecord struct {
+	Type     reflect.Type
+	Provider string
+	Duration time.Duration
+	// Err is the error of the failed construction.
+	Err error
+}
+
+// Decision describes the provider skipped or replaced during the resolution.
+type Decision struct {
+	// Kind is the kind of the decision: 'skipped' for the IfNotExists providers of already provided types,
+	// 'override' for the Override providers replacing the existing ones and 'overridden' for the providers
+	// of the overridden types.
+	Kind     string
+	Type     reflect.Type
+	Provider string
+}
+
+// StartupReport returns the report of the injector resolution, start and the constructions made so far.
+func (i *Injector) StartupReport() StartupReport {
+	r := &i.report
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return StartupReport{
+		Resolve:   r.resolve,
+		Start:     r.start,
+		Built:     append([]BuildRecord(nil), r.built...),
+		Dropped:   r.dropped,
+		Decisions: append([]Decision(nil), r.decisions...),
+	}
+}
+
+// WriteTable writes the report as the human readable table.
+func (r StartupReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "resolved in %s, started in %s\n\n", r.Resolve, r.Start)
+	if r.Dropped > 0 {
+		fmt.Fprintf(tw, "%d constructions not recorded\n\n", r.Dropped)
+	}
+	fmt.Fprintln(tw, "#\tTYPE\tPROVIDER\tDURATION\tERROR")
+	for j, b := range r.Built {
+		errText := ""
+		if b.Err != nil {
+			errText = b.Err.Error()
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", j+1, b.Type, b.Provider, b.Duration, errText)
+	}
+	if len(r.Decisions) > 0 {
+		fmt.Fprintln(tw, "\nDECISION\tTYPE\tPROVIDER")
+		for _, d := range r.Decisions {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Kind, d.Type, d.Provider)
+		}
+	}
+	return tw.Flush()
+}
+
+// String returns the report formatted as the table.
+func (r StartupReport) String() string {
+	var sb strings.Builder
+	_ = r.WriteTable(&sb)
+	return sb.String()
+}
+
+// reportState collects the startup report of the injector.
+type reportState struct {
+	lock      sync.Mutex
+	resolve   time.Duration
+	start     time.Duration
+	built     []BuildRecord
+	dropped   int
+	started   bool
+	decisions []Decision
+}
+
+// recordBuild records the construction of the value by the provider function, until the injector is started.
+func (i *Injector) recordBuild(p *providerFunc, d time.Duration, err error) {
+	r := &i.report
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.started {
+		return
+	}
+	if len(r.built) >= maxBuildRecords {
+		r.dropped++
+		return
+	}
+	r.built = append(r.built, BuildRecord{Type: p.out, Provider: p.name(), Duration: d, Err: err})
+}
+
+// recordDecision records the provider skipped or replaced during the resolution.
+func (i *Injector) recordDecision(kind string, t reflect.Type, provider string) {
+	r := &i.report
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.decisions = append(r.decisions, Decision{Kind: kind, Type: t, Provider: provider})
+}
+
+// recordDuration records the duration of the resolution.
+func (i *Injector) recordDuration(d *time.Duration, since time.Time) {
+	r := &i.report
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	*d = time.Since(since)
+}
+
+// recordStart records the duration of the start and stops recording the constructions.
+func (i *Injector) recordStart(since time.Time) {
+	r := &i.report
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.start = time.Since(since)
+	r.started = true
+}
@@ -0,0 +1,73 @@
+package wireless
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStartupReport(t *testing.T) {
+	i := New()
+	i.Provide(
+		Func(func() *testType { return &testType{v: "app"} }),
+		IfNotExists(Func(func() *testType { return &testType{v: "default"} })),
+		Func(func(tt *testType) *lifecycleType { return &lifecycleType{name: tt.v} }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var lc *lifecycleType
+	if err := i.InjectAs(&lc); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.Start(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	r := i.StartupReport()
+	if len(r.Built) != 2 || r.Built[0].Type.String() != "*wireless.testType" || r.Built[1].Type.String() != "*wireless.lifecycleType" {
+		t.Errorf("Expected constructions in dependency order, got %v", r.Built)
+	}
+	if len(r.Decisions) != 1 || r.Decisions[0].Kind != "skipped" || r.Decisions[0].Type.String() != "*wireless.testType" {
+		t.Errorf("Expected skipped IfNotExists provider, got %v", r.Decisions)
+	}
+	table := r.String()
+	for _, expected := range []string{"TYPE", "*wireless.lifecycleType", "skipped"} {
+		if !strings.Contains(table, expected) {
+			t.Errorf("Expected %q in the table, got:\n%s", expected, table)
+		}
+	}
+}
+
+func TestStartupReportBounded(t *testing.T) {
+	i := New()
+	i.Provide(Pooled(Func(func() *pooledBuffer { return &pooledBuffer{} })))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	inject := func(n int) {
+		for j := 0; j < n; j++ {
+			var b *pooledBuffer
+			if err := i.InjectAs(&b); err != nil {
+				t.Fatal("Expected no error, got", err)
+			}
+		}
+	}
+
+	inject(maxBuildRecords + 10)
+	r := i.StartupReport()
+	if len(r.Built) != maxBuildRecords || r.Dropped != 10 {
+		t.Errorf("Expected %d records and %d dropped, got %d, %d", maxBuildRecords, 10, len(r.Built), r.Dropped)
+	}
+	if !strings.Contains(r.String(), "10 constructions not recorded") {
+		t.Errorf("Expected the dropped constructions in the table, got:\n%s", r.String())
+	}
+
+	if err := i.Start(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	inject(10)
+	if r := i.StartupReport(); len(r.Built) != maxBuildRecords || r.Dropped != 10 {
+		t.Errorf("Expected no constructions recorded after Start, got %d, %d", len(r.Built), r.Dropped)
+	}
+}
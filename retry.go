@@ -0,0 +1,49 @@
+package wireless
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Retry makes the failing provider function be called again, up to the given number of attempts in total,
+// before the construction of its value fails. The calls are delayed by the backoff duration, doubled after each
+// failed attempt, and they stop once the context of the construction is done. Each failed attempt is reported
+// with the EventRetry to the handler registered with WithEventHandler.
+// Example:
+//
+//	wireless.Retry(5, 200*time.Millisecond, wireless.Func(OpenDatabase))
+func Retry(attempts int, backoff time.Duration, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.retry = &retryPolicy{attempts: attempts, backoff: backoff} })
+	return p
+}
+
+// retryPolicy is the retry policy of the provider function.
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// callWithRetry calls the provider function according to its retry policy.
+func (i *Injector) callWithRetry(ctx context.Context, p *providerFunc, ins []reflect.Value) (reflect.Value, reflect.Value, error) {
+	out, cleanup, err := i.call(p, ins)
+	if err == nil || p.retry == nil {
+		return out, cleanup, err
+	}
+	backoff := p.retry.backoff
+	for attempt := 1; attempt < p.retry.attempts; attempt++ {
+		i.emit(Event{Kind: EventRetry, Type: p.out, Provider: p.name(), Attempt: attempt, Err: err})
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return out, cleanup, err
+		case <-t.C:
+		}
+		backoff *= 2
+		if out, cleanup, err = i.call(p, ins); err == nil {
+			return out, cleanup, nil
+		}
+	}
+	return out, cleanup, err
+}
@@ -0,0 +1,81 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	errUnavailable := errors.New("database unavailable")
+
+	t.Run("Success", func(t *testing.T) {
+		var calls int
+		var events []Event
+		i := New(WithEventHandler(func(e Event) { events = append(events, e) }))
+		i.Provide(Retry(3, time.Millisecond, Func(func() (*testType, error) {
+			calls++
+			if calls < 3 {
+				return nil, errUnavailable
+			}
+			return &testType{v: "connected"}, nil
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected %v, got %v", 3, calls)
+		}
+		if len(events) != 2 || events[1].Kind != EventRetry || events[1].Attempt != 2 || !errors.Is(events[1].Err, errUnavailable) {
+			t.Errorf("Expected two retry events, got %v", events)
+		}
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		var calls int
+		i := New()
+		i.Provide(Retry(2, time.Millisecond, Func(func() (*testType, error) {
+			calls++
+			return nil, errUnavailable
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt *testType
+		if err := i.InjectAs(&tt); !errors.Is(err, errUnavailable) {
+			t.Errorf("Expected %v, got %v", errUnavailable, err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected %v, got %v", 2, calls)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		var calls int
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		i := New()
+		i.Provide(Retry(5, time.Hour, Func(func() (*testType, error) {
+			calls++
+			return nil, errUnavailable
+		})))
+		if err := i.ResolveContext(ctx); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt *testType
+		if err := i.InjectAs(&tt); !errors.Is(err, errUnavailable) {
+			t.Errorf("Expected %v, got %v", errUnavailable, err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected %v, got %v", 1, calls)
+		}
+	})
+}
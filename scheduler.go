@@ -0,0 +1,220 @@
+package wireless
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ScheduledJobsGroup is the name of the group of the ScheduledJob values executed by the Scheduler.
+const ScheduledJobsGroup = "wireless.scheduled"
+
+// Schedule computes the next time the ScheduledJob is executed at, e.g. out of the cron expression.
+type Schedule interface {
+	// Next returns the next execution time after the input time.
+	Next(after time.Time) time.Time
+}
+
+// ScheduleFunc is the function implementing Schedule.
+type ScheduleFunc func(after time.Time) time.Time
+
+// Next implements Schedule.
+func (f ScheduleFunc) Next(after time.Time) time.Time {
+	return f(after)
+}
+
+// Every returns the Schedule executing the job periodically with the interval.
+func Every(interval time.Duration) Schedule {
+	return ScheduleFunc(func(after time.Time) time.Time { return after.Add(interval) })
+}
+
+// OverlapPolicy decides on the execution of the ScheduledJob which is due while its previous run is still running.
+type OverlapPolicy int
+
+// Overlap policies of the ScheduledJob.
+const (
+	// OverlapSkip skips the run while the previous one is still running.
+	OverlapSkip OverlapPolicy = iota
+	// OverlapAllow executes the runs concurrently.
+	OverlapAllow
+)
+
+// ScheduledJob is the job executed periodically by the Scheduler. The providers register the jobs with Scheduled.
+type ScheduledJob struct {
+	Name     string
+	Schedule Schedule
+	Job      Job
+	// Timeout is the timeout of the context of each run, if positive.
+	Timeout time.Duration
+	Overlap OverlapPolicy
+}
+
+// Scheduled adds the provider of the ScheduledJob to the group of the jobs executed by the Scheduler.
+// Example:
+//
+//	wireless.Scheduled(wireless.Func(func(s *Store) wireless.ScheduledJob {
+//		return wireless.ScheduledJob{Name: "purge", Schedule: wireless.Every(time.Hour), Job: s.Purge, Timeout: time.Minute}
+//	}))
+func Scheduled(p Provider) Provider {
+	return Group(ScheduledJobsGroup, p)
+}
+
+// Scheduler is the provider set of the *JobScheduler executing the ScheduledJob group registered with Scheduled.
+// The JobScheduler depends on the *Injector to collect the group, so it needs to be allowed with WithInjectorAllowlist
+// if the dependencies on the injector are restricted.
+var Scheduler = NewSet(Func(func(i *Injector, lc *Lifecycle) (*JobScheduler, error) {
+	var jobs []ScheduledJob
+	if err := i.injectGroup(i.context(), ScheduledJobsGroup, reflect.ValueOf(&jobs)); err != nil {
+		return nil, err
+	}
+	return NewJobScheduler(jobs, lc)
+}))
+
+// JobScheduler executes the scheduled jobs between the start and the stop of the injector lifecycle.
+// The failed runs, including the recovered panics wrapped into the PanicError, are emitted with the EventJobFailed
+// and counted in its Stats.
+type JobScheduler struct {
+	jobs           []*scheduledJob
+	cancelSchedule context.CancelFunc
+	cancelRuns     context.CancelFunc
+	wg             sync.WaitGroup
+	emit           func(Event)
+}
+
+// JobStats are the metrics of the scheduled job.
+type JobStats struct {
+	Name string
+	// Runs is the number of the finished runs, including the failed ones.
+	Runs     int
+	Failures int
+	// Skipped is the number of the runs skipped due to the OverlapSkip policy.
+	Skipped      int
+	Running      int
+	LastRun      time.Time
+	LastDuration time.Duration
+	LastErr      error
+}
+
+type scheduledJob struct {
+	ScheduledJob
+	lock  sync.Mutex
+	stats JobStats
+}
+
+// NewJobScheduler creates the JobScheduler of the jobs appending its lifecycle hook, which starts the scheduling
+// and stops it, waiting for the running jobs until the stop context is done.
+func NewJobScheduler(jobs []ScheduledJob, lc *Lifecycle) (*JobScheduler, error) {
+	s := &JobScheduler{emit: lc.emit}
+	for j, job := range jobs {
+		if job.Name == "" {
+			job.Name = strconv.Itoa(j)
+		}
+		if job.Schedule == nil || job.Job == nil {
+			return nil, fmt.Errorf("scheduled job: %s has no schedule or job function", job.Name)
+		}
+		s.jobs = append(s.jobs, &scheduledJob{ScheduledJob: job, stats: JobStats{Name: job.Name}})
+	}
+	lc.Append(Hook{Name: "scheduler", OnStart: s.start, OnStop: s.stop})
+	return s, nil
+}
+
+// Stats returns the current metrics of the jobs, in the order of the group.
+func (s *JobScheduler) Stats() []JobStats {
+	stats := make([]JobStats, len(s.jobs))
+	for j, job := range s.jobs {
+		job.lock.Lock()
+		stats[j] = job.stats
+		job.lock.Unlock()
+	}
+	return stats
+}
+
+// start starts scheduling the jobs, with the context of the runs outliving the start context.
+func (s *JobScheduler) start(ctx context.Context) error {
+	var scheduleCtx, runCtx context.Context
+	runCtx, s.cancelRuns = context.WithCancel(context.WithoutCancel(ctx))
+	scheduleCtx, s.cancelSchedule = context.WithCancel(runCtx)
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.schedule(scheduleCtx, runCtx, job)
+	}
+	return nil
+}
+
+// stop stops scheduling the jobs and waits for the running ones until the context is done, when the context
+// of the runs is canceled.
+func (s *JobScheduler) stop(ctx context.Context) error {
+	s.cancelSchedule()
+	defer s.cancelRuns()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stopping scheduler failed: %w", ctx.Err())
+	}
+}
+
+// schedule executes the job according to its schedule until the schedule context is canceled.
+func (s *JobScheduler) schedule(scheduleCtx, runCtx context.Context, job *scheduledJob) {
+	defer s.wg.Done()
+	next := job.Schedule.Next(time.Now())
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-scheduleCtx.Done():
+			timer.Stop()
+			return
+		case now := <-timer.C:
+			next = job.Schedule.Next(now)
+		}
+		job.lock.Lock()
+		if job.Overlap == OverlapSkip && job.stats.Running > 0 {
+			job.stats.Skipped++
+			job.lock.Unlock()
+			continue
+		}
+		job.stats.Running++
+		job.lock.Unlock()
+		s.wg.Add(1)
+		go s.run(runCtx, job)
+	}
+}
+
+// run executes the single run of the job, recovering its panic into the PanicError.
+func (s *JobScheduler) run(ctx context.Context, job *scheduledJob) {
+	defer s.wg.Done()
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Provider: job.Name, Value: r, Stack: debug.Stack()}
+			}
+		}()
+		return job.Job(ctx)
+	}()
+	job.lock.Lock()
+	job.stats.Running--
+	job.stats.Runs++
+	job.stats.LastRun, job.stats.LastDuration, job.stats.LastErr = start, time.Since(start), err
+	if err != nil {
+		job.stats.Failures++
+	}
+	job.lock.Unlock()
+	if err != nil && s.emit != nil {
+		s.emit(Event{Kind: EventJobFailed, Type: reflect.TypeOf(job.Job), Provider: job.Name, Err: err})
+	}
+}
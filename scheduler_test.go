@@ -0,0 +1,77 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	var ticks, slow, timedOut atomic.Int32
+	jobErr := errors.New("job failed")
+	events := make(chan Event, 100)
+	i := New(WithEventHandler(func(e Event) {
+		if e.Kind == EventJobFailed {
+			events <- e
+		}
+	}))
+	i.Provide(
+		Scheduler,
+		Scheduled(Value(ScheduledJob{Name: "tick", Schedule: Every(time.Millisecond), Job: func(ctx context.Context) error {
+			ticks.Add(1)
+			return nil
+		}})),
+		Scheduled(Value(ScheduledJob{Name: "slow", Schedule: Every(time.Millisecond), Job: func(ctx context.Context) error {
+			slow.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			return jobErr
+		}})),
+		Scheduled(Value(ScheduledJob{Name: "timeout", Schedule: Every(time.Millisecond), Timeout: time.Millisecond, Overlap: OverlapAllow,
+			Job: func(ctx context.Context) error {
+				<-ctx.Done()
+				timedOut.Add(1)
+				return ctx.Err()
+			}})),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var s *JobScheduler
+	if err := i.InjectAs(&s); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.Start(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if err := i.Stop(context.Background()); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	stats := s.Stats()
+	if len(stats) != 3 || stats[0].Name != "tick" || stats[0].Runs < 2 || stats[0].Failures != 0 {
+		t.Errorf("Expected the tick runs, got %+v", stats)
+	}
+	if slow.Load() > 2 || stats[1].Skipped == 0 || !errors.Is(stats[1].LastErr, jobErr) {
+		t.Errorf("Expected the slow runs skipped, got %v runs and %+v", slow.Load(), stats[1])
+	}
+	if timedOut.Load() == 0 || stats[2].Failures != int(timedOut.Load()) || !errors.Is(stats[2].LastErr, context.DeadlineExceeded) {
+		t.Errorf("Expected the timed out runs, got %+v", stats[2])
+	}
+	for _, st := range stats {
+		if st.Running != 0 {
+			t.Errorf("Expected no running jobs after stop, got %+v", st)
+		}
+	}
+	if e := <-events; e.Provider != "slow" && e.Provider != "timeout" {
+		t.Errorf("Expected the failed job event, got %v", e)
+	}
+
+	t.Run("invalid job", func(t *testing.T) {
+		if _, err := NewJobScheduler([]ScheduledJob{{Name: "broken"}}, &Lifecycle{}); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NewScope creates the child injector of the given kind, e.g. "request" or "command", which is provided, resolved
+// and cleaned independently of its parent. The types not provided by the child scope are injected from its
+// ancestors, while the types provided by the child scope shadow the ones of its ancestors.
+// The types of the ancestors are referenced lazily: they are constructed by the ancestor on the first injection
+// into the child scope, under the synchronization of the ancestor, so that the scopes used concurrently share
+// the single instance.
+// The child scope inherits the options of the parent, which might be extended with the input options.
+// Example:
+//
+//	s := i.NewScope("request")
+//	s.Provide(wireless.Value(r))
+//	if err := s.Resolve(); err != nil { ... }
+//	defer s.Clean()
+func (i *Injector) NewScope(kind string, options ...Option) *Injector {
+	inherit := func(c *Injector) {
+		c.parent = i
+		c.kind = kind
+		c.validator = i.validator
+		c.disallowNilOutput = i.disallowNilOutput
+		c.unexportedFields = i.unexportedFields
+		c.setterInjection = i.setterInjection
+		c.strictPrimitives = i.strictPrimitives
+		c.injectorAllowlist = i.injectorAllowlist
+		c.configTypes = i.configTypes
+		c.maxDependencies, c.maxFanIn = i.maxDependencies, i.maxFanIn
+		c.policies, c.layerRules = i.policies, i.layerRules
+		c.eventHandler = i.eventHandler
+		c.strictShadowing = i.strictShadowing
+		c.propagatePanics = i.propagatePanics
+		c.idempotentResolve = i.idempotentResolve
+		c.typedNilValues = i.typedNilValues
+		c.scopeKinds = i.scopeKinds
+		c.leakAfter = i.leakAfter
+		c.errorPolicy = i.errorPolicy
+		c.autoBind = i.autoBind
+		c.trail = i.trail
+		c.rewriters = i.rewriters
+	}
+	c := New(append([]Option{inherit}, options...)...)
+	c.scopeID = scopeIDs.Add(1)
+	c.checkScopeKind()
+	i.addChild(c)
+	return c
+}
+
+// Parent returns the parent of the child scope, or nil for the root injector.
+func (i *Injector) Parent() *Injector {
+	return i.parent
+}
+
+// Kind returns the kind of the child scope, or an empty string for the root injector.
+func (i *Injector) Kind() string {
+	return i.kind
+}
+
+// parentProviderFunc returns the provider function of the child scope injecting the type from its parent.
+func (i *Injector) parentProviderFunc(out reflect.Type) *providerFunc {
+	if pf, ok := i.providersMap[out]; ok {
+		return pf
+	}
+	fn := func([]reflect.Value) []reflect.Value {
+		v := reflect.New(out)
+		if err := i.parent.InjectAs(v.Interface()); err != nil {
+			return []reflect.Value{v.Elem(), reflect.ValueOf(&err).Elem()}
+		}
+		return []reflect.Value{v.Elem(), reflect.Zero(errorType)}
+	}
+	pf := &providerFunc{
+		id:         i.nextID(),
+		value:      reflect.MakeFunc(reflect.FuncOf(nil, []reflect.Type{out, errorType}, false), fn),
+		out:        out,
+		errOut:     1,
+		cleanupOut: -1,
+		depth:      -1,
+		inherited:  true,
+	}
+	i.providersMap[out] = pf
+	return pf
+}
+
+// matchScopedProviders registers the provider functions of the ancestors scoped to the kind of the child scope,
+// unless the child scope provides their types itself.
+func (i *Injector) matchScopedProviders() {
+	for s := i.parent; s != nil; s = s.parent {
+		for _, t := range sortedTypes(s.scoped) {
+			fp := s.scoped[t]
+			if fp.scope != i.kind {
+				continue
+			}
+			if _, ok := i.providersMap[t]; ok {
+				continue
+			}
+			pf, err := fp.providerFunc()
+			if err != nil {
+				i.errors = append(i.errors, err)
+				continue
+			}
+			pf.id = i.nextID()
+			i.providersMap[t] = pf
+		}
+	}
+}
+
+// scopedError returns the error of resolving the type scoped to other kind of the scope, or nil if the type
+// is not scoped.
+func (i *Injector) scopedError(t reflect.Type) error {
+	for s := i; s != nil; s = s.parent {
+		if fp, ok := s.scoped[t]; ok {
+			return fmt.Errorf("provider of type: %s is scoped to: %q and could not be resolved in the %q scope: %w", t, fp.scope, i.scopeKind(), ErrProviderNotFound)
+		}
+	}
+	return nil
+}
+
+// WithStrictShadowing makes the child scopes refuse the providers of the types provided by their ancestors,
+// instead of only emitting the EventShadowed event.
+func WithStrictShadowing() Option {
+	return func(i *Injector) {
+		i.strictShadowing = true
+	}
+}
+
+// checkShadowing reports the types of the child scope also provided by its ancestors.
+func (i *Injector) checkShadowing() {
+	if i.parent == nil {
+		return
+	}
+	check := func(t reflect.Type, source string) {
+		// The key of the nested session scope is expected to shadow the key of its ancestor.
+		if isBuiltin(t) || t == scopeKeyType || !i.parent.hasProvider(t) {
+			return
+		}
+		e := Event{Kind: EventShadowed, Type: t, Provider: source, Shadowed: i.parent.source(t)}
+		if i.strictShadowing {
+			i.errors = append(i.errors, fmt.Errorf("%s", e))
+		}
+		i.emit(e)
+	}
+	for _, t := range sortedTypes(i.values) {
+		check(t, "value")
+	}
+	for _, t := range sortedTypes(i.bindings) {
+		check(t, "binding to "+i.bindings[t].String())
+	}
+	for _, t := range sortedTypes(i.providersMap) {
+		check(t, i.providersMap[t].name())
+	}
+}
+
+// source returns the description of the provider of the type in the scope or its nearest ancestor.
+func (i *Injector) source(t reflect.Type) string {
+	for s := i; s != nil; s = s.parent {
+		if _, ok := s.values[t]; ok {
+			return "value"
+		}
+		if bt, ok := s.bindings[t]; ok {
+			return "binding to " + bt.String()
+		}
+		if p, ok := s.providersMap[t]; ok {
+			return p.name()
+		}
+	}
+	return ""
+}
+
+// isBuiltin reports whether the type is provided by every injector and scope.
+func isBuiltin(t reflect.Type) bool {
+	return t == injectorType || t == resolverType || t == lifecyclePtrType || t == contextType || t == releaseFuncType
+}
@@ -0,0 +1,251 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type scopeRequest struct {
+	ID string
+}
+
+type scopeHandler struct {
+	Request *scopeRequest
+	Shared  *initType
+}
+
+func TestScope(t *testing.T) {
+	i := New()
+	i.Provide(Func(func() *initType { return &initType{} }))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	newScope := func(id string) *Injector {
+		s := i.NewScope("request")
+		s.Provide(
+			Value(&scopeRequest{ID: id}),
+			Func(func(r *scopeRequest, shared *initType) *scopeHandler {
+				return &scopeHandler{Request: r, Shared: shared}
+			}),
+		)
+		if err := s.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return s
+	}
+
+	first, second := newScope("first"), newScope("second")
+	defer first.Clean()
+	defer second.Clean()
+	if first.Kind() != "request" || first.Parent() != i {
+		t.Errorf("Expected request scope of the root injector, got %v", first.Kind())
+	}
+
+	var fh, sh *scopeHandler
+	if err := first.InjectAs(&fh); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := second.InjectAs(&sh); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if fh.Request.ID != "first" || sh.Request.ID != "second" {
+		t.Errorf("Expected scoped requests, got %v and %v", fh.Request.ID, sh.Request.ID)
+	}
+	if fh.Shared != sh.Shared {
+		t.Error("Expected the parent instance to be shared by the scopes")
+	}
+
+	var shared *initType
+	if err := first.InjectAs(&shared); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if shared != fh.Shared {
+		t.Error("Expected the parent instance to be injected from the scope")
+	}
+
+	var r *scopeRequest
+	if err := i.InjectAs(&r); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+	}
+}
+
+func TestScopedTo(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(&initType{}),
+		ScopedTo("request", Func(func(shared *initType) *scopeRequest { return &scopeRequest{ID: "scoped"} })),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var r *scopeRequest
+	err := i.InjectAs(&r)
+	if !errors.Is(err, ErrProviderNotFound) || !strings.Contains(err.Error(), `is scoped to: "request"`) {
+		t.Errorf("Expected scoped provider error, got %v", err)
+	}
+
+	first, second := i.NewScope("request"), i.NewScope("request")
+	var fr, sr *scopeRequest
+	for _, s := range []struct {
+		scope *Injector
+		r     **scopeRequest
+	}{{first, &fr}, {second, &sr}} {
+		if err := s.scope.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := s.scope.InjectAs(s.r); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+	}
+	if fr == sr {
+		t.Error("Expected each scope to construct its own instance")
+	}
+
+	job := i.NewScope("job")
+	if err := job.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := job.InjectAs(&r); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+	}
+
+	t.Run("Dependency", func(t *testing.T) {
+		i := New()
+		i.Provide(
+			ScopedTo("request", Func(func() *scopeRequest { return &scopeRequest{} })),
+			Func(func(r *scopeRequest) *scopeHandler { return &scopeHandler{Request: r} }),
+		)
+		if err := i.Resolve(); err == nil || !strings.Contains(err.Error(), `is scoped to: "request"`) {
+			t.Errorf("Expected scoped dependency error, got %v", err)
+		}
+	})
+
+	t.Run("Not a function", func(t *testing.T) {
+		for name, p := range map[string]Provider{
+			"value":           Value(&scopeRequest{}),
+			"binding":         Bind(new(interfaceType), new(testType)),
+			"interface value": InterfaceValue(new(interfaceType), testType{}),
+		} {
+			i := New()
+			i.Provide(ScopedTo("request", p))
+			if err := i.Resolve(); err == nil || !strings.Contains(err.Error(), name+" declared at:") || !strings.Contains(err.Error(), "scope_test.go") {
+				t.Errorf("Expected the scoped %s error, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestShadowing(t *testing.T) {
+	var events []Event
+	i := New(WithEventHandler(func(e Event) { events = append(events, e) }))
+	i.Provide(Func(func() *initType { return &initType{} }))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	s := i.NewScope("request")
+	s.Provide(Value(&initType{}))
+	if err := s.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected %v, got %v", 1, len(events))
+	}
+	if e := events[0]; e.Kind != EventShadowed || e.Scope != "request" || e.Type != reflect.TypeOf(&initType{}) || e.Provider != "value" || !strings.Contains(e.Shadowed, "TestShadowing") {
+		t.Errorf("Expected shadowed event, got %v", e)
+	}
+
+	strict := i.NewScope("request", WithStrictShadowing())
+	strict.Provide(Value(&initType{}))
+	if err := strict.Resolve(); err == nil || !strings.Contains(err.Error(), "shadows the provider") {
+		t.Errorf("Expected shadowing error, got %v", err)
+	}
+}
+
+func TestScopeContext(t *testing.T) {
+	type key struct{}
+	i := New()
+	if err := i.ResolveContext(context.WithValue(context.Background(), key{}, "parent")); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	s := i.NewScope("session")
+	if err := s.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	r := i.NewScope("request")
+	if err := r.ResolveContext(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var sctx, rctx context.Context
+	if err := s.InjectAs(&sctx); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := r.InjectAs(&rctx); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if v := sctx.Value(key{}); v != "parent" {
+		t.Errorf("Expected %v, got %v", "parent", v)
+	}
+
+	i.Clean()
+	for _, ctx := range []context.Context{sctx, rctx} {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Error("Expected the scope context canceled along with the parent")
+		}
+	}
+}
+
+func TestScopeLazyParent(t *testing.T) {
+	var calls int32
+	i := New()
+	i.Provide(Func(func() *initType {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &initType{}
+	}))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var wg sync.WaitGroup
+	shared := make([]*initType, 8)
+	for j := range shared {
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			s := i.NewScope("request")
+			s.Provide(Func(func(shared *initType) *scopeHandler { return &scopeHandler{Shared: shared} }))
+			if err := s.Resolve(); err != nil {
+				t.Error("Expected no error, got", err)
+				return
+			}
+			defer s.Clean()
+			var h *scopeHandler
+			if err := s.InjectAs(&h); err != nil {
+				t.Error("Expected no error, got", err)
+				return
+			}
+			shared[j] = h.Shared
+		}(j)
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("Expected %v, got %v", 1, calls)
+	}
+	for _, s := range shared {
+		if s != shared[0] {
+			t.Error("Expected the parent instance to be shared by the concurrent scopes")
+		}
+	}
+}
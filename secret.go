@@ -0,0 +1,211 @@
+package wireless
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// redacted replaces the secret values in all the human readable outputs.
+const redacted = "[REDACTED]"
+
+// maxRedactDepth limits the depth of the values formatted by Redact, which also guards against reference cycles.
+const maxRedactDepth = 16
+
+// Secret wraps the value which must not be revealed in the debug outputs and logs.
+// It is injected as any other type, while it is formatted, marshaled and logged as '[REDACTED]'.
+// It is decoded from the environment variables and the config files as the wrapped type.
+// Example:
+//
+//	type DBConfig struct {
+//		DSN      string
+//		Password wireless.Secret[string] `env:"PASSWORD,required"`
+//	}
+type Secret[T any] struct {
+	value T
+}
+
+// NewSecret wraps the value as a secret.
+func NewSecret[T any](value T) Secret[T] {
+	return Secret[T]{value: value}
+}
+
+// Value returns the wrapped secret value.
+func (s Secret[T]) Value() T {
+	return s.value
+}
+
+// String returns the redacted value.
+func (s Secret[T]) String() string {
+	return redacted
+}
+
+// GoString returns the redacted value.
+func (s Secret[T]) GoString() string {
+	return redacted
+}
+
+// Format writes the redacted value regardless of the verb.
+func (s Secret[T]) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, redacted)
+}
+
+// LogValue implements slog.LogValuer.
+func (s Secret[T]) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redacted)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (s Secret[T]) MarshalYAML() (interface{}, error) {
+	return redacted, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Secret[T]) UnmarshalText(text []byte) error {
+	return setString(reflect.ValueOf(&s.value).Elem(), string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Secret[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.value)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler of the gopkg.in/yaml.v2, also supported by the gopkg.in/yaml.v3,
+// so that the root module does not depend on the YAML package.
+func (s *Secret[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshal(&s.value)
+}
+
+func (s Secret[T]) secret() {}
+
+var secretType = reflect.TypeOf(new(interface{ secret() })).Elem()
+
+// Redact formats the value similarly to the '%+v' verb, but with the Secret values and the struct fields tagged
+// with 'wireless:"secret"' replaced with '[REDACTED]'.
+func Redact(v interface{}) string {
+	var sb strings.Builder
+	writeRedacted(&sb, reflect.ValueOf(v), 0)
+	return sb.String()
+}
+
+// writeRedacted writes the redacted value into the builder.
+func writeRedacted(sb *strings.Builder, rv reflect.Value, depth int) {
+	if !rv.IsValid() {
+		sb.WriteString("<nil>")
+		return
+	}
+	if rv.Type().Implements(secretType) {
+		sb.WriteString(redacted)
+		return
+	}
+	if rv.Kind() != reflect.Ptr || !rv.IsNil() {
+		if s, ok := interfaceOf(rv).(fmt.Stringer); ok {
+			sb.WriteString(s.String())
+			return
+		}
+	}
+	if depth > maxRedactDepth {
+		sb.WriteString("...")
+		return
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			sb.WriteString("<nil>")
+			return
+		}
+		if rv.Kind() == reflect.Ptr {
+			sb.WriteByte('&')
+		}
+		writeRedacted(sb, rv.Elem(), depth+1)
+	case reflect.Struct:
+		sb.WriteByte('{')
+		for j := 0; j < rv.NumField(); j++ {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			ft := rv.Type().Field(j)
+			sb.WriteString(ft.Name)
+			sb.WriteByte(':')
+			if parseTag(ft.Tag).secret {
+				sb.WriteString(redacted)
+				continue
+			}
+			writeRedacted(sb, rv.Field(j), depth+1)
+		}
+		sb.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprint(sb, rv)
+			return
+		}
+		sb.WriteByte('[')
+		for j := 0; j < rv.Len(); j++ {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			writeRedacted(sb, rv.Index(j), depth+1)
+		}
+		sb.WriteByte(']')
+	case reflect.Map:
+		entries := make([]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			var eb strings.Builder
+			writeRedacted(&eb, iter.Key(), depth+1)
+			eb.WriteByte(':')
+			writeRedacted(&eb, iter.Value(), depth+1)
+			entries = append(entries, eb.String())
+		}
+		sort.Strings(entries)
+		sb.WriteString("map[")
+		sb.WriteString(strings.Join(entries, " "))
+		sb.WriteByte(']')
+	default:
+		fmt.Fprint(sb, rv)
+	}
+}
+
+// interfaceOf returns the interface value of the reflected value, or nil if it is obtained from the unexported field.
+func interfaceOf(rv reflect.Value) interface{} {
+	if !rv.CanInterface() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// DebugString returns the human readable description of the injector values and the provided instances,
+// with the secrets redacted. Instances of the providers which have not been executed are described as such.
+func (i *Injector) DebugString() string {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	var lines []string
+	for t, v := range i.values {
+		if isBuiltin(t) {
+			continue
+		}
+		lines = append(lines, t.String()+": "+Redact(v.Interface()))
+	}
+	for t, pf := range i.providersMap {
+		if pf.pool != nil {
+			lines = append(lines, t.String()+": <pooled> ("+pf.name()+")")
+			continue
+		}
+		if !pf.outValue.IsValid() {
+			lines = append(lines, t.String()+": <not provided> ("+pf.name()+")")
+			continue
+		}
+		lines = append(lines, t.String()+": "+Redact(pf.outValue.Interface())+" ("+pf.name()+")")
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,83 @@
+package wireless
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type secretConfig struct {
+	User     string
+	Password Secret[string] `env:"PASSWORD"`
+	Token    string         `wireless:"secret"`
+}
+
+func TestSecret(t *testing.T) {
+	t.Run("Format", func(t *testing.T) {
+		c := secretConfig{User: "admin", Password: NewSecret("pass"), Token: "token"}
+		for _, s := range []string{fmt.Sprint(c.Password), fmt.Sprintf("%+v", c), fmt.Sprintf("%#v", c), fmt.Sprintf("%d", c.Password)} {
+			if strings.Contains(s, "pass") {
+				t.Errorf("Expected redacted secret, got %v", s)
+			}
+		}
+		b, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if strings.Contains(string(b), "pass") {
+			t.Errorf("Expected redacted secret, got %v", string(b))
+		}
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		c := &secretConfig{User: "admin", Password: NewSecret("pass"), Token: "token"}
+		expected := "&{User:admin Password:[REDACTED] Token:[REDACTED]}"
+		if s := Redact(c); s != expected {
+			t.Errorf("Expected %v, got %v", expected, s)
+		}
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		var c secretConfig
+		if err := json.Unmarshal([]byte(`{"Password":"pass"}`), &c); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if c.Password.Value() != "pass" {
+			t.Errorf("Expected %v, got %v", "pass", c.Password.Value())
+		}
+
+		t.Setenv("SECRET_PASSWORD", "env-pass")
+		i := New()
+		i.Provide(Env[secretConfig]("SECRET"))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var ec *secretConfig
+		if err := i.InjectAs(&ec); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if ec.Password.Value() != "env-pass" {
+			t.Errorf("Expected %v, got %v", "env-pass", ec.Password.Value())
+		}
+	})
+
+	t.Run("DebugString", func(t *testing.T) {
+		i := New()
+		i.Provide(Value(NewSecret("pass")), Value(&secretConfig{User: "admin", Token: "token"}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var s Secret[string]
+		if err := i.InjectAs(&s); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if s.Value() != "pass" {
+			t.Errorf("Expected %v, got %v", "pass", s.Value())
+		}
+		ds := i.DebugString()
+		if strings.Contains(ds, "pass") || strings.Contains(ds, "token") || !strings.Contains(ds, "admin") {
+			t.Errorf("Expected redacted debug string, got %v", ds)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ScopeKey is the key of the session scope created with Scope. It is provided to the session scope, so that
+// its providers might depend on the key.
+type ScopeKey struct {
+	Key interface{}
+}
+
+var scopeKeyType = reflect.TypeOf(ScopeKey{})
+
+// Scope returns the child scope of the ScopeSession kind for the comparable key, e.g. the tenant ID, creating and
+// resolving it on the first call. The session scope lives until it is evicted with Evict or its parent is cleaned,
+// so that the providers scoped to the ScopeSession kind construct a single instance per key.
+// If the session scope fails to resolve, it is cleaned and not kept, and the resolution error is returned.
+// Example:
+//
+//	i.Provide(wireless.ScopedTo(wireless.ScopeSession, wireless.Func(func(k wireless.ScopeKey) *Producer {
+//		return NewProducer(k.Key.(string))
+//	})))
+//	...
+//	s, err := i.Scope(tenantID)
+//	if err != nil { ... }
+//	var p *Producer
+//	err = s.InjectAs(&p)
+func (i *Injector) Scope(key interface{}) (*Injector, error) {
+	i.sessionsLock.Lock()
+	if s, ok := i.sessions[key]; ok {
+		i.sessionsLock.Unlock()
+		return s, nil
+	}
+	s := i.NewScope(ScopeSession)
+	s.Provide(Value(ScopeKey{Key: key}))
+	if err := s.Resolve(); err != nil {
+		i.sessionsLock.Unlock()
+		// The cleaned scope forgets itself in the sessions, thus it is cleaned without the lock.
+		s.Clean()
+		return nil, fmt.Errorf("resolving session scope of the key: %v failed: %w", key, err)
+	}
+	if i.sessions == nil {
+		i.sessions = map[interface{}]*Injector{}
+	}
+	i.sessions[key] = s
+	i.sessionsLock.Unlock()
+	return s, nil
+}
+
+// Evict closes the session scope of the key created with Scope and forgets it, so that the next call of Scope
+// creates a new one. It returns the errors of closing the scope, or nil if there is no scope of the key.
+func (i *Injector) Evict(key interface{}) error {
+	i.sessionsLock.Lock()
+	s, ok := i.sessions[key]
+	delete(i.sessions, key)
+	i.sessionsLock.Unlock()
+	if !ok {
+		return nil
+	}
+	return s.Close()
+}
+
+// forgetSession removes the cleaned session scope, e.g. by CleanScope, so that the next call of Scope creates
+// a new one instead of returning the cleaned scope.
+func (i *Injector) forgetSession(s *Injector) {
+	i.sessionsLock.Lock()
+	defer i.sessionsLock.Unlock()
+	for k, ss := range i.sessions {
+		if ss == s {
+			delete(i.sessions, k)
+			return
+		}
+	}
+}
+
+// closeSessions closes all the session scopes, returning the errors of closing them.
+func (i *Injector) closeSessions() multiError {
+	i.sessionsLock.Lock()
+	sessions := i.sessions
+	i.sessions = nil
+	i.sessionsLock.Unlock()
+	var errs multiError
+	for _, s := range sessions {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,83 @@
+package wireless
+
+import (
+	"strings"
+	"testing"
+)
+
+type sessionProducer struct {
+	Tenant string
+	closed bool
+}
+
+func TestSessionScope(t *testing.T) {
+	var created []*sessionProducer
+	i := New()
+	i.Provide(ScopedTo(ScopeSession, Func(func(k ScopeKey) (*sessionProducer, func()) {
+		p := &sessionProducer{Tenant: k.Key.(string)}
+		created = append(created, p)
+		return p, func() { p.closed = true }
+	})))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	inject := func(tenant string) *sessionProducer {
+		s, err := i.Scope(tenant)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var p *sessionProducer
+		if err := s.InjectAs(&p); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return p
+	}
+	first, second := inject("first"), inject("second")
+	if first.Tenant != "first" || second.Tenant != "second" {
+		t.Errorf("Expected per tenant producers, got %v and %v", first.Tenant, second.Tenant)
+	}
+	if inject("first") != first {
+		t.Error("Expected the same producer of the tenant")
+	}
+	if s, _ := i.Scope("first"); s.Kind() != ScopeSession {
+		t.Errorf("Expected %v, got %v", ScopeSession, s.Kind())
+	}
+
+	if err := i.Evict("first"); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if !first.closed {
+		t.Error("Expected the evicted producer to be cleaned")
+	}
+	if again := inject("first"); again == first {
+		t.Error("Expected a new producer after the eviction")
+	}
+	if err := i.Evict("unknown"); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	i.Clean()
+	for _, p := range created[1:] {
+		if !p.closed {
+			t.Errorf("Expected the producer of: %v to be cleaned with the parent", p.Tenant)
+		}
+	}
+}
+
+func TestSessionScopeFailure(t *testing.T) {
+	i := New()
+	i.Provide(ScopedTo(ScopeSession, Func(func(k ScopeKey, _ *testType) *sessionProducer { return &sessionProducer{} })))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	for j := 0; j < 3; j++ {
+		s, err := i.Scope("first")
+		if s != nil || err == nil || !strings.Contains(err.Error(), "no provider found for the *wireless.testType") {
+			t.Errorf("Expected the missing provider error, got %v", err)
+		}
+	}
+	if scopes := i.ActiveScopes(); len(scopes) != 0 {
+		t.Errorf("Expected the failed session scopes to be cleaned, got %v", scopes)
+	}
+}
@@ -0,0 +1,66 @@
+package wireless
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Setters is implemented by the types that define explicitly which of their methods are used to inject
+// the dependencies by Inject. Each of the methods needs to take a single dependency argument and optionally return
+// an error.
+type Setters interface {
+	WirelessSetters() []string
+}
+
+// WithSetterInjection makes Inject call all the exported 'Set<Dep>(dep)' methods of the injected value with their
+// dependencies, after its fields are injected. Types implementing Setters have their listed methods called
+// regardless of this option.
+func WithSetterInjection() Option {
+	return func(i *Injector) {
+		i.setterInjection = true
+	}
+}
+
+// injectSetters calls the setter methods of the injected value.
+func (i *Injector) injectSetters(ctx context.Context, rv reflect.Value) error {
+	var names []string
+	if s, ok := rv.Interface().(Setters); ok {
+		names = s.WirelessSetters()
+	} else if i.setterInjection {
+		for j := 0; j < rv.NumMethod(); j++ {
+			if name := rv.Type().Method(j).Name; strings.HasPrefix(name, "Set") && isSetter(rv.Method(j).Type()) {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, name := range names {
+		m := rv.MethodByName(name)
+		if !m.IsValid() || !isSetter(m.Type()) {
+			return fmt.Errorf("setter %s of %s is not a method taking single argument and optionally returning an error", name, rv.Type())
+		}
+		dep := reflect.New(m.Type().In(0))
+		if err := i.injectAs(ctx, dep); err != nil {
+			return fmt.Errorf("setter %s of %s: %w", name, rv.Type(), err)
+		}
+		outs := m.Call([]reflect.Value{dep.Elem()})
+		if len(outs) == 1 && !outs[0].IsNil() {
+			return fmt.Errorf("setter %s of %s failed: %w", name, rv.Type(), outs[0].Interface().(error))
+		}
+	}
+	return nil
+}
+
+func isSetter(mt reflect.Type) bool {
+	if mt.NumIn() != 1 || mt.IsVariadic() {
+		return false
+	}
+	switch mt.NumOut() {
+	case 0:
+		return true
+	case 1:
+		return mt.Out(0) == errorType
+	}
+	return false
+}
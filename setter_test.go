@@ -0,0 +1,57 @@
+package wireless
+
+import "testing"
+
+type legacyType struct {
+	ptr    *testType
+	number int
+}
+
+func (l *legacyType) SetPtr(ptr *testType) { l.ptr = ptr }
+
+func (l *legacyType) SetNumber(n int) error {
+	l.number = n
+	return nil
+}
+
+type explicitSetters struct {
+	legacyType
+}
+
+func (e *explicitSetters) WirelessSetters() []string { return []string{"SetPtr"} }
+
+func TestSetterInjection(t *testing.T) {
+	ptr := &testType{v: "setter"}
+
+	i := New(WithSetterInjection())
+	i.Provide(Value(ptr), Value(42))
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var l legacyType
+	err = i.Inject(&l)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if l.ptr != ptr || l.number != 42 {
+		t.Errorf("Expected setters called, got %+v", l)
+	}
+
+	i = New()
+	i.Provide(Value(ptr))
+	err = i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var e explicitSetters
+	err = i.Inject(&e)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if e.ptr != ptr || e.number != 0 {
+		t.Errorf("Expected only listed setters called, got %+v", e)
+	}
+}
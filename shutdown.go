@@ -0,0 +1,37 @@
+package wireless
+
+import (
+	"context"
+	"reflect"
+)
+
+// OnShutdown registers the teardown function of the resource created outside of the provider functions. It is
+// executed by Clean along with the cleanup functions of the provider functions, in reverse order to which they
+// were registered, so it runs before the cleanups of the already constructed values it might depend on.
+// The function gets the injector context without its cancellation, and its error is returned by Close and reported
+// with the EventCleanupFailed to the handler registered with WithEventHandler.
+// Example:
+//
+//	conn := dialLate(db)
+//	err := i.OnShutdown(func(ctx context.Context) error { return conn.Close() })
+func (i *Injector) OnShutdown(fn func(ctx context.Context) error) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.cleaned {
+		return ErrAlreadyCleaned
+	}
+	depth, depths := 0, map[*providerFunc]int{}
+	for _, p := range i.providerFuncs {
+		depth = maxInt(depth, cleanupDepth(p, depths))
+	}
+	pf := &providerFunc{id: i.nextID(), value: reflect.ValueOf(fn), out: reflect.TypeOf(fn), depth: depth + 1}
+	pf.cleanups = []reflect.Value{reflect.ValueOf(func() {
+		if err := fn(context.WithoutCancel(i.context())); err != nil {
+			i.addCleanErrors(err)
+			i.emit(Event{Kind: EventCleanupFailed, Type: pf.out, Provider: pf.name(), Err: err})
+		}
+	})}
+	i.providerFuncs = append(i.providerFuncs, pf)
+	return nil
+}
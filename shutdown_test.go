@@ -0,0 +1,160 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnShutdown(t *testing.T) {
+	var calls []string
+	var events []Event
+	closeErr := errors.New("close failed")
+	i := New(WithEventHandler(func(e Event) { events = append(events, e) }))
+	i.Provide(Func(func() (*testType, func()) {
+		return &testType{}, func() { calls = append(calls, "provider") }
+	}))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var tt *testType
+	if err := i.InjectAs(&tt); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	err := i.OnShutdown(func(ctx context.Context) error {
+		if ctx.Err() != nil {
+			t.Error("Expected not canceled context, got", ctx.Err())
+		}
+		calls = append(calls, "late")
+		return closeErr
+	})
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	i.Clean()
+	expected := []string{"late", "provider"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, calls)
+	}
+	if len(events) != 1 || events[0].Kind != EventCleanupFailed || !errors.Is(events[0].Err, closeErr) {
+		t.Errorf("Expected cleanup failed event, got %v", events)
+	}
+	if err = i.OnShutdown(func(ctx context.Context) error { return nil }); !errors.Is(err, ErrAlreadyCleaned) {
+		t.Errorf("Expected %v, got %v", ErrAlreadyCleaned, err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	stopErr := errors.New("stop failed")
+	closeErr := errors.New("close failed")
+	i := New()
+	i.Provide(Func(func(lc *Lifecycle) (*testType, func()) {
+		lc.Append(Hook{Name: "server", OnStop: func(ctx context.Context) error { return stopErr }})
+		return &testType{}, func() { panic("double close") }
+	}))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var tt *testType
+	if err := i.InjectAs(&tt); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.Start(context.Background()); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.OnShutdown(func(ctx context.Context) error { return closeErr }); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var c io.Closer = i
+	err := c.Close()
+	var pe *PanicError
+	if !errors.Is(err, stopErr) || !errors.Is(err, closeErr) || !errors.As(err, &pe) {
+		t.Errorf("Expected stop, shutdown and panic errors, got %v", err)
+	}
+	if err = c.Close(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var stopped, cleanedLive atomic.Bool
+		i := New()
+		i.Provide(Func(func(lc *Lifecycle) (*testType, func()) {
+			lc.AppendRunner(RunnerFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				time.Sleep(10 * time.Millisecond)
+				stopped.Store(true)
+				return nil
+			}))
+			return &testType{}, func() { cleanedLive.Store(!stopped.Load()) }
+		}))
+		if err := i.ResolveContext(ctx); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		cancel()
+		if err := i.Close(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if !stopped.Load() || cleanedLive.Load() {
+			t.Error("Expected the runner to stop before the cleanup")
+		}
+	})
+}
+
+func TestDrain(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var cleaned bool
+	i := New()
+	i.Provide(
+		Func(func() (*testType, func()) {
+			close(started)
+			<-release
+			return &testType{v: "built"}, func() { cleaned = true }
+		}),
+		Value(&initType{}),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	injected := make(chan error, 1)
+	var tt *testType
+	go func() { injected <- i.InjectAs(&tt) }()
+	<-started
+	closed := make(chan error, 1)
+	go func() { closed <- i.Close() }()
+	for i.State() != StateShuttingDown {
+		time.Sleep(time.Millisecond)
+	}
+
+	var it *initType
+	if err := i.InjectAs(&it); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Expected %v, got %v", ErrShuttingDown, err)
+	}
+	close(release)
+	if err := <-injected; err != nil || tt.v != "built" {
+		t.Errorf("Expected the in-flight injection to finish, got %v", err)
+	}
+	if err := <-closed; err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if !cleaned {
+		t.Error("Expected the value of the in-flight injection to be cleaned")
+	}
+	if err := i.InjectAs(&it); !errors.Is(err, ErrAlreadyCleaned) {
+		t.Errorf("Expected %v, got %v", ErrAlreadyCleaned, err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package sqlwireless provides the database/sql module constructing the *sql.DB managed by wireless.Injector.
+package sqlwireless
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/routercore/wireless"
+)
+
+// DefaultPingTimeout is the timeout of the ping on start used if the Config does not define one.
+const DefaultPingTimeout = 5 * time.Second
+
+// Config is the configuration of the database connection pool.
+type Config struct {
+	Driver          string
+	DSN             wireless.Secret[string]
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	PingTimeout     time.Duration
+}
+
+// Module declares the *sql.DB provider requiring the injected *Config, and its health check.
+// The database is pinged when the injector is started and closed when the injector is cleaned.
+// Example:
+//
+//	i.Provide(wireless.Env[sqlwireless.Config]("DB"), sqlwireless.Module())
+func Module() wireless.ProviderSet {
+	return wireless.NewSet(
+		wireless.Func(Open),
+		wireless.Group(wireless.HealthGroup, wireless.Func(HealthCheck)),
+	)
+}
+
+// Open opens the database with the config and appends the lifecycle hook pinging it on start.
+// The returned cleanup function closes the database.
+func Open(c *Config, lc *wireless.Lifecycle) (*sql.DB, func(), error) {
+	db, err := sql.Open(c.Driver, c.DSN.Value())
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening database with driver: %s failed: %w", c.Driver, err)
+	}
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+
+	timeout := c.PingTimeout
+	if timeout <= 0 {
+		timeout = DefaultPingTimeout
+	}
+	lc.Append(wireless.Hook{
+		OnStart: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := db.PingContext(ctx); err != nil {
+				return fmt.Errorf("pinging database with driver: %s failed: %w", c.Driver, err)
+			}
+			return nil
+		},
+	})
+	return db, func() { _ = db.Close() }, nil
+}
+
+// HealthCheck creates the health check pinging the database.
+func HealthCheck(db *sql.DB) wireless.HealthCheck {
+	return wireless.HealthCheck{Name: "sql", Check: db.PingContext}
+}
@@ -0,0 +1,75 @@
+package sqlwireless
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+var errUnavailable = errors.New("database unavailable")
+
+type testDriver struct{}
+
+func (testDriver) Open(name string) (driver.Conn, error) {
+	if name != "available" {
+		return nil, errUnavailable
+	}
+	return testConn{}, nil
+}
+
+type testConn struct{}
+
+func (testConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (testConn) Close() error                        { return nil }
+func (testConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("sqlwireless-test", testDriver{})
+}
+
+func TestModule(t *testing.T) {
+	t.Run("Available", func(t *testing.T) {
+		i := wireless.New()
+		i.Provide(wireless.Value(&Config{Driver: "sqlwireless-test", DSN: wireless.NewSecret("available")}), Module())
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var db *sql.DB
+		if err := i.InjectAs(&db); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err := i.CheckHealth(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		i.Clean()
+		if err := db.Ping(); err == nil {
+			t.Error("Expected closed database error, got nil")
+		}
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		i := wireless.New()
+		i.Provide(wireless.Value(&Config{Driver: "sqlwireless-test", DSN: wireless.NewSecret("unavailable")}), Module())
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		defer i.Clean()
+		var db *sql.DB
+		if err := i.InjectAs(&db); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); !errors.Is(err, errUnavailable) {
+			t.Errorf("Expected %v, got %v", errUnavailable, err)
+		}
+		if err := i.CheckHealth(context.Background()); !errors.Is(err, errUnavailable) {
+			t.Errorf("Expected %v, got %v", errUnavailable, err)
+		}
+	})
+}
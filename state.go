@@ -0,0 +1,235 @@
+package wireless
+
+import (
+	"reflect"
+	"sort"
+)
+
+// State is the phase of the injector lifecycle.
+type State int
+
+// States of the injector returned by State.
+const (
+	// StateNew is the state of the injector which is not resolved yet.
+	StateNew State = iota
+	// StateResolved is the state of the resolved injector.
+	StateResolved
+	// StateStarted is the state of the resolved injector with its lifecycle hooks started.
+	StateStarted
+	// StateCleaned is the state of the cleaned injector.
+	StateCleaned
+	// StateShuttingDown is the state of the injector being cleaned or closed, refusing the new injections.
+	StateShuttingDown
+)
+
+// String implements fmt.Stringer interface.
+func (s State) String() string {
+	switch s {
+	case StateNew:
+		return "new"
+	case StateResolved:
+		return "resolved"
+	case StateStarted:
+		return "started"
+	case StateCleaned:
+		return "cleaned"
+	case StateShuttingDown:
+		return "shutting down"
+	}
+	return "unknown"
+}
+
+// State returns the current phase of the injector.
+func (i *Injector) State() State {
+	if i.closing.Load() {
+		return StateShuttingDown
+	}
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	switch {
+	case i.cleaned:
+		return StateCleaned
+	case !i.resolved:
+		return StateNew
+	}
+	l := i.lifecycle
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.group != nil {
+		return StateStarted
+	}
+	return StateResolved
+}
+
+// ProviderState is the state of the value of the provider.
+type ProviderState int
+
+// States of the provider values described by ProviderStatus.
+const (
+	// ProviderPending is the state of the provider function which was not executed yet, or whose weak instance
+	// was dropped.
+	ProviderPending ProviderState = iota
+	// ProviderConstructed is the state of the provider whose value is constructed.
+	ProviderConstructed
+	// ProviderFailed is the state of the provider function whose last execution failed.
+	ProviderFailed
+	// ProviderPooled is the state of the pooled provider function, whose instances are constructed on demand.
+	ProviderPooled
+)
+
+// String implements fmt.Stringer interface.
+func (s ProviderState) String() string {
+	switch s {
+	case ProviderPending:
+		return "pending"
+	case ProviderConstructed:
+		return "constructed"
+	case ProviderFailed:
+		return "failed"
+	case ProviderPooled:
+		return "pooled"
+	}
+	return "unknown"
+}
+
+// ProviderStatus describes the state of the resolved provider.
+type ProviderStatus struct {
+	Type  reflect.Type
+	Group string
+	Name  string
+	// Provider is the name of the provider function, or 'value' for the values.
+	Provider string
+	State    ProviderState
+	// Err is the error of the last failed execution of the provider function.
+	Err error
+	// Generation is the number of times the value was replaced, see IsCurrent.
+	Generation uint64
+	// Dependencies are the input types of the provider function.
+	Dependencies []reflect.Type
+}
+
+// ProviderStatuses returns the states of the values and provider functions of the resolved injector, with
+// the values ordered by their type and the provider functions by their registration.
+func (i *Injector) ProviderStatuses() []ProviderStatus {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	var statuses []ProviderStatus
+	for _, t := range sortedTypes(i.values) {
+		if isBuiltin(t) {
+			continue
+		}
+		statuses = append(statuses, ProviderStatus{Type: t, Provider: "value", State: ProviderConstructed, Generation: i.generations[t]})
+	}
+	type member struct {
+		p           *providerFunc
+		group, name string
+	}
+	var members []member
+	for _, p := range i.providersMap {
+		members = append(members, member{p: p})
+	}
+	for group, ps := range i.groups {
+		for _, p := range ps {
+			members = append(members, member{p: p, group: group})
+		}
+	}
+	for k, p := range i.named {
+		members = append(members, member{p: p, name: k.name})
+	}
+	sort.Slice(members, func(j, k int) bool {
+		return members[j].p.id < members[k].p.id
+	})
+	for _, m := range members {
+		s := m.p.status(m.group, m.name)
+		if m.group == "" && m.name == "" {
+			s.Generation = i.generations[m.p.out]
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// status returns the status of the provider function, which is the member of the group or named provider
+// if any of them is set.
+func (p *providerFunc) status(group, name string) ProviderStatus {
+	p.stateLock.RLock()
+	err, constructed := p.err, p.outValue.IsValid()
+	p.stateLock.RUnlock()
+	s := ProviderStatus{Type: p.out, Group: group, Name: name, Provider: p.name(), Err: err}
+	if len(p.inTypes) > 0 {
+		s.Dependencies = append([]reflect.Type(nil), p.inTypes...)
+	}
+	switch {
+	case p.pool != nil:
+		s.State = ProviderPooled
+	case constructed:
+		s.State = ProviderConstructed
+	case err != nil:
+		s.State = ProviderFailed
+	}
+	return s
+}
+
+// CleanupOrder returns the statuses of the constructed provider functions and of the teardown functions registered
+// with OnShutdown in the order in which Clean executes their cleanup functions. The dependents precede their
+// dependencies, and the providers of the same depth in the dependency graph are ordered by their registration in
+// reverse, regardless of the order and the goroutines in which they were lazily constructed.
+func (i *Injector) CleanupOrder() []ProviderStatus {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	groups, names := map[*providerFunc]string{}, map[*providerFunc]string{}
+	for group, ps := range i.groups {
+		for _, p := range ps {
+			groups[p] = group
+		}
+	}
+	for k, p := range i.named {
+		names[p] = k.name
+	}
+	var statuses []ProviderStatus
+	for _, p := range i.cleanupOrder() {
+		statuses = append(statuses, p.status(groups[p], names[p]))
+	}
+	return statuses
+}
+
+// cleanupOrder returns the constructed provider functions in reverse topological order, with the ties broken
+// by their ids in reverse.
+func (i *Injector) cleanupOrder() []*providerFunc {
+	i.funcsLock.Lock()
+	order := append([]*providerFunc(nil), i.providerFuncs...)
+	i.funcsLock.Unlock()
+	depths := map[*providerFunc]int{}
+	for _, p := range order {
+		cleanupDepth(p, depths)
+	}
+	sort.Slice(order, func(j, k int) bool {
+		if depths[order[j]] != depths[order[k]] {
+			return depths[order[j]] > depths[order[k]]
+		}
+		return order[j].id > order[k].id
+	})
+	return order
+}
+
+// cleanupDepth returns the depth of the provider function in the dependency graph, including the implementations
+// selected by the BindFunc selectors, which are only known once they are injected.
+func cleanupDepth(p *providerFunc, depths map[*providerFunc]int) int {
+	if d, ok := depths[p]; ok {
+		return d
+	}
+	// The provisional depth guards against the cycles.
+	depths[p] = p.depth
+	deps := p.dependencies
+	if s := p.selected.Load(); s != nil {
+		deps = append(deps[:len(deps):len(deps)], s)
+	}
+	d := p.depth
+	for _, dep := range deps {
+		d = maxInt(d, cleanupDepth(dep, depths)+1)
+	}
+	depths[p] = d
+	return d
+}
@@ -0,0 +1,160 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	buildErr := errors.New("build failed")
+	i := New()
+	i.Provide(
+		Value(1),
+		Func(func() *testType { return &testType{} }),
+		Func(func() (*lifecycleType, error) { return nil, buildErr }),
+		Named("primary", Func(func() string { return "primary" })),
+		Group("handlers", Value(HealthCheck{Name: "ok"})),
+	)
+	if s := i.State(); s != StateNew {
+		t.Errorf("Expected %v, got %v", StateNew, s)
+	}
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if s := i.State(); s != StateResolved {
+		t.Errorf("Expected %v, got %v", StateResolved, s)
+	}
+
+	var tt *testType
+	if err := i.InjectAs(&tt); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	var lc *lifecycleType
+	if err := i.InjectAs(&lc); !errors.Is(err, buildErr) {
+		t.Errorf("Expected %v, got %v", buildErr, err)
+	}
+
+	var states []string
+	for _, s := range i.ProviderStatuses() {
+		states = append(states, s.Type.String()+" "+s.Group+s.Name+" "+s.State.String())
+	}
+	expected := []string{
+		"int  constructed",
+		"*wireless.testType  constructed",
+		"*wireless.lifecycleType  failed",
+		"wireless.HealthCheck handlers constructed",
+		"string primary pending",
+	}
+	if len(states) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, states)
+	}
+	for j := range expected {
+		if states[j] != expected[j] {
+			t.Errorf("Expected %v, got %v", expected[j], states[j])
+		}
+	}
+
+	if err := i.Start(context.Background()); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if s := i.State(); s != StateStarted {
+		t.Errorf("Expected %v, got %v", StateStarted, s)
+	}
+	if err := i.Close(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if s := i.State(); s != StateCleaned {
+		t.Errorf("Expected %v, got %v", StateCleaned, s)
+	}
+}
+
+func TestProviderStatusesConcurrent(t *testing.T) {
+	i := New()
+	i.Provide(
+		Func(func() *testType { return &testType{} }),
+		Func(func() (*initType, error) { return nil, errors.New("build failed") }),
+	)
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	var wg sync.WaitGroup
+	for j := 0; j < 4; j++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			var tt *testType
+			_ = i.InjectAs(&tt)
+			var it *initType
+			_ = i.InjectAs(&it)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = i.ProviderStatuses()
+			_ = i.CleanupOrder()
+		}()
+	}
+	wg.Wait()
+}
+
+type (
+	orderA struct{}
+	orderB struct{}
+	orderC struct{}
+	orderD struct{}
+)
+
+func TestCleanupOrder(t *testing.T) {
+	for run := 0; run < 10; run++ {
+		var (
+			lock    sync.Mutex
+			cleaned []string
+		)
+		cleanup := func(name string) func() {
+			return func() {
+				lock.Lock()
+				defer lock.Unlock()
+				cleaned = append(cleaned, name)
+			}
+		}
+		i := New()
+		i.Provide(
+			Func(func(*orderB) (*orderC, func()) { return &orderC{}, cleanup("c") }),
+			Func(func(*orderA) (*orderD, func()) { return &orderD{}, cleanup("d") }),
+			Func(func(*orderA) (*orderB, func()) { return &orderB{}, cleanup("b") }),
+			Func(func() (*orderA, func()) { return &orderA{}, cleanup("a") }),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var wg sync.WaitGroup
+		for _, as := range []interface{}{new(*orderD), new(*orderC), new(*orderB), new(*orderA)} {
+			wg.Add(1)
+			go func(as interface{}) {
+				defer wg.Done()
+				if err := i.InjectAs(as); err != nil {
+					t.Error("Expected no error, got", err)
+				}
+			}(as)
+		}
+		wg.Wait()
+
+		var order []reflect.Type
+		for _, s := range i.CleanupOrder() {
+			order = append(order, s.Type)
+		}
+		expected := []reflect.Type{
+			reflect.TypeOf(&orderC{}), reflect.TypeOf(&orderB{}), reflect.TypeOf(&orderD{}), reflect.TypeOf(&orderA{}),
+		}
+		if !reflect.DeepEqual(order, expected) {
+			t.Errorf("Expected %v, got %v", expected, order)
+		}
+		i.Clean()
+		if strings.Join(cleaned, "") != "cbda" {
+			t.Errorf("Expected %v, got %v", "cbda", cleaned)
+		}
+	}
+}
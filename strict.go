@@ -0,0 +1,65 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithStrictPrimitives makes the injector refuse the unnamed providers and dependencies of the predeclared basic
+// types, like string or int. Such values need to be registered with Named, or use defined types instead,
+// e.g. 'type Port int', so that the values of the same basic type never collide.
+func WithStrictPrimitives() Option {
+	return func(i *Injector) {
+		i.strictPrimitives = true
+	}
+}
+
+// checkPrimitives verifies the providers and their dependencies in the strict primitives mode.
+func (i *Injector) checkPrimitives() {
+	if !i.strictPrimitives {
+		return
+	}
+	for _, t := range sortedTypes(i.values) {
+		if isPrimitive(t) {
+			i.errors = append(i.errors, fmt.Errorf("value of the basic type: %s needs to be named or use a defined type", t))
+		}
+	}
+	for _, t := range sortedTypes(i.providersMap) {
+		if isPrimitive(t) {
+			i.errors = append(i.errors, fmt.Errorf("provider: %s of the basic type: %s needs to be named or use a defined type", i.providersMap[t].name(), t))
+		}
+	}
+	for _, p := range i.allProviders() {
+		i.checkPrimitiveDependencies(p)
+	}
+}
+
+func (i *Injector) checkPrimitiveDependencies(p *providerFunc) {
+	for _, in := range p.inTypes {
+		if isPrimitive(in) {
+			i.errors = append(i.errors, fmt.Errorf("provider: %s depends on the basic type: %s which needs to be a defined type", p.name(), in))
+		}
+	}
+	for _, d := range p.decorators {
+		for _, in := range d.inTypes[1:] {
+			if isPrimitive(in) {
+				i.errors = append(i.errors, fmt.Errorf("decorator: %s depends on the basic type: %s which needs to be a defined type", d.name(), in))
+			}
+		}
+	}
+}
+
+// isPrimitive checks if the type is one of the predeclared basic types.
+func isPrimitive(t reflect.Type) bool {
+	if t.PkgPath() != "" || t.Name() == "" {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package wireless
+
+import (
+	"strings"
+	"testing"
+)
+
+type port int
+
+func TestStrictPrimitives(t *testing.T) {
+	t.Run("Unnamed", func(t *testing.T) {
+		i := New(WithStrictPrimitives())
+		i.Provide(Value("dsn"))
+		if err := i.Resolve(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("Dependency", func(t *testing.T) {
+		i := New(WithStrictPrimitives())
+		i.Provide(
+			Named("dsn", Value("dsn")),
+			Func(func(dsn string) *testType { return &testType{v: dsn} }),
+		)
+		if err := i.Resolve(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		i := New(WithStrictPrimitives())
+		i.Provide(
+			Named("dsn", Value("dsn")),
+			Value(port(8080)),
+		)
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var s struct {
+			DSN  string `wireless:"name=dsn"`
+			Port port
+		}
+		err = i.Inject(&s)
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if s.DSN != "dsn" || s.Port != 8080 {
+			t.Errorf("Expected named and defined values injected, got %+v", s)
+		}
+	})
+}
+
+func TestConvertible(t *testing.T) {
+	i := New()
+	i.Provide(
+		Func(func() int { return 8080 }),
+		Convertible(new(port), new(int)),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var p port
+	err = i.InjectAs(&p)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if p != 8080 {
+		t.Errorf("Expected %v, got %v", 8080, p)
+	}
+
+	i = New()
+	i.Provide(Value(8080), Convertible(new(string), new(int)))
+	if err = i.Resolve(); err == nil || !strings.Contains(err.Error(), "int -> string") {
+		t.Errorf("Expected the conversion error from int to string, got %v", err)
+	}
+}
+
+func TestDeterministicErrors(t *testing.T) {
+	var expected string
+	for j := 0; j < 20; j++ {
+		i := New(WithStrictPrimitives())
+		i.Provide(
+			Value(1),
+			Value("text"),
+			Value(true),
+			Value(1.5),
+			Func(func(uint) *testType { return &testType{} }),
+			Func(func(int8) *lifecycleType { return nil }),
+		)
+		err := i.Resolve()
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if j == 0 {
+			expected = err.Error()
+			continue
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected %v, got %v", expected, err)
+		}
+	}
+}
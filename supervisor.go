@@ -0,0 +1,96 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// RestartMode defines when the supervised Runner is restarted.
+type RestartMode int
+
+// Restart modes of the RestartPolicy.
+const (
+	// RestartNever never restarts the Runner.
+	RestartNever RestartMode = iota
+	// RestartOnFailure restarts the Runner when it returns an error other than context.Canceled.
+	RestartOnFailure
+	// RestartAlways restarts the Runner whenever it returns, unless it is stopped.
+	RestartAlways
+)
+
+// RestartPolicy is the policy of restarting the supervised Runner.
+type RestartPolicy struct {
+	Mode RestartMode
+	// Backoff is the delay before the first restart, doubled after each restart up to the MaxBackoff.
+	// Zero means the default delay of 100ms, so that the failing Runner is not restarted in a busy loop.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// MaxRestarts limits the number of restarts. Zero means unlimited restarts.
+	MaxRestarts int
+}
+
+// defaultRestartBackoff is the delay before the first restart of the RestartPolicy without the Backoff.
+const defaultRestartBackoff = 100 * time.Millisecond
+
+// AppendSupervisedRunner adds the hook running the Runner, same as AppendRunner, which is restarted according to
+// the policy until the injector is stopped. Each restart is reported with the EventRestart to the handler
+// registered with WithEventHandler. Once the restarts are exhausted, the last error of the Runner fails the group
+// of the started hooks and Runners.
+// Example:
+//
+//	lc.AppendSupervisedRunner(consumer, wireless.RestartPolicy{
+//		Mode:        wireless.RestartOnFailure,
+//		Backoff:     time.Second,
+//		MaxBackoff:  time.Minute,
+//		MaxRestarts: 10,
+//	})
+func (l *Lifecycle) AppendSupervisedRunner(r Runner, p RestartPolicy) {
+	name := fmt.Sprintf("%T", r)
+	l.appendRunner(name, &supervisor{name: name, runner: r, policy: p, emit: l.emit})
+}
+
+// supervisor is the Runner restarting the supervised Runner according to its policy.
+type supervisor struct {
+	name   string
+	runner Runner
+	policy RestartPolicy
+	emit   func(e Event)
+}
+
+// Run implements Runner.
+func (s *supervisor) Run(ctx context.Context) error {
+	backoff := s.policy.Backoff
+	if backoff <= 0 {
+		backoff = defaultRestartBackoff
+	}
+	for restarts := 0; ; restarts++ {
+		err := s.runner.Run(ctx)
+		if ctx.Err() != nil {
+			return err
+		}
+		failed := err != nil && !errors.Is(err, context.Canceled)
+		if s.policy.Mode == RestartNever || (s.policy.Mode == RestartOnFailure && !failed) {
+			return err
+		}
+		if s.policy.MaxRestarts > 0 && restarts >= s.policy.MaxRestarts {
+			return err
+		}
+		if s.emit != nil {
+			s.emit(Event{Kind: EventRestart, Type: reflect.TypeOf(s.runner), Provider: s.name, Attempt: restarts + 1, Err: err})
+		}
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+		backoff *= 2
+		if s.policy.MaxBackoff > 0 && backoff > s.policy.MaxBackoff {
+			backoff = s.policy.MaxBackoff
+		}
+	}
+}
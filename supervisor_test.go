@@ -0,0 +1,102 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAppendSupervisedRunner(t *testing.T) {
+	runErr := errors.New("connection reset")
+	start := func(t *testing.T, policy RestartPolicy, run func(ctx context.Context) error) (*Injector, *atomic.Int32) {
+		var restarts atomic.Int32
+		i := New(WithEventHandler(func(e Event) {
+			if e.Kind == EventRestart {
+				restarts.Add(1)
+			}
+		}))
+		i.Provide(Func(func(lc *Lifecycle) *lifecycleType {
+			lc.AppendSupervisedRunner(RunnerFunc(run), policy)
+			return &lifecycleType{}
+		}))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var lt *lifecycleType
+		if err := i.InjectAs(&lt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return i, &restarts
+	}
+
+	t.Run("OnFailure", func(t *testing.T) {
+		var runs atomic.Int32
+		i, restarts := start(t, RestartPolicy{Mode: RestartOnFailure, Backoff: time.Millisecond, MaxRestarts: 2}, func(ctx context.Context) error {
+			runs.Add(1)
+			return runErr
+		})
+		if err := i.Wait(context.Background()); !errors.Is(err, runErr) {
+			t.Errorf("Expected %v, got %v", runErr, err)
+		}
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if runs.Load() != 3 || restarts.Load() != 2 {
+			t.Errorf("Expected %v runs and %v restarts, got %v and %v", 3, 2, runs.Load(), restarts.Load())
+		}
+	})
+
+	t.Run("Always", func(t *testing.T) {
+		var runs atomic.Int32
+		i, _ := start(t, RestartPolicy{Mode: RestartAlways, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}, func(ctx context.Context) error {
+			if runs.Add(1) < 3 {
+				return nil
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		for runs.Load() < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+	})
+
+	t.Run("Default backoff", func(t *testing.T) {
+		var runs atomic.Int32
+		i, _ := start(t, RestartPolicy{Mode: RestartAlways}, func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+		time.Sleep(defaultRestartBackoff / 2)
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if runs.Load() != 1 {
+			t.Errorf("Expected %v run before the default backoff, got %v", 1, runs.Load())
+		}
+	})
+
+	t.Run("Never", func(t *testing.T) {
+		var runs atomic.Int32
+		i, restarts := start(t, RestartPolicy{Mode: RestartNever}, func(ctx context.Context) error {
+			runs.Add(1)
+			return runErr
+		})
+		if err := i.Wait(context.Background()); !errors.Is(err, runErr) {
+			t.Errorf("Expected %v, got %v", runErr, err)
+		}
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if runs.Load() != 1 || restarts.Load() != 0 {
+			t.Errorf("Expected %v run and no restarts, got %v and %v", 1, runs.Load(), restarts.Load())
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Swap replaces the value of the input value type with the input value for all the subsequent injections.
+// The type needs to be provided either directly as a value or by the provider function. Values that were already
+// injected into their dependents are not replaced, thus Swap is meant for the types injected on demand, like the
+// configuration reloaded at runtime. The value replacing the provider value is decorated and post processed
+// the same way as the provided one. Cleanups of the replaced provider value are still executed on Clean.
+// The functions registered with Watch are notified of the new value, and the generation of the type is increased,
+// see IsCurrent.
+func (i *Injector) Swap(v interface{}) error {
+	if err := i.swap(v); err != nil {
+		return err
+	}
+	i.notify(reflect.TypeOf(v), reflect.ValueOf(v))
+	return nil
+}
+
+func (i *Injector) swap(v interface{}) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.resolved {
+		return ErrNotResolved
+	}
+	if i.cleaned {
+		return ErrAlreadyCleaned
+	}
+	if v == nil {
+		return errors.New("swapped value is nil")
+	}
+	rv := reflect.ValueOf(v)
+	t := rv.Type()
+	if _, ok := i.values[t]; ok {
+		i.values[t] = rv
+		i.nextGeneration(t)
+		return nil
+	}
+	pf, ok := i.providersMap[t]
+	if !ok {
+		return notFoundError{t: t}
+	}
+	out, err := i.processSwapped(pf, rv)
+	if err != nil {
+		return err
+	}
+	pf.stateLock.Lock()
+	pf.outValue = out
+	pf.stateLock.Unlock()
+	i.nextGeneration(t)
+	return nil
+}
+
+// processSwapped applies the decorators and post processors of the provider function to the swapped value.
+// The cleanups of the decorators are added to the cleanups of the replaced value, or executed on failure.
+func (i *Injector) processSwapped(pf *providerFunc, rv reflect.Value) (reflect.Value, error) {
+	ctx := i.context()
+	for _, dep := range pf.dependencies {
+		if err := i.executeNecessaryProviders(ctx, dep); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	pf.cleanLock.Lock()
+	old := pf.cleanups
+	pf.cleanups = nil
+	pf.cleanLock.Unlock()
+	out, err := i.process(context.WithoutCancel(ctx), pf, rv)
+	pf.cleanLock.Lock()
+	pf.cleanups = append(old, pf.cleanups...)
+	pf.cleanLock.Unlock()
+	return out, err
+}
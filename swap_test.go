@@ -0,0 +1,74 @@
+package wireless
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSwap(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(&testType{v: "value"}),
+		Func(func() testType { return testType{v: "func"} }),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	if err = i.Swap(&testType{v: "swapped value"}); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if err = i.Swap(testType{v: "swapped func"}); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if err = i.Swap(42); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("Expected %v, got %v", ErrProviderNotFound, err)
+	}
+
+	var s struct {
+		Ptr  *testType
+		Func testType
+	}
+	err = i.Inject(&s)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if s.Ptr.v != "swapped value" || s.Func.v != "swapped func" {
+		t.Errorf("Expected swapped values, got %+v", s)
+	}
+}
+
+func TestSwapDecorated(t *testing.T) {
+	i := New()
+	i.Provide(
+		Func(func() testType { return testType{v: "func"} }),
+		Decorate(func(tt testType) testType { return testType{v: tt.v + "-decorated"} }),
+		PostProcess(func(t reflect.Type, v interface{}) (interface{}, error) {
+			if tt, ok := v.(testType); ok {
+				return testType{v: tt.v + "-processed"}, nil
+			}
+			return v, nil
+		}),
+	)
+	err := i.Resolve()
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	if err = i.Swap(testType{v: "swapped"}); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var s struct {
+		Func testType
+	}
+	err = i.Inject(&s)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if expected := "swapped-decorated-processed"; s.Func.v != expected {
+		t.Errorf("Expected %v, got %v", expected, s.Func.v)
+	}
+}
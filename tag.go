@@ -0,0 +1,56 @@
+package wireless
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagName is the struct field tag key used by the injector.
+const tagName = "wireless"
+
+// fieldTag is the parsed 'wireless' struct field tag.
+type fieldTag struct {
+	skip     bool
+	dive     bool
+	inject   bool
+	optional bool
+	group    string
+	name     string
+	named    bool
+	secret   bool
+	config   bool
+}
+
+// parseTag parses the comma separated, optionally key=value, 'wireless' struct field tag options.
+func parseTag(tag reflect.StructTag) fieldTag {
+	var ft fieldTag
+	tv, ok := tag.Lookup(tagName)
+	if !ok {
+		return ft
+	}
+	for _, opt := range strings.Split(tv, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "-":
+			ft.skip = true
+		case "dive":
+			ft.dive = true
+		case "inject":
+			ft.inject = true
+		case "optional":
+			ft.optional = true
+		case "group":
+			ft.group = value
+		case "name":
+			ft.name = value
+		case "named":
+			ft.named = true
+		case "secret":
+			ft.secret = true
+		case "config":
+			// The config marker field is never injected.
+			ft.config, ft.skip = true, true
+		}
+	}
+	return ft
+}
@@ -0,0 +1,124 @@
+package wireless
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// TTL makes the instance of the provider function expire after the given duration. The expired instance is
+// replaced in the background by a newly constructed one, while the cleanup functions of the old instance are
+// executed. The functions registered with Watch are notified of the new instance. If the construction fails,
+// the old instance is kept until the next refresh and the EventRefreshFailed is emitted. Same as with Swap,
+// the dependents constructed before the refresh keep the old instance, thus the TTL providers are meant to be
+// injected on demand or watched.
+// Example:
+//
+//	wireless.TTL(5*time.Minute, wireless.Func(NewAccessToken))
+func TTL(ttl time.Duration, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) { o.ttl = ttl })
+	return p
+}
+
+// ttlState is the refresh state of the TTL provider.
+type ttlState struct {
+	ttl   time.Duration
+	lock  sync.Mutex
+	timer *time.Timer
+}
+
+// newTTLState returns the refresh state of the TTL provider, or nil.
+func newTTLState(o providerOptions) *ttlState {
+	if o.ttl <= 0 {
+		return nil
+	}
+	return &ttlState{ttl: o.ttl}
+}
+
+// stop stops the refresh timer.
+func (s *ttlState) stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// scheduleRefresh starts the refresh timer of the constructed TTL provider instance.
+func (i *Injector) scheduleRefresh(p *providerFunc) {
+	s := p.ttl
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.timer != nil {
+		s.timer.Reset(s.ttl)
+		return
+	}
+	s.timer = time.AfterFunc(s.ttl, func() { i.refresh(p) })
+}
+
+// refresh constructs the new instance of the TTL provider and cleans the old one.
+func (i *Injector) refresh(p *providerFunc) {
+	out, err := i.reconstruct(p)
+	if err != nil {
+		i.emit(Event{Kind: EventRefreshFailed, Type: p.out, Provider: p.name(), Err: err})
+		return
+	}
+	if out.IsValid() {
+		i.emit(Event{Kind: EventRefreshed, Type: p.out, Provider: p.name()})
+		i.notify(p.out, out)
+	}
+}
+
+// reconstruct replaces the instance of the provider. The new instance is constructed with the injector read lock
+// held, so that the injections are not blocked by the construction and get the old instance meanwhile, while it is
+// swapped for the old instance with the injector lock held. It returns an invalid value if there is no instance
+// to replace, or if the old instance was released, dropped or swapped in the meantime, in which case the new
+// instance is cleaned instead.
+func (i *Injector) reconstruct(p *providerFunc) (reflect.Value, error) {
+	i.lock.RLock()
+	if i.cleaned || !p.outValue.IsValid() {
+		i.lock.RUnlock()
+		return reflect.Value{}, nil
+	}
+	generation := i.generations[p.out]
+
+	// The cleanups of the new instance are kept apart until it is installed, so that the old instance is cleaned
+	// by the release in the meantime.
+	p.cleanLock.Lock()
+	old := p.cleanups
+	p.cleanups = nil
+	p.cleanLock.Unlock()
+	out, err := i.construct(i.context(), p)
+	p.cleanLock.Lock()
+	fresh := p.cleanups
+	p.cleanups = old
+	p.cleanLock.Unlock()
+	i.lock.RUnlock()
+	if err != nil {
+		i.scheduleRefresh(p)
+		return reflect.Value{}, err
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.cleaned || i.generations[p.out] != generation || !p.outValue.IsValid() {
+		// The old instance is already cleaned, so the new one is discarded.
+		i.runCleanups(p, fresh)
+		return reflect.Value{}, nil
+	}
+	p.cleanLock.Lock()
+	old = p.cleanups
+	p.cleanups = fresh
+	p.cleanLock.Unlock()
+	i.runCleanups(p, old)
+	p.stateLock.Lock()
+	p.outValue = out
+	p.stateLock.Unlock()
+	i.nextGeneration(p.out)
+	i.scheduleRefresh(p)
+	return out, nil
+}
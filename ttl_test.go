@@ -0,0 +1,192 @@
+package wireless
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type ttlToken struct {
+	n      int
+	closed atomic.Bool
+}
+
+func TestTTL(t *testing.T) {
+	t.Run("Refresh", func(t *testing.T) {
+		var constructed int
+		cleaned := make(chan int, 10)
+		i := New()
+		i.Provide(TTL(10*time.Millisecond, Func(func() (*ttlToken, func()) {
+			constructed++
+			n := constructed
+			return &ttlToken{n: n}, func() { cleaned <- n }
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		refreshed := make(chan *ttlToken, 10)
+		stop := Watch(i, func(tk *ttlToken) { refreshed <- tk })
+		defer stop()
+
+		var tk *ttlToken
+		if err := i.InjectAs(&tk); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		select {
+		case tk = <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("Expected refreshed token")
+		}
+		if tk.n != 2 {
+			t.Errorf("Expected %v, got %v", 2, tk.n)
+		}
+		if n := <-cleaned; n != 1 {
+			t.Errorf("Expected %v, got %v", 1, n)
+		}
+		i.Clean()
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		var constructed int
+		failed := make(chan Event, 10)
+		i := New(WithEventHandler(func(e Event) {
+			if e.Kind == EventRefreshFailed {
+				failed <- e
+			}
+		}))
+		i.Provide(TTL(10*time.Millisecond, Func(func() (*ttlToken, error) {
+			constructed++
+			if constructed > 1 {
+				return nil, errors.New("token endpoint unavailable")
+			}
+			return &ttlToken{n: constructed}, nil
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		defer i.Clean()
+
+		var tk *ttlToken
+		if err := i.InjectAs(&tk); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		select {
+		case e := <-failed:
+			if e.Err == nil {
+				t.Error("Expected refresh error, got nil")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected refresh failure event")
+		}
+		if err := i.InjectAs(&tk); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if tk.n != 1 {
+			t.Errorf("Expected %v, got %v", 1, tk.n)
+		}
+	})
+
+	t.Run("Injections during refresh", func(t *testing.T) {
+		var constructed int
+		building, release := make(chan struct{}), make(chan struct{})
+		i := New()
+		i.Provide(
+			Value(&testType{v: "value"}),
+			TTL(10*time.Millisecond, Func(func() *ttlToken {
+				constructed++
+				if constructed == 2 {
+					close(building)
+					<-release
+				}
+				return &ttlToken{n: constructed}
+			})),
+		)
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		defer i.Clean()
+		refreshed := make(chan *ttlToken, 10)
+		stop := Watch(i, func(tk *ttlToken) { refreshed <- tk })
+		defer stop()
+
+		var tk *ttlToken
+		if err := i.InjectAs(&tk); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		<-building
+		// The injections are not blocked by the refresh in progress and get the old instance.
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if err := i.InjectAs(&tk); err != nil || tk.n != 1 {
+			t.Errorf("Expected %v, got %v, %v", 1, tk, err)
+		}
+		close(release)
+		select {
+		case tk = <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("Expected refreshed token")
+		}
+		if tk.n != 2 {
+			t.Errorf("Expected %v, got %v", 2, tk.n)
+		}
+	})
+	t.Run("Release during refresh", func(t *testing.T) {
+		var constructed, cleaned atomic.Int64
+		built := make(chan struct{}, 1)
+		i := New()
+		i.Provide(TTL(time.Hour, Func(func() (*ttlToken, func()) {
+			tk := &ttlToken{n: int(constructed.Add(1))}
+			select {
+			case built <- struct{}{}:
+				// The release is given the time to wait for the lock held by the refresh.
+				time.Sleep(time.Millisecond)
+			default:
+			}
+			return tk, func() {
+				if tk.closed.Swap(true) {
+					t.Errorf("Expected token: %d to be cleaned once", tk.n)
+				}
+				cleaned.Add(1)
+			}
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		p := i.providersMap[reflect.TypeOf(new(ttlToken))]
+		for j := 0; j < 50; j++ {
+			var tk *ttlToken
+			if err := i.InjectAs(&tk); err != nil {
+				t.Fatal("Expected no error, got", err)
+			}
+			if tk.closed.Load() {
+				t.Fatalf("Expected token: %d not to be cleaned", tk.n)
+			}
+			select {
+			case <-built:
+			default:
+			}
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				i.refresh(p)
+			}()
+			go func() {
+				defer wg.Done()
+				// The release waits for the new instance to be constructed.
+				<-built
+				_ = i.Release(new(*ttlToken))
+			}()
+			wg.Wait()
+		}
+		i.Clean()
+		if constructed.Load() != cleaned.Load() {
+			t.Errorf("Expected %v, got %v", constructed.Load(), cleaned.Load())
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package wireless
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// WithComplexityLimits enables the graph lint, reported by Validate, of the provider functions with more than
+// maxDependencies direct dependencies, and of the types which are direct dependencies of more than maxFanIn
+// provider functions. The non positive limit disables the respective check.
+func WithComplexityLimits(maxDependencies, maxFanIn int) Option {
+	return func(i *Injector) {
+		i.maxDependencies, i.maxFanIn = maxDependencies, maxFanIn
+	}
+}
+
+// Validate verifies the resolved injector graph and returns all the problems found, including the results
+// of the enabled graph lints. It returns nil if the graph is valid.
+func (i *Injector) Validate() error {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if !i.resolved {
+		return ErrNotResolved
+	}
+	if len(i.errors) > 0 {
+		return i.errors
+	}
+	var errs multiError
+	errs = append(errs, i.lintComplexity()...)
+	errs = append(errs, i.lintPolicies()...)
+	errs = append(errs, i.lintLayers()...)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// lintComplexity reports the providers exceeding the complexity limits.
+func (i *Injector) lintComplexity() []error {
+	if i.maxDependencies <= 0 && i.maxFanIn <= 0 {
+		return nil
+	}
+	var (
+		errs  []error
+		fanIn = map[reflect.Type]int{}
+	)
+	for _, p := range i.allProviders() {
+		deps := 0
+		for j, in := range p.inTypes {
+			if j == 0 && in == contextType {
+				continue
+			}
+			deps++
+			fanIn[in]++
+		}
+		if i.maxDependencies > 0 && deps > i.maxDependencies {
+			errs = append(errs, fmt.Errorf("provider: %s of type: %s has %d direct dependencies, more than the limit of %d", p.name(), p.out, deps, i.maxDependencies))
+		}
+	}
+	if i.maxFanIn > 0 {
+		types := make([]reflect.Type, 0, len(fanIn))
+		for t, n := range fanIn {
+			if n > i.maxFanIn {
+				types = append(types, t)
+			}
+		}
+		sort.Slice(types, func(j, k int) bool { return types[j].String() < types[k].String() })
+		for _, t := range types {
+			errs = append(errs, fmt.Errorf("type: %s is a direct dependency of %d providers, more than the limit of %d", t, fanIn[t], i.maxFanIn))
+		}
+	}
+	return errs
+}
+
+// allProviders returns the registered, group member and named provider functions ordered by their registration.
+func (i *Injector) allProviders() []*providerFunc {
+	providers := make([]*providerFunc, 0, len(i.providersMap))
+	for _, p := range i.providersMap {
+		providers = append(providers, p)
+	}
+	for _, members := range i.groups {
+		providers = append(providers, members...)
+	}
+	for _, p := range i.named {
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(j, k int) bool {
+		return providers[j].id < providers[k].id
+	})
+	return providers
+}
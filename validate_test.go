@@ -0,0 +1,53 @@
+package wireless
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type (
+	lintA struct{}
+	lintB struct{}
+	lintC struct{}
+)
+
+func TestValidate(t *testing.T) {
+	newInjector := func(options ...Option) *Injector {
+		i := New(options...)
+		i.Provide(
+			Value(&lintA{}),
+			Func(func(a *lintA) *lintB { return &lintB{} }),
+			Func(func(a *lintA, b *lintB) *lintC { return &lintC{} }),
+		)
+		return i
+	}
+
+	i := newInjector()
+	if err := i.Validate(); !errors.Is(err, ErrNotResolved) {
+		t.Errorf("Expected %v, got %v", ErrNotResolved, err)
+	}
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.Validate(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	i = newInjector(WithComplexityLimits(1, 1))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	err := i.Validate()
+	if err == nil {
+		t.Fatal("Expected complexity errors, got nil")
+	}
+	for _, expected := range []string{
+		"of type: *wireless.lintC has 2 direct dependencies, more than the limit of 1",
+		"type: *wireless.lintA is a direct dependency of 2 providers, more than the limit of 1",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q in %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package wireless
+
+import "reflect"
+
+// Validator is the interface used to validate the values before they are injected.
+// An adapter for the github.com/go-playground/validator is provided in the validatorwireless package.
+type Validator interface {
+	Validate(v interface{}) error
+}
+
+// ValidatorFunc is the function implementing the Validator interface.
+type ValidatorFunc func(v interface{}) error
+
+// Validate implements Validator interface.
+func (f ValidatorFunc) Validate(v interface{}) error {
+	return f(v)
+}
+
+// WithValidator sets up the validator for all the values provided directly and constructed by the provider functions.
+// Provided values are validated on Resolve, while constructed ones right after the construction.
+func WithValidator(v Validator) Option {
+	return func(i *Injector) {
+		i.validator = v
+	}
+}
+
+func (i *Injector) validate(v reflect.Value) error {
+	if i.validator == nil || !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return i.validator.Validate(v.Interface())
+}
@@ -0,0 +1,37 @@
+package wireless
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	v := ValidatorFunc(func(v interface{}) error {
+		if tt, ok := v.(testType); ok && tt.v == "" {
+			return errors.New("field v is required")
+		}
+		return nil
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		i := New(WithValidator(v))
+		i.Provide(Value(testType{}))
+		if err := i.Resolve(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("Func", func(t *testing.T) {
+		i := New(WithValidator(v))
+		i.Provide(Func(func() testType { return testType{} }))
+		err := i.Resolve()
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var tt testType
+		if err = i.InjectAs(&tt); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,20 @@
+module github.com/routercore/wireless/validatorwireless
+
+go 1.26.0
+
+replace github.com/routercore/wireless => ../
+
+require (
+	github.com/go-playground/validator/v10 v10.30.5
+	github.com/routercore/wireless v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.15 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/leodido/go-urn v1.5.0 // indirect
+	golang.org/x/crypto v0.57.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+)
@@ -0,0 +1,38 @@
+// Package validatorwireless provides the wireless.Validator backed by the github.com/go-playground/validator.
+package validatorwireless
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/routercore/wireless"
+)
+
+// New creates a new wireless.Validator validating struct values with given validator.
+// If the input validator is nil, the default one is created.
+func New(v *validator.Validate) wireless.Validator {
+	if v == nil {
+		v = validator.New(validator.WithRequiredStructEnabled())
+	}
+	return &structValidator{v: v}
+}
+
+type structValidator struct {
+	v *validator.Validate
+}
+
+// Validate implements wireless.Validator interface. Values that are not structs or pointers to structs are skipped.
+// The returned error is validator.ValidationErrors with field level details.
+func (s *structValidator) Validate(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return s.v.Struct(v)
+}
@@ -0,0 +1,27 @@
+package validatorwireless
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/routercore/wireless"
+)
+
+type config struct {
+	Addr string `validate:"required"`
+}
+
+func TestValidate(t *testing.T) {
+	i := wireless.New(wireless.WithValidator(New(nil)))
+	i.Provide(wireless.Value(&config{}))
+
+	err := i.Resolve()
+	var ve validator.ValidationErrors
+	if !errors.As(err, &ve) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+	if ve[0].Field() != "Addr" {
+		t.Errorf("Expected %v, got %v", "Addr", ve[0].Field())
+	}
+}
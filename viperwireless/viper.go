@@ -0,0 +1,76 @@
+// Package viperwireless binds the sections of the github.com/spf13/viper configuration to the typed config structs
+// provided by wireless.Injector.
+package viperwireless
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/routercore/wireless"
+	"github.com/spf13/viper"
+)
+
+// Binder binds the viper configuration sections to the config structs.
+type Binder struct {
+	v         *viper.Viper
+	lock      sync.Mutex
+	reloaders []func(i *wireless.Injector) error
+}
+
+// New creates a new Binder of the viper instance.
+func New(v *viper.Viper) *Binder {
+	return &Binder{v: v}
+}
+
+// Section declares the provider of the *T config struct decoded from the viper configuration under given key.
+// An empty key decodes the whole configuration.
+func Section[T any](b *Binder, key string) wireless.Provider {
+	decode := func() (*T, error) {
+		c := new(T)
+		var err error
+		if key == "" {
+			err = b.v.Unmarshal(c)
+		} else {
+			err = b.v.UnmarshalKey(key, c)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding viper section: %q failed: %w", key, err)
+		}
+		return c, nil
+	}
+	b.lock.Lock()
+	b.reloaders = append(b.reloaders, func(i *wireless.Injector) error {
+		c, err := decode()
+		if err != nil {
+			return err
+		}
+		return i.Swap(c)
+	})
+	b.lock.Unlock()
+	return wireless.Func(decode)
+}
+
+// Reload decodes all the sections again and swaps them in the injector.
+func (b *Binder) Reload(i *wireless.Injector) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, r := range b.reloaders {
+		if err := r(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Watch starts watching the viper config file and reloads all the sections on each change.
+// Reload errors are passed to the onError function, if it is not nil. The functions registered with wireless.Watch
+// are notified of the reloaded sections.
+func (b *Binder) Watch(i *wireless.Injector, onError func(error)) {
+	b.v.OnConfigChange(func(fsnotify.Event) {
+		if err := b.Reload(i); err != nil && onError != nil {
+			onError(err)
+		}
+	})
+	b.v.WatchConfig()
+}
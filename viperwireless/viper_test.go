@@ -0,0 +1,43 @@
+package viperwireless
+
+import (
+	"testing"
+
+	"github.com/routercore/wireless"
+	"github.com/spf13/viper"
+)
+
+type dbConfig struct {
+	DSN string
+}
+
+func TestSection(t *testing.T) {
+	v := viper.New()
+	v.Set("db.dsn", "postgres://primary")
+	b := New(v)
+
+	i := wireless.New()
+	i.Provide(Section[dbConfig](b, "db"))
+	if err := i.Resolve(); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+
+	var c *dbConfig
+	if err := i.InjectAs(&c); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if c.DSN != "postgres://primary" {
+		t.Errorf("Expected %v, got %v", "postgres://primary", c.DSN)
+	}
+
+	v.Set("db.dsn", "postgres://replica")
+	if err := b.Reload(i); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := i.InjectAs(&c); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if c.DSN != "postgres://replica" {
+		t.Errorf("Expected %v, got %v", "postgres://replica", c.DSN)
+	}
+}
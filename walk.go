@@ -0,0 +1,73 @@
+package wireless
+
+// Visitor visits the providers declared in the injector.
+type Visitor interface {
+	Visit(info ProviderInfo)
+}
+
+// VisitorFunc is the function implementing the Visitor.
+type VisitorFunc func(info ProviderInfo)
+
+// Visit implements the Visitor interface.
+func (f VisitorFunc) Visit(info ProviderInfo) {
+	f(info)
+}
+
+// Walk visits the providers declared in the injector with Provide, after they are rewritten, in the order of
+// declaration. The Dependencies of the described provider functions and decorators, together with their Type,
+// define the graph the injector resolves. The providers of the ancestor scopes are not visited.
+func (i *Injector) Walk(v Visitor) {
+	for _, p := range i.declarations {
+		v.Visit(Inspect(p)[0])
+	}
+}
+
+// Rewriter substitutes the declared provider described by the ProviderInfo. It returns the provider, or the provider
+// set, to be declared instead, the described Provider to keep it unchanged, or nil to drop it. The substitutes
+// are not rewritten again by the same rewriter, thus they might include the described Provider.
+type Rewriter func(info ProviderInfo) Provider
+
+// WithRewriter rewrites every provider declared with Provide, with the provider sets flattened, before it is
+// registered. It allows the cross-cutting transformations without editing the provider sets, e.g. wrapping
+// every *sql.DB. The rewriters are applied in the order of the options and are inherited by the child scopes.
+// Example:
+//
+//	wireless.WithRewriter(func(info wireless.ProviderInfo) wireless.Provider {
+//		if info.Type != reflect.TypeOf((*sql.DB)(nil)) {
+//			return info.Provider
+//		}
+//		return wireless.NewSet(info.Provider, wireless.Decorate(instrumentDB))
+//	})
+func WithRewriter(r Rewriter) Option {
+	return func(i *Injector) {
+		i.rewriters = append(i.rewriters, r)
+	}
+}
+
+// rewrite applies the rewriters to the provider, returning the flattened substitutes.
+func (i *Injector) rewrite(p Provider) []Provider {
+	providers := []Provider{p}
+	for _, r := range i.rewriters {
+		var rewritten []Provider
+		for _, rp := range providers {
+			if s := r(Inspect(rp)[0]); s != nil {
+				rewritten = append(rewritten, flatten(s)...)
+			}
+		}
+		providers = rewritten
+	}
+	return providers
+}
+
+// flatten returns the providers with the provider sets flattened.
+func flatten(p Provider) []Provider {
+	set, ok := p.(ProviderSet)
+	if !ok {
+		return []Provider{p}
+	}
+	var providers []Provider
+	for _, sp := range set {
+		providers = append(providers, flatten(sp)...)
+	}
+	return providers
+}
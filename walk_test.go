@@ -0,0 +1,103 @@
+package wireless
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	i := New()
+	i.Provide(
+		Value(&initType{}),
+		NewSet(Func(func(it *initType) *testType { return &testType{v: "a"} })),
+		Decorate(func(tt *testType, it *initType) *testType { return tt }),
+	)
+	var infos []ProviderInfo
+	i.Walk(VisitorFunc(func(info ProviderInfo) {
+		infos = append(infos, info)
+	}))
+	if len(infos) != 3 {
+		t.Fatalf("Expected %v, got %v", 3, len(infos))
+	}
+	initT, testT := reflect.TypeOf(&initType{}), reflect.TypeOf(&testType{})
+	expected := []ProviderInfo{
+		{Kind: KindValue, Type: initT},
+		{Kind: KindFunc, Type: testT, Dependencies: []reflect.Type{initT}},
+		{Kind: KindDecorator, Type: testT, Dependencies: []reflect.Type{initT}},
+	}
+	for j, info := range infos {
+		e := expected[j]
+		if info.Kind != e.Kind || info.Type != e.Type || !reflect.DeepEqual(info.Dependencies, e.Dependencies) {
+			t.Errorf("Expected %v, got %v", e, info)
+		}
+	}
+}
+
+func TestRewriter(t *testing.T) {
+	testT := reflect.TypeOf(&testType{})
+	wrap := func(info ProviderInfo) Provider {
+		if info.Kind != KindFunc || info.Type != testT {
+			return info.Provider
+		}
+		return NewSet(info.Provider, Decorate(func(tt *testType) *testType {
+			return &testType{v: tt.v + "-wrapped"}
+		}))
+	}
+
+	t.Run("wrap", func(t *testing.T) {
+		i := New(WithRewriter(wrap))
+		i.Provide(NewSet(Func(func() *testType { return &testType{v: "a"} })))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := i.InjectAs(&tt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if tt.v != "a-wrapped" {
+			t.Errorf("Expected %v, got %v", "a-wrapped", tt.v)
+		}
+		var kinds []ProviderKind
+		i.Walk(VisitorFunc(func(info ProviderInfo) { kinds = append(kinds, info.Kind) }))
+		if !reflect.DeepEqual(kinds, []ProviderKind{KindFunc, KindDecorator}) {
+			t.Errorf("Expected %v, got %v", []ProviderKind{KindFunc, KindDecorator}, kinds)
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		i := New(WithRewriter(func(info ProviderInfo) Provider {
+			if info.Type == testT {
+				return nil
+			}
+			return info.Provider
+		}))
+		i.Provide(Func(func() *testType { return &testType{} }))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := i.InjectAs(&tt); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected the not found error, got %v", err)
+		}
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		i := New(WithRewriter(wrap))
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		s := i.NewScope("request")
+		s.Provide(Func(func() *testType { return &testType{v: "b"} }))
+		if err := s.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var tt *testType
+		if err := s.InjectAs(&tt); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if tt.v != "b-wrapped" {
+			t.Errorf("Expected %v, got %v", "b-wrapped", tt.v)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package wireless
+
+import (
+	"reflect"
+)
+
+// Watch registers the function called with each new value of the type T, replacing the previous one either by Swap,
+// e.g. on the configuration reload, or by the refresh of the TTL provider. The function is called after
+// the injector lock is released, so it might inject other values. It returns the function unregistering it.
+// Example:
+//
+//	stop := wireless.Watch(i, func(t *oauth2.Token) { client.SetToken(t) })
+//	defer stop()
+func Watch[T any](i *Injector, fn func(T)) (stop func()) {
+	t := reflect.TypeOf(new(T)).Elem()
+	w := &watcher{fn: func(v reflect.Value) { fn(v.Convert(t).Interface().(T)) }}
+
+	i.watchLock.Lock()
+	defer i.watchLock.Unlock()
+	if i.watchers == nil {
+		i.watchers = map[reflect.Type][]*watcher{}
+	}
+	i.watchers[t] = append(i.watchers[t], w)
+	return func() {
+		i.watchLock.Lock()
+		defer i.watchLock.Unlock()
+		ws := i.watchers[t]
+		for j := range ws {
+			if ws[j] == w {
+				i.watchers[t] = append(ws[:j:j], ws[j+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// watcher is the function watching the values of a type.
+type watcher struct {
+	fn func(v reflect.Value)
+}
+
+// notify calls the watchers of the type with its new value.
+func (i *Injector) notify(t reflect.Type, v reflect.Value) {
+	i.watchLock.Lock()
+	ws := append([]*watcher(nil), i.watchers[t]...)
+	i.watchLock.Unlock()
+	for _, w := range ws {
+		w.fn(v)
+	}
+}
@@ -0,0 +1,26 @@
+package wireless
+
+import (
+	"testing"
+)
+
+func TestWatch(t *testing.T) {
+	i := New()
+	i.Provide(Value(&testType{v: "value"}))
+	if err := i.Resolve(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+
+	var watched []string
+	stop := Watch(i, func(tt *testType) { watched = append(watched, tt.v) })
+	if err := i.Swap(&testType{v: "first"}); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	stop()
+	if err := i.Swap(&testType{v: "second"}); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+	if len(watched) != 1 || watched[0] != "first" {
+		t.Errorf("Expected %v, got %v", []string{"first"}, watched)
+	}
+}
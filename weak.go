@@ -0,0 +1,107 @@
+package wireless
+
+import (
+	"sync"
+	"time"
+)
+
+// Weak makes the instance of the provider function droppable. The instance is dropped, with its cleanup functions
+// executed, once it was not injected for the idle duration or when Trim is called, e.g. on memory pressure.
+// It is constructed again on the next injection. The zero idle duration disables the idle expiration.
+// The dependents constructed before the instance was dropped keep referencing it, so the weak providers
+// should rather be injected on demand with InjectAs.
+// Example:
+//
+//	wireless.Weak(10*time.Minute, wireless.Func(NewReportCache))
+func Weak(idle time.Duration, p Provider) Provider {
+	p.setOptions(func(o *providerOptions) {
+		o.weak = true
+		o.idle = idle
+	})
+	return p
+}
+
+// Trim drops all the constructed instances of the weak providers and returns their number.
+func (i *Injector) Trim() int {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	var weak []*providerFunc
+	for _, p := range i.providerFuncs {
+		if p.weak != nil {
+			weak = append(weak, p)
+		}
+	}
+	for _, p := range weak {
+		i.dropWeak(p)
+	}
+	return len(weak)
+}
+
+// weakInstance is the idle expiration state of the weak provider.
+type weakInstance struct {
+	idle  time.Duration
+	lock  sync.Mutex
+	timer *time.Timer
+	used  time.Time
+}
+
+// newWeakInstance returns the expiration state of the weak provider, or nil.
+func newWeakInstance(o providerOptions) *weakInstance {
+	if !o.weak {
+		return nil
+	}
+	return &weakInstance{idle: o.idle}
+}
+
+// stop stops the idle expiration timer.
+func (w *weakInstance) stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+}
+
+// touch postpones the idle expiration of the weak provider instance.
+func (i *Injector) touch(p *providerFunc) {
+	w := p.weak
+	if w == nil || w.idle <= 0 {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.used = time.Now()
+	if w.timer != nil {
+		w.timer.Reset(w.idle)
+		return
+	}
+	w.timer = time.AfterFunc(w.idle, func() { i.expireWeak(p) })
+}
+
+// expireWeak drops the weak provider instance, unless it was injected while the expiration timer fired.
+func (i *Injector) expireWeak(p *providerFunc) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	w := p.weak
+	w.lock.Lock()
+	if idle := time.Since(w.used); idle < w.idle && w.timer != nil {
+		w.timer.Reset(w.idle - idle)
+		w.lock.Unlock()
+		return
+	}
+	w.lock.Unlock()
+	i.dropWeak(p)
+}
+
+// dropWeak executes the cleanup functions of the weak provider instance and drops it. It needs to be called
+// with the injector lock held.
+func (i *Injector) dropWeak(p *providerFunc) {
+	p.weak.stop()
+	if i.cleaned || !p.outValue.IsValid() {
+		return
+	}
+	i.drop(p)
+}
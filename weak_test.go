@@ -0,0 +1,71 @@
+package wireless
+
+import (
+	"testing"
+	"time"
+)
+
+type weakCache struct {
+	n int
+}
+
+func TestWeak(t *testing.T) {
+	t.Run("Trim", func(t *testing.T) {
+		var constructed, cleaned int
+		i := New()
+		i.Provide(Weak(0, Func(func() (*weakCache, func()) {
+			constructed++
+			return &weakCache{n: constructed}, func() { cleaned++ }
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+
+		var c *weakCache
+		if err := i.InjectAs(&c); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if n := i.Trim(); n != 1 {
+			t.Errorf("Expected %v dropped instances, got %v", 1, n)
+		}
+		if cleaned != 1 {
+			t.Errorf("Expected %v cleanups, got %v", 1, cleaned)
+		}
+		if err := i.InjectAs(&c); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if c.n != 2 {
+			t.Errorf("Expected %v, got %v", 2, c.n)
+		}
+
+		i.Clean()
+		if cleaned != 2 {
+			t.Errorf("Expected %v cleanups, got %v", 2, cleaned)
+		}
+	})
+
+	t.Run("Idle", func(t *testing.T) {
+		dropped := make(chan struct{}, 1)
+		i := New()
+		i.Provide(Weak(10*time.Millisecond, Func(func() (*weakCache, func()) {
+			return &weakCache{}, func() { dropped <- struct{}{} }
+		})))
+		if err := i.Resolve(); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		defer i.Clean()
+
+		var c *weakCache
+		if err := i.InjectAs(&c); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		select {
+		case <-dropped:
+		case <-time.After(time.Second):
+			t.Error("Expected idle instance to be dropped")
+		}
+		if n := i.Trim(); n != 0 {
+			t.Errorf("Expected %v dropped instances, got %v", 0, n)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+// Package wirelessanalysis provides the static checker of the wireless providers declared in the source code.
+package wirelessanalysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// WirelessPath is the import path of the wireless package.
+const WirelessPath = "github.com/routercore/wireless"
+
+// Analyzer reports the invalid provider function signatures, the bindings of invalid kinds and the dependencies
+// of the provider functions missing in the Provide calls which declare all their providers inline.
+var Analyzer = &analysis.Analyzer{
+	Name:     "wireless",
+	Doc:      "check the wireless provider declarations",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		switch name := calleeName(pass.TypesInfo, call); name {
+		case "Func", "Decorate":
+			checkFunc(pass, call, name)
+		case "Bind", "BindWithConstraint":
+			checkBind(pass, call)
+		case "Value":
+			if len(call.Args) == 1 && pass.TypesInfo.Types[call.Args[0]].IsNil() {
+				pass.Reportf(call.Args[0].Pos(), "value provider is nil")
+			}
+		case "(*Injector).Provide":
+			checkMissing(pass, call)
+		}
+	})
+	return nil, nil
+}
+
+// calleeName returns the name of the called wireless function or method, e.g. 'Func' or '(*Injector).Provide'.
+func calleeName(info *types.Info, call *ast.CallExpr) string {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	case *ast.IndexExpr:
+		return calleeName(info, &ast.CallExpr{Fun: fun.X})
+	default:
+		return ""
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != WirelessPath {
+		return ""
+	}
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		if p, ok := recv.Type().(*types.Pointer); ok {
+			if nt, ok := p.Elem().(*types.Named); ok {
+				return "(*" + nt.Obj().Name() + ")." + fn.Name()
+			}
+		}
+		return ""
+	}
+	return fn.Name()
+}
+
+// providerSignature returns the signature of the provider function argument, reporting if it is invalid.
+func providerSignature(pass *analysis.Pass, arg ast.Expr, kind string) (*types.Signature, bool) {
+	t := pass.TypesInfo.TypeOf(arg)
+	if t == nil {
+		return nil, false
+	}
+	sig, ok := t.Underlying().(*types.Signature)
+	if !ok {
+		pass.Reportf(arg.Pos(), "%s is not a function but: %s", kind, t)
+		return nil, false
+	}
+	return sig, true
+}
+
+// checkFunc reports the provider functions and decorators with invalid signatures.
+func checkFunc(pass *analysis.Pass, call *ast.CallExpr, name string) {
+	if len(call.Args) != 1 {
+		return
+	}
+	kind := "provider"
+	if name == "Decorate" {
+		kind = "decorator"
+	}
+	sig, ok := providerSignature(pass, call.Args[0], kind)
+	if !ok {
+		return
+	}
+	res := sig.Results()
+	switch res.Len() {
+	case 1:
+	case 2:
+		if !isError(res.At(1).Type()) && !isCleanup(res.At(1).Type()) {
+			pass.Reportf(call.Args[0].Pos(), "%s second returned value is neither an error nor a cleanup function but: %s", kind, res.At(1).Type())
+		}
+	case 3:
+		if !isCleanup(res.At(1).Type()) || !isError(res.At(2).Type()) {
+			pass.Reportf(call.Args[0].Pos(), "%s returned values are expected to be the value, the cleanup function and an error", kind)
+		}
+	default:
+		pass.Reportf(call.Args[0].Pos(), "%s has invalid returned variables number: %d", kind, res.Len())
+	}
+	if name == "Decorate" && res.Len() > 0 && (sig.Params().Len() == 0 || !types.Identical(sig.Params().At(0).Type(), res.At(0).Type())) {
+		pass.Reportf(call.Args[0].Pos(), "decorator first argument is expected to be the decorated type: %s", res.At(0).Type())
+	}
+}
+
+// checkBind reports the bindings with arguments not defined with the 'new' statement, or not implementing
+// the bound interface.
+func checkBind(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) < 2 {
+		return
+	}
+	var elems [2]types.Type
+	for j, arg := range call.Args[:2] {
+		if isReflectType(pass.TypesInfo.TypeOf(arg)) {
+			// The types passed as reflect.Type are known only at runtime.
+			return
+		}
+		p, ok := pass.TypesInfo.TypeOf(arg).(*types.Pointer)
+		if !ok {
+			pass.Reportf(arg.Pos(), "binding argument is not defining type with `new` statement: %s", pass.TypesInfo.TypeOf(arg))
+			return
+		}
+		elems[j] = p.Elem()
+	}
+	switch it := elems[0].Underlying().(type) {
+	case *types.Interface:
+		if !types.Implements(elems[1], it) {
+			pass.Reportf(call.Args[1].Pos(), "type: %s does not implement the interface: %s", elems[1], elems[0])
+		}
+	case *types.Signature:
+		if !types.ConvertibleTo(elems[1], elems[0]) {
+			pass.Reportf(call.Args[1].Pos(), "type: %s is not convertible to the function type: %s", elems[1], elems[0])
+		}
+	default:
+		pass.Reportf(call.Args[0].Pos(), "bound type: %s is neither an interface nor a function type", elems[0])
+	}
+}
+
+// isReflectType reports whether the type is the reflect.Type.
+func isReflectType(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == "reflect" && n.Obj().Name() == "Type"
+}
+
+// checkMissing reports the dependencies of the provider functions declared in the Provide call which are not
+// provided by it. The check is skipped if any of the providers is not declared inline, as it might provide
+// anything.
+func checkMissing(pass *analysis.Pass, call *ast.CallExpr) {
+	provided := map[string]bool{}
+	var funcs []ast.Expr
+	for _, arg := range call.Args {
+		pc, ok := ast.Unparen(arg).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		switch calleeName(pass.TypesInfo, pc) {
+		case "Func":
+			sig, ok := pass.TypesInfo.TypeOf(pc.Args[0]).Underlying().(*types.Signature)
+			if !ok || sig.Results().Len() == 0 {
+				return
+			}
+			provided[types.TypeString(sig.Results().At(0).Type(), nil)] = true
+			funcs = append(funcs, pc.Args[0])
+		case "Value":
+			provided[types.TypeString(pass.TypesInfo.TypeOf(pc.Args[0]), nil)] = true
+		case "Bind", "BindWithConstraint", "InterfaceValue":
+			if p, ok := pass.TypesInfo.TypeOf(pc.Args[0]).(*types.Pointer); ok {
+				provided[types.TypeString(p.Elem(), nil)] = true
+			}
+		case "Alias":
+			for _, a := range pc.Args[1:] {
+				if p, ok := pass.TypesInfo.TypeOf(a).(*types.Pointer); ok {
+					provided[types.TypeString(p.Elem(), nil)] = true
+				}
+			}
+		case "Decorate", "PostProcess":
+		default:
+			return
+		}
+	}
+	for _, fn := range funcs {
+		sig := pass.TypesInfo.TypeOf(fn).Underlying().(*types.Signature)
+		for j := 0; j < sig.Params().Len(); j++ {
+			t := sig.Params().At(j).Type()
+			ts := types.TypeString(t, nil)
+			if provided[ts] || isBuiltin(ts) || j == 0 && ts == "context.Context" {
+				continue
+			}
+			pass.Reportf(fn.Pos(), "no provider found for the %s type", types.TypeString(t, types.RelativeTo(pass.Pkg)))
+		}
+	}
+}
+
+// isBuiltin reports whether the type is always provided by the injector.
+func isBuiltin(ts string) bool {
+	switch ts {
+	case "*" + WirelessPath + ".Injector", "*" + WirelessPath + ".Lifecycle", WirelessPath + ".Resolver":
+		return true
+	}
+	return false
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+func isCleanup(t types.Type) bool {
+	sig, ok := t.(*types.Signature)
+	return ok && sig.Params().Len() == 0 && sig.Results().Len() == 0
+}
@@ -0,0 +1,31 @@
+package wirelessanalysis
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestCollector(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), Collector, "b")
+	providers := results[0].Result.([]Provider)
+	if len(providers) != 3 {
+		t.Fatalf("Expected %v, got %v", 3, len(providers))
+	}
+	expected := []Provider{
+		{Kind: "func", Type: "*b.Logger", Dependencies: []string{"*b.Config"}, Func: "NewLogger"},
+		{Kind: "value", Type: "*b.Config"},
+		{Kind: "binding", Type: "io.Writer", Dependencies: []string{"*b.Logger"}},
+	}
+	for j, p := range providers {
+		e := expected[j]
+		if p.Kind != e.Kind || p.Type != e.Type || p.Func != e.Func || strings.Join(p.Dependencies, ",") != strings.Join(e.Dependencies, ",") {
+			t.Errorf("Expected %v, got %v", e, p)
+		}
+	}
+}
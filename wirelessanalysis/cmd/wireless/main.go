@@ -0,0 +1,66 @@
+// Command wireless provides the tools working with the wireless providers declared in the source code.
+//
+// Usage:
+//
+//	wireless graph [-format dot|mermaid|json] [packages]
+//
+// The graph command renders the graph of the providers declared in the packages and all the packages of
+// the same module they import, without running the program.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/routercore/wireless/wirelessanalysis"
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "graph" {
+		fmt.Fprintln(os.Stderr, "usage: wireless graph [-format dot|mermaid|json] [packages]")
+		os.Exit(2)
+	}
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	format := fs.String("format", wirelessanalysis.FormatDOT, "output format: dot, mermaid or json")
+	_ = fs.Parse(os.Args[2:])
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	if err := graph(*format, patterns); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// graph writes the graph of the providers declared in the packages to the standard output.
+func graph(format string, patterns []string) error {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("loading packages failed")
+	}
+	modules := map[string]bool{}
+	for _, p := range pkgs {
+		if p.Module != nil {
+			modules[p.Module.Path] = true
+		}
+	}
+	var providers []wirelessanalysis.Provider
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if p.Module == nil || !modules[p.Module.Path] || p.PkgPath == wirelessanalysis.WirelessPath {
+			return
+		}
+		providers = append(providers, wirelessanalysis.Collect(p.Fset, p.Syntax, p.TypesInfo)...)
+	})
+	wirelessanalysis.SortProviders(providers)
+	return wirelessanalysis.WriteGraph(os.Stdout, format, providers)
+}
@@ -0,0 +1,13 @@
+// Command wirelessvet runs the wireless analyzer, either standalone or as the go vet tool:
+//
+//	go vet -vettool=$(which wirelessvet) ./...
+package main
+
+import (
+	"github.com/routercore/wireless/wirelessanalysis"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(wirelessanalysis.Analyzer)
+}
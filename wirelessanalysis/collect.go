@@ -0,0 +1,119 @@
+package wirelessanalysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// Provider is the provider declaration found in the source code.
+type Provider struct {
+	// Kind is one of 'func', 'value', 'binding' or 'decorator'.
+	Kind string `json:"kind"`
+	// Type is the provided type, the bound interface type or the decorated type.
+	Type string `json:"type"`
+	// Dependencies are the types of the provider function arguments, or the type the interface is bound to.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Func is the source of the provider function expression.
+	Func string `json:"func,omitempty"`
+	// Position is the position of the provider declaration.
+	Position string `json:"position"`
+}
+
+// Collector is the analyzer collecting the provider declarations of the package, returned as its []Provider result.
+var Collector = &analysis.Analyzer{
+	Name:       "wirelesscollect",
+	Doc:        "collect the wireless provider declarations",
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf([]Provider(nil)),
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		return Collect(pass.Fset, pass.Files, pass.TypesInfo), nil
+	},
+}
+
+// Collect collects the provider declarations of the type checked files, in the order of declaration.
+func Collect(fset *token.FileSet, files []*ast.File, info *types.Info) []Provider {
+	var providers []Provider
+	qualifier := func(p *types.Package) string { return p.Name() }
+	typeString := func(t types.Type) string { return types.TypeString(t, qualifier) }
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			p := Provider{Position: fset.Position(call.Pos()).String()}
+			switch calleeName(info, call) {
+			case "Func", "FuncOf", "Decorate":
+				sig, ok := info.TypeOf(call.Args[0]).Underlying().(*types.Signature)
+				if !ok || sig.Results().Len() == 0 {
+					return true
+				}
+				p.Kind, p.Type, p.Func = "func", typeString(sig.Results().At(0).Type()), types.ExprString(call.Args[0])
+				if idx, ok := ast.Unparen(call.Fun).(*ast.IndexExpr); ok {
+					p.Type = typeString(info.TypeOf(idx.Index))
+				}
+				start := 0
+				if calleeName(info, call) == "Decorate" {
+					p.Kind, start = "decorator", 1
+				}
+				for j := start; j < sig.Params().Len(); j++ {
+					t := sig.Params().At(j).Type()
+					if j == 0 && typeString(t) == "context.Context" {
+						continue
+					}
+					p.Dependencies = append(p.Dependencies, typeString(t))
+				}
+			case "Value":
+				t := info.TypeOf(call.Args[0])
+				if t == nil || info.Types[call.Args[0]].IsNil() {
+					return true
+				}
+				p.Kind, p.Type = "value", typeString(t)
+			case "Bind", "BindWithConstraint", "InterfaceValue":
+				if len(call.Args) < 2 {
+					return true
+				}
+				it, ok1 := info.TypeOf(call.Args[0]).(*types.Pointer)
+				tt := info.TypeOf(call.Args[1])
+				if !ok1 || tt == nil {
+					return true
+				}
+				if calleeName(info, call) == "InterfaceValue" {
+					p.Kind, p.Type = "value", typeString(it.Elem())
+					break
+				}
+				to, ok2 := tt.(*types.Pointer)
+				if !ok2 {
+					return true
+				}
+				p.Kind, p.Type, p.Dependencies = "binding", typeString(it.Elem()), []string{typeString(to.Elem())}
+			default:
+				return true
+			}
+			providers = append(providers, p)
+			return true
+		})
+	}
+	return providers
+}
+
+// SortProviders sorts the providers stably by their type, kind and position, so that the reports generated out of
+// them do not depend on the order in which the packages are loaded.
+func SortProviders(providers []Provider) {
+	sort.SliceStable(providers, func(j, k int) bool {
+		a, b := providers[j], providers[k]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Position < b.Position
+	})
+}
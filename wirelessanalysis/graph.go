@@ -0,0 +1,71 @@
+package wirelessanalysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Graph output formats supported by WriteGraph.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// WriteGraph writes the graph of the providers, with the edges pointing from the provided types to their
+// dependencies, in the given format.
+func WriteGraph(w io.Writer, format string, providers []Provider) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(providers)
+	case FormatDOT:
+		fmt.Fprintln(w, "digraph wireless {")
+		for _, n := range graphNodes(providers) {
+			fmt.Fprintf(w, "\t%s;\n", strconv.Quote(n))
+		}
+		for _, p := range providers {
+			for _, d := range p.Dependencies {
+				fmt.Fprintf(w, "\t%s -> %s;\n", strconv.Quote(p.Type), strconv.Quote(d))
+			}
+		}
+		_, err := fmt.Fprintln(w, "}")
+		return err
+	case FormatMermaid:
+		fmt.Fprintln(w, "graph LR")
+		ids := map[string]string{}
+		for j, n := range graphNodes(providers) {
+			ids[n] = "n" + strconv.Itoa(j)
+			fmt.Fprintf(w, "\t%s[%s]\n", ids[n], strconv.Quote(n))
+		}
+		for _, p := range providers {
+			for _, d := range p.Dependencies {
+				fmt.Fprintf(w, "\t%s --> %s\n", ids[p.Type], ids[d])
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported graph format: %s", format)
+}
+
+// graphNodes returns the provided and the dependency types in the order of their appearance.
+func graphNodes(providers []Provider) []string {
+	var nodes []string
+	seen := map[string]bool{}
+	add := func(n string) {
+		if !seen[n] {
+			seen[n] = true
+			nodes = append(nodes, n)
+		}
+	}
+	for _, p := range providers {
+		add(p.Type)
+		for _, d := range p.Dependencies {
+			add(d)
+		}
+	}
+	return nodes
+}
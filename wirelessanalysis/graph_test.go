@@ -0,0 +1,43 @@
+package wirelessanalysis
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteGraph(t *testing.T) {
+	providers := []Provider{
+		{Kind: "func", Type: "*a.Logger", Dependencies: []string{"*a.Config"}},
+		{Kind: "value", Type: "*a.Config"},
+	}
+	for format, expected := range map[string]string{
+		FormatDOT:     `"*a.Logger" -> "*a.Config";`,
+		FormatMermaid: "n0 --> n1",
+		FormatJSON:    `"type": "*a.Logger"`,
+	} {
+		var buf bytes.Buffer
+		if err := WriteGraph(&buf, format, providers); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %q in the %s graph, got:\n%s", expected, format, buf.String())
+		}
+	}
+}
+
+func TestSortProviders(t *testing.T) {
+	providers := []Provider{
+		{Kind: "func", Type: "*a.Logger", Position: "b.go:3:2"},
+		{Kind: "value", Type: "*a.Config", Position: "a.go:5:2"},
+		{Kind: "decorator", Type: "*a.Logger", Position: "a.go:9:2"},
+	}
+	SortProviders(providers)
+	var order []string
+	for _, p := range providers {
+		order = append(order, p.Kind)
+	}
+	if strings.Join(order, ",") != "value,decorator,func" {
+		t.Errorf("Expected %v, got %v", "value,decorator,func", order)
+	}
+}
@@ -0,0 +1,37 @@
+package a
+
+import (
+	"io"
+	"strings"
+
+	"github.com/routercore/wireless"
+)
+
+type Config struct{}
+
+type Logger struct{}
+
+type Service struct{}
+
+func NewLogger(c *Config) *Logger { return &Logger{} }
+
+func NewService(l *Logger, r io.Reader) (*Service, func(), error) { return &Service{}, nil, nil }
+
+func invalid() (*Service, int) { return nil, 0 }
+
+func main() {
+	i := wireless.New()
+	i.Provide(
+		wireless.Func(NewLogger),
+		wireless.Func(NewService), // want `no provider found for the io.Reader type`
+		wireless.Value(&Config{}),
+	)
+	i.Provide(wireless.Func(&Config{}))                    // want `provider is not a function but: \*a.Config`
+	i.Provide(wireless.Func(invalid))                      // want `provider second returned value is neither an error nor a cleanup function but: int`
+	i.Provide(wireless.Bind(io.Reader(nil), new(*Logger))) // want `binding argument is not defining type with .new. statement: io.Reader`
+	i.Provide(wireless.Bind(new(io.Reader), new(*Logger))) // want `type: \*a.Logger does not implement the interface: io.Reader`
+	i.Provide(wireless.Bind(new(*Config), new(*Logger)))   // want `bound type: \*a.Config is neither an interface nor a function type`
+	i.Provide(wireless.Bind(new(io.Reader), new(*strings.Reader)))
+	i.Provide(wireless.Value(nil))                                       // want `value provider is nil`
+	i.Provide(wireless.Decorate(func(l *Config) *Logger { return nil })) // want `decorator first argument is expected to be the decorated type: \*a.Logger`
+}
@@ -0,0 +1,21 @@
+package b
+
+import (
+	"io"
+
+	"github.com/routercore/wireless"
+)
+
+type Config struct{}
+
+type Logger struct{}
+
+func (*Logger) Write(p []byte) (int, error) { return len(p), nil }
+
+func NewLogger(c *Config) *Logger { return &Logger{} }
+
+var Set = []wireless.Provider{
+	wireless.Func(NewLogger),
+	wireless.Value(&Config{}),
+	wireless.Bind(new(io.Writer), new(*Logger)),
+}
@@ -0,0 +1,17 @@
+package wireless
+
+type Provider interface{}
+
+type Injector struct{}
+
+func (i *Injector) Provide(providers ...Provider) {}
+
+func New() *Injector { return &Injector{} }
+
+func Func(in interface{}) Provider { return nil }
+
+func Value(v interface{}) Provider { return nil }
+
+func Bind(iface interface{}, to interface{}) Provider { return nil }
+
+func Decorate(fn interface{}) Provider { return nil }
@@ -0,0 +1,67 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// AccessorsConfig is the configuration of the generated typed facade.
+type AccessorsConfig struct {
+	// Package is the name of the generated package.
+	Package string
+	// ImportPath is the import path of the generated package, used to reference its own identifiers unqualified.
+	ImportPath string
+	// TypeName is the name of the generated facade type. Defaults to 'App'.
+	TypeName string
+}
+
+// Accessors writes the Go source file declaring the typed facade over the wireless.Container with an accessor
+// method for each of the root types, defined with the 'new' statement. The accessors are named after the types,
+// e.g. 'Logger() *Logger'. All the root types are injected by the generated constructor, named 'New<TypeName>',
+// so that the injection errors are reported once and the accessors are plain getters.
+// Example:
+//
+//	err := wirelessgen.Accessors(f, wirelessgen.AccessorsConfig{Package: "app"}, new(*Logger), new(*sql.DB))
+func Accessors(w io.Writer, c AccessorsConfig, roots ...interface{}) error {
+	if c.TypeName == "" {
+		c.TypeName = "App"
+	}
+	g := &generator{importPath: c.ImportPath, imports: map[string]string{}, aliases: map[string]bool{c.Package: true}}
+
+	var fields, injects, methods bytes.Buffer
+	names := map[string]bool{}
+	for j, root := range roots {
+		rt := reflect.TypeOf(root)
+		if rt == nil || rt.Kind() != reflect.Ptr {
+			return fmt.Errorf("root type: %T is not defined with `new` statement", root)
+		}
+		t := rt.Elem()
+		tn, err := g.typeName(t)
+		if err != nil {
+			return err
+		}
+		name := exportedName(t)
+		if name == "" {
+			name = "Root"
+		}
+		base := name
+		for n := 2; names[name]; n++ {
+			name = base + strconv.Itoa(n)
+		}
+		names[name] = true
+
+		fmt.Fprintf(&fields, "\tr%d %s\n", j, tn)
+		fmt.Fprintf(&injects, "\tif err := c.InjectAs(&a.r%d); err != nil {\n\t\treturn nil, err\n\t}\n", j)
+		fmt.Fprintf(&methods, "\n// %s returns the %s.\nfunc (a *%s) %s() %s {\n\treturn a.r%d\n}\n", name, tn, c.TypeName, name, tn, j)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// %[1]s is the typed facade of the injector.\ntype %[1]s struct {\n%[2]s}\n\n", c.TypeName, fields.String())
+	fmt.Fprintf(&body, "// New%[1]s creates the %[1]s with all its types injected from the resolved container.\nfunc New%[1]s(c %[2]s.Container) (*%[1]s, error) {\n\ta := &%[1]s{}\n%[3]s\treturn a, nil\n}\n",
+		c.TypeName, g.qualifier(wirelessPath), injects.String())
+	body.Write(methods.Bytes())
+	return g.write(w, c.Package, body.Bytes())
+}
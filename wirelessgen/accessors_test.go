@@ -0,0 +1,33 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAccessors(t *testing.T) {
+	var buf bytes.Buffer
+	err := Accessors(&buf, AccessorsConfig{Package: "app", ImportPath: "github.com/routercore/wireless/wirelessgen"},
+		new(*Logger), new(io.Reader))
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	src := buf.String()
+	for _, expected := range []string{
+		"type App struct {",
+		"func NewApp(c wireless.Container) (*App, error) {",
+		"if err := c.InjectAs(&a.r1); err != nil {",
+		"func (a *App) Logger() *Logger {",
+		"func (a *App) Reader() io.Reader {",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("Expected %q in the generated source, got:\n%s", expected, src)
+		}
+	}
+
+	if err := Accessors(io.Discard, AccessorsConfig{Package: "app"}, Logger{}); err == nil {
+		t.Error("Expected error of the root type not defined with new, got nil")
+	}
+}
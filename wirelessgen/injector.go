@@ -0,0 +1,231 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/routercore/wireless"
+)
+
+var (
+	contextType = reflect.TypeOf(new(context.Context)).Elem()
+	errorType   = reflect.TypeOf(new(error)).Elem()
+	cleanupType = reflect.TypeOf(func() {})
+)
+
+// InjectorConfig is the configuration of the generated injector.
+type InjectorConfig struct {
+	// Package is the name of the generated package.
+	Package string
+	// ImportPath is the import path of the generated package, used to reference its own identifiers unqualified.
+	ImportPath string
+	// TypeName is the name of the generated injector type. Defaults to 'Injector'.
+	TypeName string
+}
+
+// Injector writes the Go source file declaring the typed injector of the providers, which calls the provider
+// functions directly, without any reflection, and implements wireless.Container.
+// The values and interface values become the arguments of the generated constructor, named 'New<TypeName>',
+// which also takes the context passed to the provider functions taking context.Context as their first argument.
+// The provider functions need to be top level functions, while the bindings are converted explicitly.
+// Missing providers and dependency cycles are reported by the generator, rather than at runtime.
+// The generated injector is not safe for concurrent use.
+// The generator is driven by the same provider sets that are passed to the Provide of the wireless.Injector,
+// e.g. from the program run by go:generate:
+//
+//	err := wirelessgen.Injector(f, wirelessgen.InjectorConfig{Package: "app", ImportPath: "example.com/app"}, app.Providers)
+func Injector(w io.Writer, c InjectorConfig, providers ...wireless.Provider) error {
+	if c.TypeName == "" {
+		c.TypeName = "Injector"
+	}
+	g := &generator{importPath: c.ImportPath, imports: map[string]string{}, aliases: map[string]bool{c.Package: true}}
+	ig := &injectorGen{generator: g, recv: c.TypeName, nodes: map[reflect.Type]*node{}}
+	for _, info := range wireless.Inspect(providers...) {
+		if err := ig.add(info); err != nil {
+			return err
+		}
+	}
+	return ig.write(w, c)
+}
+
+// node is the provided type of the generated injector.
+type node struct {
+	id   int
+	t    reflect.Type
+	kind wireless.ProviderKind
+	// ref is the function reference of the provider function.
+	ref string
+	fn  reflect.Type
+	// target is the bound type of the binding.
+	target reflect.Type
+	// generated reports whether the getter is already generated, visiting is used to detect cycles.
+	generated, visiting bool
+}
+
+type injectorGen struct {
+	*generator
+	recv    string
+	nodes   map[reflect.Type]*node
+	order   []*node
+	ctx     bool
+	getters bytes.Buffer
+}
+
+// add registers the provider as the node of the generated injector.
+func (ig *injectorGen) add(info wireless.ProviderInfo) error {
+	if info.Type == nil {
+		return fmt.Errorf("provider: %T is invalid", info.Provider)
+	}
+	if info.Group != "" || info.Name != "" {
+		return fmt.Errorf("grouped and named provider of type: %s is not supported by the generated injector", info.Type)
+	}
+	if _, ok := ig.nodes[info.Type]; ok {
+		if info.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("provider already registered for type: %s", info.Type)
+	}
+	n := &node{id: len(ig.order), t: info.Type, kind: info.Kind}
+	switch info.Kind {
+	case wireless.KindFunc:
+		ref, ok := ig.funcRef(info.Func)
+		if !ok {
+			return fmt.Errorf("provider of type: %s is not a top level function", info.Type)
+		}
+		n.ref, n.fn = ref, reflect.TypeOf(info.Func)
+		if n.fn.IsVariadic() {
+			return fmt.Errorf("provider: %s is variadic", ref)
+		}
+		if n.fn.NumIn() > 0 && n.fn.In(0) == contextType {
+			ig.ctx = true
+		}
+	case wireless.KindValue, wireless.KindInterfaceValue:
+	case wireless.KindBinding:
+		n.target = info.Target
+	default:
+		return fmt.Errorf("provider: %T is not supported by the generated injector", info.Provider)
+	}
+	ig.nodes[info.Type] = n
+	ig.order = append(ig.order, n)
+	return nil
+}
+
+// getter returns the name of the getter method of the type, generating it along with the getters of
+// its dependencies.
+func (ig *injectorGen) getter(t reflect.Type, trace []string) (string, error) {
+	n, ok := ig.nodes[t]
+	if !ok {
+		return "", fmt.Errorf("no provider found for the %s type required by: %s", t, strings.Join(trace, "<-"))
+	}
+	name := fmt.Sprintf("get%d", n.id)
+	if n.generated {
+		return name, nil
+	}
+	if n.visiting {
+		return "", fmt.Errorf("dependency cycle detected %s<-%s", t, strings.Join(trace, "<-"))
+	}
+	n.visiting = true
+	defer func() { n.visiting = false }()
+	trace = append([]string{t.String()}, trace...)
+
+	tn, err := ig.typeName(t)
+	if err != nil {
+		return "", err
+	}
+	var body strings.Builder
+	switch n.kind {
+	case wireless.KindValue, wireless.KindInterfaceValue:
+		fmt.Fprintf(&body, "\treturn i.v%d, nil\n", n.id)
+	case wireless.KindBinding:
+		target, err := ig.getter(n.target, trace)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&body, "\tv, err := i.%s()\n\treturn (%s)(v), err\n", target, tn)
+	case wireless.KindFunc:
+		fmt.Fprintf(&body, "\tif i.ok%d {\n\t\treturn i.p%d, nil\n\t}\n", n.id, n.id)
+		args := make([]string, n.fn.NumIn())
+		for j := range args {
+			in := n.fn.In(j)
+			if j == 0 && in == contextType {
+				args[j] = "i.ctx"
+				continue
+			}
+			dep, err := ig.getter(in, trace)
+			if err != nil {
+				return "", err
+			}
+			args[j] = fmt.Sprintf("a%d", j)
+			fmt.Fprintf(&body, "\ta%d, err := i.%s()\n\tif err != nil {\n\t\treturn i.p%d, err\n\t}\n", j, dep, n.id)
+		}
+		call := n.ref + "(" + strings.Join(args, ", ") + ")"
+		outs := n.fn.NumOut()
+		switch {
+		case outs == 1:
+			fmt.Fprintf(&body, "\tv := %s\n", call)
+		case outs == 2 && n.fn.Out(1) == errorType:
+			fmt.Fprintf(&body, "\tv, err := %s\n\tif err != nil {\n\t\treturn v, err\n\t}\n", call)
+		case outs == 2 && n.fn.Out(1) == cleanupType:
+			fmt.Fprintf(&body, "\tv, cleanup := %s\n\tif cleanup != nil {\n\t\ti.cleanups = append(i.cleanups, cleanup)\n\t}\n", call)
+		case outs == 3 && n.fn.Out(1) == cleanupType && n.fn.Out(2) == errorType:
+			fmt.Fprintf(&body, "\tv, cleanup, err := %s\n\tif err != nil {\n\t\treturn v, err\n\t}\n\tif cleanup != nil {\n\t\ti.cleanups = append(i.cleanups, cleanup)\n\t}\n", call)
+		default:
+			return "", fmt.Errorf("provider: %s has invalid returned variables", n.ref)
+		}
+		if n.fn.Out(0) != t {
+			fmt.Fprintf(&body, "\ti.p%d, i.ok%d = (%s)(v), true\n", n.id, n.id, tn)
+		} else {
+			fmt.Fprintf(&body, "\ti.p%d, i.ok%d = v, true\n", n.id, n.id)
+		}
+		fmt.Fprintf(&body, "\treturn i.p%d, nil\n", n.id)
+	}
+	n.generated = true
+	fmt.Fprintf(&ig.getters, "\n// %s returns the %s.\nfunc (i *%s) %s() (%s, error) {\n%s}\n", name, tn, ig.recv, name, tn, body.String())
+	return name, nil
+}
+
+// write writes the source file of the injector.
+func (ig *injectorGen) write(w io.Writer, c InjectorConfig) error {
+	for _, n := range ig.order {
+		if _, err := ig.getter(n.t, nil); err != nil {
+			return err
+		}
+	}
+
+	var fields, params, assigns, cases strings.Builder
+	if ig.ctx {
+		fmt.Fprintf(&params, "ctx %s.Context, ", ig.qualifier("context"))
+		assigns.WriteString("\t\tctx: ctx,\n")
+	}
+	for _, n := range ig.order {
+		tn, _ := ig.typeName(n.t)
+		switch n.kind {
+		case wireless.KindValue, wireless.KindInterfaceValue:
+			fmt.Fprintf(&fields, "\tv%d %s\n", n.id, tn)
+			fmt.Fprintf(&params, "v%d %s, ", n.id, tn)
+			fmt.Fprintf(&assigns, "\t\tv%d: v%d,\n", n.id, n.id)
+		case wireless.KindFunc:
+			fmt.Fprintf(&fields, "\tp%d  %s\n\tok%d bool\n", n.id, tn, n.id)
+		}
+		fmt.Fprintf(&cases, "\tcase *%s:\n\t\tv, err := i.get%d()\n\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n\t\t*p = v\n", tn, n.id)
+	}
+
+	var body bytes.Buffer
+	ctxField := ""
+	if ig.ctx {
+		ctxField = fmt.Sprintf("\tctx %s.Context\n", ig.qualifier("context"))
+	}
+	fmt.Fprintf(&body, "// %[1]s is the injector generated from the wireless providers.\ntype %[1]s struct {\n%[2]s\tcleanups []func()\n%[3]s}\n\n", c.TypeName, ctxField, fields.String())
+	fmt.Fprintf(&body, "var _ %s.Container = (*%s)(nil)\n\n", ig.qualifier(wirelessPath), c.TypeName)
+	fmt.Fprintf(&body, "// New%[1]s creates the %[1]s with the provided values.\nfunc New%[1]s(%[2]s) *%[1]s {\n\treturn &%[1]s{\n%[3]s\t}\n}\n\n",
+		c.TypeName, strings.TrimSuffix(params.String(), ", "), assigns.String())
+	fmt.Fprintf(&body, "// InjectAs injects the value of the type pointed by the input.\nfunc (i *%s) InjectAs(as interface{}) error {\n\tswitch p := as.(type) {\n%s\tdefault:\n\t\treturn %s.Errorf(\"injector not found for the type: %%T: %%w\", as, %s.ErrProviderNotFound)\n\t}\n\treturn nil\n}\n\n",
+		c.TypeName, cases.String(), ig.qualifier("fmt"), ig.qualifier(wirelessPath))
+	fmt.Fprintf(&body, "// Clean executes all the cleanup functions in reverse order to which they were created.\nfunc (i *%s) Clean() {\n\tfor j := len(i.cleanups) - 1; j >= 0; j-- {\n\t\ti.cleanups[j]()\n\t}\n\ti.cleanups = nil\n}\n", c.TypeName)
+	body.Write(ig.getters.Bytes())
+	return ig.generator.write(w, c.Package, body.Bytes())
+}
@@ -0,0 +1,109 @@
+package wirelessgen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+type Service struct {
+	log *Logger
+	r   io.Reader
+}
+
+func NewReader(ctx context.Context, c *Config) (*strings.Reader, func(), error) {
+	return strings.NewReader(c.Addr), func() {}, nil
+}
+
+func NewService(log *Logger, r io.Reader) *Service {
+	return &Service{log: log, r: r}
+}
+
+func TestInjector(t *testing.T) {
+	providers := wireless.NewSet(
+		wireless.Func(NewLogger),
+		wireless.Value(&Config{}),
+		wireless.Func(NewReader),
+		wireless.Bind(new(io.Reader), new(*strings.Reader)),
+		wireless.Func(NewService),
+	)
+	var buf bytes.Buffer
+	err := Injector(&buf, InjectorConfig{Package: "app", ImportPath: "github.com/routercore/wireless/wirelessgen", TypeName: "App"}, providers)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	src := buf.String()
+	for _, expected := range []string{
+		"type App struct {",
+		"var _ wireless.Container = (*App)(nil)",
+		"func NewApp(ctx context.Context, v1 *Config) *App {",
+		"v, cleanup, err := NewReader(i.ctx, a1)",
+		"return (io.Reader)(v), err",
+		"case **Service:",
+		"func (i *App) Clean() {",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("Expected %q in the generated source, got:\n%s", expected, src)
+		}
+	}
+
+	t.Run("Build", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("building the generated source is skipped in short mode")
+		}
+		var buf bytes.Buffer
+		err := Injector(&buf, InjectorConfig{Package: "app", ImportPath: "example.com/app", TypeName: "App"},
+			wireless.Value("addr"),
+			wireless.Func(strings.NewReader),
+			wireless.Bind(new(io.Reader), new(*strings.Reader)),
+			wireless.Func(bufio.NewReader),
+			wireless.Func(os.Open),
+		)
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		buildModule(t, map[string][]byte{"app.go": buf.Bytes()})
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		err := Injector(io.Discard, InjectorConfig{Package: "app"}, wireless.Func(NewService))
+		if err == nil || !strings.Contains(err.Error(), "no provider found") {
+			t.Errorf("Expected missing provider error, got %v", err)
+		}
+	})
+}
+
+// buildModule writes the files to the temporary module requiring the wireless module from the repository
+// and builds it.
+func buildModule(t *testing.T, files map[string][]byte) {
+	t.Helper()
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	dir := t.TempDir()
+	files["go.mod"] = []byte("module example.com/app\n\ngo 1.22\n\nrequire github.com/routercore/wireless v0.0.0\n\n" +
+		"replace github.com/routercore/wireless => " + root + "\n")
+	if files["go.sum"], err = os.ReadFile(filepath.Join(root, "go.sum")); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	for name, data := range files {
+		if err = os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+	}
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("Expected the generated source to build, got %v:\n%s\n%s", err, out, files["app.go"])
+	}
+}
@@ -0,0 +1,137 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/routercore/wireless"
+)
+
+// Markdown writes the Markdown reference of the providers, describing each provided type with its constructor
+// signature, source location, dependencies, lifetime and cleanup behavior.
+// Example:
+//
+//	err := wirelessgen.Markdown(f, "Storage providers", storage.Providers)
+func Markdown(w io.Writer, title string, providers ...wireless.Provider) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n", title)
+	for _, info := range wireless.Inspect(providers...) {
+		if info.Type == nil {
+			return fmt.Errorf("provider: %T is invalid", info.Provider)
+		}
+		fmt.Fprintf(&buf, "\n## `%s`\n\n", info.Type)
+		row := func(name, value string) {
+			fmt.Fprintf(&buf, "- **%s:** %s\n", name, value)
+		}
+		switch info.Kind {
+		case wireless.KindFunc, wireless.KindDecorator:
+			ft := reflect.TypeOf(info.Func)
+			kind := "Provider function"
+			if info.Kind == wireless.KindDecorator {
+				kind = "Decorator"
+			}
+			row("Kind", kind)
+			row("Signature", "`"+funcName(info.Func)+strings.TrimPrefix(ft.String(), "func")+"`")
+			if loc := funcLocation(info.Func); loc != "" {
+				row("Source", "`"+loc+"`")
+			}
+			var deps []string
+			for j := 0; j < ft.NumIn(); j++ {
+				// The decorated value and the injector context are not the dependencies.
+				if j == 0 && (info.Kind == wireless.KindDecorator || ft.In(j) == contextType) {
+					continue
+				}
+				deps = append(deps, "`"+ft.In(j).String()+"`")
+			}
+			if len(deps) == 0 {
+				deps = append(deps, "none")
+			}
+			row("Dependencies", strings.Join(deps, ", "))
+			if info.Kind == wireless.KindFunc {
+				row("Lifetime", lifetime(info))
+			}
+			cleanup := "none"
+			if ft.NumOut() > 1 && ft.Out(1) == cleanupType {
+				cleanup = "returns the cleanup function called by Clean"
+			}
+			row("Cleanup", cleanup)
+		case wireless.KindValue, wireless.KindInterfaceValue:
+			row("Kind", "Value")
+			row("Lifetime", "singleton, provided as is")
+			row("Cleanup", "none")
+		case wireless.KindBinding:
+			kind := "Binding"
+			if info.Convertible {
+				kind = "Conversion"
+			}
+			row("Kind", kind)
+			row("Dependencies", "`"+info.Target.String()+"`")
+			if info.NonShared {
+				row("Lifetime", "own instance of `"+info.Target.String()+"`, constructed on the first injection")
+			} else {
+				row("Lifetime", "same as of `"+info.Target.String()+"`")
+			}
+		default:
+			row("Kind", fmt.Sprintf("%T", info.Provider))
+		}
+		if info.Group != "" {
+			row("Group", "`"+info.Group+"`")
+		}
+		if info.Name != "" {
+			row("Name", "`"+info.Name+"`")
+		}
+		if info.IfNotExists {
+			row("Default", "registered only if no other provider of the type exists")
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// lifetime describes the lifetime of the instances of the provider function.
+func lifetime(info wireless.ProviderInfo) string {
+	var s string
+	switch {
+	case info.Pooled:
+		s = "pooled, constructed on the injections when no released instance is available"
+	case info.Weak && info.Idle > 0:
+		s = fmt.Sprintf("weak, constructed on the first injection and dropped after %s idle or by Trim", info.Idle)
+	case info.Weak:
+		s = "weak, constructed on the first injection and dropped by Trim"
+	case info.TTL > 0:
+		s = fmt.Sprintf("singleton, constructed on the first injection and refreshed every %s", info.TTL)
+	default:
+		s = "singleton, constructed on the first injection"
+	}
+	if info.Scope != "" {
+		s += fmt.Sprintf(", per `%s` scope", info.Scope)
+	}
+	return s
+}
+
+// funcName returns the qualified name of the function.
+func funcName(fn interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return ""
+	}
+	name := f.Name()
+	if slash := strings.LastIndex(name, "/"); slash >= 0 {
+		name = name[slash+1:]
+	}
+	return name
+}
+
+// funcLocation returns the source file and line of the function.
+func funcLocation(fn interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return ""
+	}
+	file, line := f.FileLine(f.Entry())
+	return fmt.Sprintf("%s:%d", file, line)
+}
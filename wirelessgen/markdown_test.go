@@ -0,0 +1,47 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/routercore/wireless"
+)
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := Markdown(&buf, "Providers", wireless.NewSet(
+		wireless.Value(&Config{}),
+		wireless.Func(NewReader),
+		wireless.Bind(new(io.Reader), new(*strings.Reader)),
+		wireless.Pooled(wireless.Func(func() *bytes.Buffer { return new(bytes.Buffer) })),
+		wireless.Weak(time.Minute, wireless.Func(func() *Logger { return &Logger{} })),
+		wireless.ScopedTo(wireless.ScopeRequest, wireless.TTL(time.Hour, wireless.Func(func() *Config { return &Config{} }))),
+	))
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	doc := buf.String()
+	for _, expected := range []string{
+		"# Providers",
+		"## `*wirelessgen.Config`",
+		"- **Signature:** `wirelessgen.NewReader(context.Context, *wirelessgen.Config) (*strings.Reader, func(), error)`",
+		"- **Dependencies:** `*wirelessgen.Config`\n",
+		"- **Lifetime:** singleton, constructed on the first injection\n",
+		"- **Lifetime:** pooled, constructed on the injections when no released instance is available",
+		"- **Lifetime:** weak, constructed on the first injection and dropped after 1m0s idle or by Trim",
+		"- **Lifetime:** singleton, constructed on the first injection and refreshed every 1h0m0s, per `request` scope",
+		"- **Cleanup:** returns the cleanup function called by Clean",
+		"- **Lifetime:** same as of `*strings.Reader`",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("Expected %q in the generated document, got:\n%s", expected, doc)
+		}
+	}
+	if !regexp.MustCompile("- \\*\\*Source:\\*\\* `[^`]+\\.go:[0-9]+`").MatchString(doc) {
+		t.Errorf("Expected the source location in the generated document, got:\n%s", doc)
+	}
+}
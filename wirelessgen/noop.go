@@ -0,0 +1,107 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// NoOpsConfig is the configuration of the generated no-op implementations.
+type NoOpsConfig struct {
+	// Package is the name of the generated package.
+	Package string
+	// ImportPath is the import path of the generated package, used to reference its own identifiers unqualified.
+	ImportPath string
+}
+
+// NoOps writes the Go source file declaring the no-op implementations of the interfaces, defined with the 'new'
+// statement, whose methods return zero values of their results. The implementations are named after
+// the interfaces, e.g. 'noOpTracer', and registered with wireless.RegisterNoOp in the init function of the file,
+// so that wireless.NoOp provides them.
+// Example:
+//
+//	err := wirelessgen.NoOps(f, wirelessgen.NoOpsConfig{Package: "app"}, new(Tracer), new(Meter))
+func NoOps(w io.Writer, c NoOpsConfig, targets ...interface{}) error {
+	g := &generator{importPath: c.ImportPath, imports: map[string]string{}, aliases: map[string]bool{c.Package: true}}
+
+	var types, registers bytes.Buffer
+	for _, target := range targets {
+		rt := reflect.TypeOf(target)
+		if rt == nil || rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Interface {
+			return fmt.Errorf("no-op interface: %T is not defined with `new` statement", target)
+		}
+		t := rt.Elem()
+		tn, err := g.typeName(t)
+		if err != nil {
+			return err
+		}
+		name := "noOp" + exportedName(t)
+		fmt.Fprintf(&types, "\n// %s is the no-op implementation of the %s.\ntype %s struct{}\n", name, tn, name)
+		for j := 0; j < t.NumMethod(); j++ {
+			m := t.Method(j)
+			if m.PkgPath != "" {
+				return fmt.Errorf("method: %s of the interface: %s is not exported", m.Name, t)
+			}
+			sig, err := g.noOpSignature(m.Type)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&types, "\nfunc (%s) %s%s {\n\treturn\n}\n", name, m.Name, sig)
+		}
+		fmt.Fprintf(&registers, "\t%s.RegisterNoOp(new(%s), %s{})\n", g.qualifier(wirelessPath), tn, name)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "func init() {\n%s}\n", registers.String())
+	body.Write(types.Bytes())
+	return g.write(w, c.Package, body.Bytes())
+}
+
+// noOpSignature returns the signature of the no-op method with its results named, so that they are returned
+// with bare return.
+func (g *generator) noOpSignature(t reflect.Type) (string, error) {
+	ins, outs, err := g.methodTypes(t)
+	if err != nil {
+		return "", err
+	}
+	return signature(ins, outs), nil
+}
+
+// methodTypes returns the names of the parameter and result types of the method type, with the variadic
+// parameter prefixed with the ellipsis.
+func (g *generator) methodTypes(t reflect.Type) ([]string, []string, error) {
+	ins, outs := make([]string, t.NumIn()), make([]string, t.NumOut())
+	for j := range ins {
+		in, err := g.typeName(t.In(j))
+		if err != nil {
+			return nil, nil, err
+		}
+		if t.IsVariadic() && j == len(ins)-1 {
+			in = "..." + strings.TrimPrefix(in, "[]")
+		}
+		ins[j] = in
+	}
+	for j := range outs {
+		out, err := g.typeName(t.Out(j))
+		if err != nil {
+			return nil, nil, err
+		}
+		outs[j] = out
+	}
+	return ins, outs, nil
+}
+
+// signature returns the signature of the parameter and result types, with the results named 'r0', 'r1', etc.
+func signature(ins, outs []string) string {
+	named := make([]string, len(outs))
+	for j, out := range outs {
+		named[j] = fmt.Sprintf("r%d %s", j, out)
+	}
+	sig := "(" + strings.Join(ins, ", ") + ")"
+	if len(named) == 0 {
+		return sig
+	}
+	return sig + " (" + strings.Join(named, ", ") + ")"
+}
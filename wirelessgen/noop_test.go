@@ -0,0 +1,52 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+type tracer interface {
+	Start(name string, attrs ...string) (func(), error)
+	Flush()
+}
+
+func TestNoOps(t *testing.T) {
+	var buf bytes.Buffer
+	err := NoOps(&buf, NoOpsConfig{Package: "app", ImportPath: "github.com/routercore/wireless/wirelessgen"},
+		new(tracer), new(io.ReadCloser))
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	src := buf.String()
+	for _, expected := range []string{
+		"wireless.RegisterNoOp(new(tracer), noOpTracer{})",
+		"wireless.RegisterNoOp(new(io.ReadCloser), noOpReadCloser{})",
+		"func (noOpTracer) Start(string, ...string) (r0 func(), r1 error) {\n\treturn\n}",
+		"func (noOpTracer) Flush() {",
+		"func (noOpReadCloser) Read([]uint8) (r0 int, r1 error) {",
+		"func (noOpReadCloser) Close() (r0 error) {",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("Expected %q in the generated source, got:\n%s", expected, src)
+		}
+	}
+
+	if err := NoOps(io.Discard, NoOpsConfig{Package: "app"}, new(Logger)); err == nil {
+		t.Error("Expected error of the non interface type, got nil")
+	}
+
+	t.Run("Build", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("building the generated source is skipped in short mode")
+		}
+		var buf bytes.Buffer
+		err := NoOps(&buf, NoOpsConfig{Package: "app", ImportPath: "example.com/app"}, new(io.ReadCloser), new(fmt.Stringer))
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		buildModule(t, map[string][]byte{"app.go": buf.Bytes()})
+	})
+}
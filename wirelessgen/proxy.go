@@ -0,0 +1,96 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ProxiesConfig is the configuration of the generated interface proxies.
+type ProxiesConfig struct {
+	// Package is the name of the generated package.
+	Package string
+	// ImportPath is the import path of the generated package, used to reference its own identifiers unqualified.
+	ImportPath string
+}
+
+// Proxies writes the Go source file declaring the proxies of the interfaces, defined with the 'new' statement,
+// which pass each method call, along with the method name and arguments, to the wireless.ProxyHandler.
+// The proxies are named after the interfaces, e.g. 'proxyRepository', and registered with wireless.RegisterProxy
+// in the init function of the file, so that wireless.Intercept intercepts the interfaces.
+// Example:
+//
+//	err := wirelessgen.Proxies(f, wirelessgen.ProxiesConfig{Package: "app"}, new(Repository), new(Mailer))
+func Proxies(w io.Writer, c ProxiesConfig, targets ...interface{}) error {
+	g := &generator{importPath: c.ImportPath, imports: map[string]string{}, aliases: map[string]bool{c.Package: true}}
+
+	var types, registers bytes.Buffer
+	for _, target := range targets {
+		rt := reflect.TypeOf(target)
+		if rt == nil || rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Interface {
+			return fmt.Errorf("proxied interface: %T is not defined with `new` statement", target)
+		}
+		t := rt.Elem()
+		tn, err := g.typeName(t)
+		if err != nil {
+			return err
+		}
+		wireless := g.qualifier(wirelessPath)
+		name := "proxy" + exportedName(t)
+		fmt.Fprintf(&types, "\n// %s is the proxy of the %s.\ntype %s struct {\n\ttarget %s\n\th %s.ProxyHandler\n}\n",
+			name, tn, name, tn, wireless)
+		for j := 0; j < t.NumMethod(); j++ {
+			m := t.Method(j)
+			if m.PkgPath != "" {
+				return fmt.Errorf("method: %s of the interface: %s is not exported", m.Name, t)
+			}
+			method, err := g.proxyMethod(name, m)
+			if err != nil {
+				return err
+			}
+			types.WriteString(method)
+		}
+		fmt.Fprintf(&registers, "\t%s.RegisterProxy(new(%s), func(target interface{}, h %s.ProxyHandler) interface{} {\n"+
+			"\t\treturn %s{target: target.(%s), h: h}\n\t})\n", wireless, tn, wireless, name, tn)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "func init() {\n%s}\n", registers.String())
+	body.Write(types.Bytes())
+	return g.write(w, c.Package, body.Bytes())
+}
+
+// proxyMethod returns the method of the proxy, calling the method of the target through the handler and
+// returning its results.
+func (g *generator) proxyMethod(name string, m reflect.Method) (string, error) {
+	ins, outs, err := g.methodTypes(m.Type)
+	if err != nil {
+		return "", err
+	}
+	params, args := make([]string, len(ins)), make([]string, len(ins))
+	for j, in := range ins {
+		params[j] = fmt.Sprintf("a%d %s", j, in)
+		args[j] = fmt.Sprintf("a%d", j)
+	}
+	call := fmt.Sprintf("p.target.%s(%s", m.Name, strings.Join(args, ", "))
+	if m.Type.IsVariadic() {
+		call += "..."
+	}
+	call += ")"
+	if len(outs) > 0 {
+		results := make([]string, len(outs))
+		for j := range outs {
+			results[j] = fmt.Sprintf("r%d", j)
+		}
+		call = strings.Join(results, ", ") + " = " + call
+	}
+	ret := "nil"
+	if n := len(outs); n > 0 && m.Type.Out(n-1) == errorType {
+		ret = fmt.Sprintf("r%d", n-1)
+	}
+	sig := signature(params, outs)
+	return fmt.Sprintf("\nfunc (p %s) %s%s {\n\tp.h(%q, []interface{}{%s}, func() error {\n\t\t%s\n\t\treturn %s\n\t})\n\treturn\n}\n",
+		name, m.Name, sig, m.Name, strings.Join(args, ", "), call, ret), nil
+}
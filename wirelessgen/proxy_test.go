@@ -0,0 +1,47 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestProxies(t *testing.T) {
+	var buf bytes.Buffer
+	err := Proxies(&buf, ProxiesConfig{Package: "app", ImportPath: "github.com/routercore/wireless/wirelessgen"},
+		new(tracer), new(io.ReadCloser))
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	src := buf.String()
+	for _, expected := range []string{
+		"wireless.RegisterProxy(new(tracer), func(target interface{}, h wireless.ProxyHandler) interface{} {",
+		"return proxyTracer{target: target.(tracer), h: h}",
+		"func (p proxyTracer) Start(a0 string, a1 ...string) (r0 func(), r1 error) {",
+		"p.h(\"Start\", []interface{}{a0, a1}, func() error {\n\t\tr0, r1 = p.target.Start(a0, a1...)\n\t\treturn r1\n\t})",
+		"func (p proxyTracer) Flush() {\n\tp.h(\"Flush\", []interface{}{}, func() error {\n\t\tp.target.Flush()\n\t\treturn nil\n\t})",
+		"func (p proxyReadCloser) Read(a0 []uint8) (r0 int, r1 error) {",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("Expected %q in the generated source, got:\n%s", expected, src)
+		}
+	}
+
+	if err := Proxies(io.Discard, ProxiesConfig{Package: "app"}, new(Logger)); err == nil {
+		t.Error("Expected error of the non interface type, got nil")
+	}
+
+	t.Run("Build", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("building the generated source is skipped in short mode")
+		}
+		var buf bytes.Buffer
+		err := Proxies(&buf, ProxiesConfig{Package: "app", ImportPath: "example.com/app"}, new(io.ReadCloser), new(fmt.Stringer))
+		if err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		buildModule(t, map[string][]byte{"app.go": buf.Bytes()})
+	})
+}
@@ -0,0 +1,316 @@
+// Package wirelessgen generates the Go source code out of the wireless providers.
+package wirelessgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"path"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/routercore/wireless"
+)
+
+const (
+	wirePath     = "github.com/google/wire"
+	wirelessPath = "github.com/routercore/wireless"
+)
+
+// WireConfig is the configuration of the generated wire provider set.
+type WireConfig struct {
+	// Package is the name of the generated package.
+	Package string
+	// ImportPath is the import path of the generated package, used to reference its own identifiers unqualified.
+	ImportPath string
+	// SetName is the name of the generated provider set variable. Defaults to 'Set'.
+	SetName string
+}
+
+// WireSet writes the Go source file declaring the wire provider set of the providers, so that part of the graph
+// might be compiled statically with wire. The top level provider functions are referenced directly, while the
+// bindings are declared with wire.Bind. The types of the values, the anonymous functions and other providers,
+// which could not be referenced statically, are provided by the generated adapters injecting them from
+// the *wireless.Injector, which then needs to be passed to the wire injector.
+// Example:
+//
+//	err := wirelessgen.WireSet(f, wirelessgen.WireConfig{Package: "main", ImportPath: "main"}, providers)
+func WireSet(w io.Writer, c WireConfig, providers ...wireless.Provider) error {
+	if c.SetName == "" {
+		c.SetName = "Set"
+	}
+	g := &generator{importPath: c.ImportPath, imports: map[string]string{}, aliases: map[string]bool{c.Package: true}}
+	g.qualifier(wirePath)
+
+	var entries []string
+	for _, info := range wireless.Inspect(providers...) {
+		if info.Type == nil {
+			return fmt.Errorf("provider: %T is invalid", info.Provider)
+		}
+		switch info.Kind {
+		case wireless.KindFunc:
+			if ref, ok := g.funcRef(info.Func); ok && info.Group == "" && info.Name == "" {
+				entries = append(entries, ref)
+				continue
+			}
+		case wireless.KindBinding:
+			if !info.Convertible {
+				it, err := g.typeName(info.Type)
+				if err != nil {
+					return err
+				}
+				tt, err := g.typeName(info.Target)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, fmt.Sprintf("%s.Bind(new(%s), new(%s))", g.qualifier(wirePath), it, tt))
+				continue
+			}
+		case wireless.KindDecorator, wireless.KindOther:
+			// The decorators are applied by the injector to the adapted types, other providers are not typed.
+			continue
+		}
+		if info.Group != "" {
+			// The group members are injected as a whole by InjectGroup, rather than by their type.
+			continue
+		}
+		adapter, err := g.adapter(info)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, adapter)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// %s is the wire provider set generated from the wireless providers.\nvar %s = %s.NewSet(\n", c.SetName, c.SetName, g.qualifier(wirePath))
+	for _, e := range entries {
+		fmt.Fprintf(&body, "\t%s,\n", e)
+	}
+	body.WriteString(")\n")
+	body.Write(g.adapters.Bytes())
+	return g.write(w, c.Package, body.Bytes())
+}
+
+// generator collects the imports and the adapters of the generated file.
+type generator struct {
+	importPath string
+	imports    map[string]string
+	aliases    map[string]bool
+	adapters   bytes.Buffer
+	names      map[string]bool
+}
+
+// write writes the formatted source file of the package with the body and the collected imports.
+func (g *generator) write(w io.Writer, pkg string, body []byte) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by wirelessgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if g.imports[p] == path.Base(p) {
+			fmt.Fprintf(&buf, "\t%s\n", strconv.Quote(p))
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%s %s\n", g.imports[p], strconv.Quote(p))
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(body)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting generated source failed: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// qualifier returns the alias of the imported package path.
+func (g *generator) qualifier(pkgPath string) string {
+	if alias, ok := g.imports[pkgPath]; ok {
+		return alias
+	}
+	base := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, path.Base(pkgPath))
+	alias := base
+	for n := 2; g.aliases[alias]; n++ {
+		alias = base + strconv.Itoa(n)
+	}
+	g.aliases[alias] = true
+	g.imports[pkgPath] = alias
+	return alias
+}
+
+// funcRef returns the reference of the top level function, if the function is not anonymous.
+func (g *generator) funcRef(fn interface{}) (string, bool) {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "", false
+	}
+	name := f.Name()
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", false
+	}
+	pkgPath, ident := name[:slash+1+dot], name[slash+2+dot:]
+	if !isIdent(ident) || pkgPath == "reflect" {
+		return "", false
+	}
+	if pkgPath == g.importPath {
+		return ident, true
+	}
+	return g.qualifier(pkgPath) + "." + ident, true
+}
+
+// adapter writes the function injecting the provided type from the wireless injector and returns its name.
+func (g *generator) adapter(info wireless.ProviderInfo) (string, error) {
+	tn, err := g.typeName(info.Type)
+	if err != nil {
+		return "", err
+	}
+	name := "inject" + exportedName(info.Type) + capitalize(info.Name)
+	if g.names == nil {
+		g.names = map[string]bool{}
+	}
+	base := name
+	for n := 2; g.names[name]; n++ {
+		name = base + strconv.Itoa(n)
+	}
+	g.names[name] = true
+
+	inject := "i.InjectAs(&v)"
+	if info.Name != "" {
+		inject = fmt.Sprintf("i.InjectNamed(%s, &v)", strconv.Quote(info.Name))
+	}
+	fmt.Fprintf(&g.adapters, "\n// %s injects the %s from the wireless injector.\nfunc %s(i *%s.Injector) (%s, error) {\n\tvar v %s\n\terr := %s\n\treturn v, err\n}\n",
+		name, tn, name, g.qualifier(wirelessPath), tn, tn, inject)
+	return name, nil
+}
+
+// typeName returns the Go source representation of the type.
+func (g *generator) typeName(t reflect.Type) (string, error) {
+	if t.Name() != "" {
+		if strings.Contains(t.Name(), "[") {
+			return "", fmt.Errorf("generic type: %s is not supported", t)
+		}
+		if t.PkgPath() == "" {
+			return t.Name(), nil
+		}
+		if t.PkgPath() == g.importPath {
+			return t.Name(), nil
+		}
+		if !isIdent(t.Name()) || !unicode.IsUpper([]rune(t.Name())[0]) {
+			return "", fmt.Errorf("type: %s is not exported", t)
+		}
+		return g.qualifier(t.PkgPath()) + "." + t.Name(), nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+		elem, err := g.typeName(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		switch t.Kind() {
+		case reflect.Ptr:
+			return "*" + elem, nil
+		case reflect.Slice:
+			return "[]" + elem, nil
+		case reflect.Array:
+			return fmt.Sprintf("[%d]%s", t.Len(), elem), nil
+		}
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + elem, nil
+		case reflect.SendDir:
+			return "chan<- " + elem, nil
+		}
+		return "chan " + elem, nil
+	case reflect.Map:
+		key, err := g.typeName(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := g.typeName(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "map[" + key + "]" + elem, nil
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "interface{}", nil
+		}
+	case reflect.Func:
+		ins, outs := make([]string, t.NumIn()), make([]string, t.NumOut())
+		for j := range ins {
+			in, err := g.typeName(t.In(j))
+			if err != nil {
+				return "", err
+			}
+			if t.IsVariadic() && j == len(ins)-1 {
+				in = "..." + strings.TrimPrefix(in, "[]")
+			}
+			ins[j] = in
+		}
+		for j := range outs {
+			out, err := g.typeName(t.Out(j))
+			if err != nil {
+				return "", err
+			}
+			outs[j] = out
+		}
+		s := "func(" + strings.Join(ins, ", ") + ")"
+		switch len(outs) {
+		case 0:
+			return s, nil
+		case 1:
+			return s + " " + outs[0], nil
+		}
+		return s + " (" + strings.Join(outs, ", ") + ")", nil
+	}
+	return "", fmt.Errorf("type: %s is not supported", t)
+}
+
+// exportedName returns the identifier derived from the type name, e.g. 'Config' of '*pkg.Config'.
+func exportedName(t reflect.Type) string {
+	for t.Name() == "" && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	return capitalize(t.Name())
+}
+
+// capitalize returns the name with the first letter upper cased and the characters invalid in identifiers removed.
+func capitalize(name string) string {
+	r := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name))
+	if len(r) == 0 {
+		return ""
+	}
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// isIdent reports whether the name is a valid Go identifier.
+func isIdent(name string) bool {
+	for j, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (j == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
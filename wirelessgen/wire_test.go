@@ -0,0 +1,51 @@
+package wirelessgen
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/routercore/wireless"
+)
+
+type Config struct {
+	Addr string
+}
+
+type Logger struct{}
+
+func NewLogger() *Logger {
+	return &Logger{}
+}
+
+func TestWireSet(t *testing.T) {
+	var buf bytes.Buffer
+	err := WireSet(&buf, WireConfig{Package: "app", ImportPath: "github.com/routercore/wireless/wirelessgen"},
+		wireless.NewSet(
+			wireless.Func(NewLogger),
+			wireless.Value(&Config{}),
+			wireless.Func(func() *strings.Reader { return strings.NewReader("") }),
+			wireless.Bind(new(io.Reader), new(*strings.Reader)),
+		),
+	)
+	if err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	src := buf.String()
+	for _, expected := range []string{
+		"package app",
+		`"github.com/google/wire"`,
+		"var Set = wire.NewSet(",
+		"\tNewLogger,\n",
+		"\tinjectConfig,\n",
+		"\tinjectReader,\n",
+		"wire.Bind(new(io.Reader), new(*strings.Reader))",
+		"func injectConfig(i *wireless.Injector) (*Config, error) {",
+		"func injectReader(i *wireless.Injector) (*strings.Reader, error) {",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("Expected %q in the generated source, got:\n%s", expected, src)
+		}
+	}
+}
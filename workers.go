@@ -0,0 +1,194 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPoolClosed is returned by Submit once the WorkerPool is drained.
+var ErrPoolClosed = errors.New("worker pool closed")
+
+// WorkersConfig is the configuration of the WorkerPool provided by Workers.
+type WorkersConfig struct {
+	// Size is the number of the workers, runtime.GOMAXPROCS(0) if not positive.
+	Size int `json:"size" yaml:"size" toml:"size"`
+	// Queue is the number of the submitted jobs waiting for the workers, before Submit blocks.
+	Queue int `json:"queue" yaml:"queue" toml:"queue"`
+}
+
+// Job is the function executed in the background by the WorkerPool. Its context is canceled when the pool is not
+// drained in time.
+type Job func(ctx context.Context) error
+
+// Workers is the provider set of the *WorkerPool, sized with the *WorkersConfig which needs to be provided,
+// e.g. with ConfigFile and FieldsOf. The pool is started along with the injector lifecycle and drained when it is
+// stopped, so that the background jobs are executed by the pool instead of the ad-hoc goroutines.
+// Example:
+//
+//	wireless.NewSet(
+//		wireless.ConfigFile[Config]("config.yaml", wireless.FormatAuto),
+//		wireless.FieldsOf(new(*Config), "Workers"),
+//		wireless.Workers,
+//	)
+var Workers = NewSet(Func(NewWorkerPool))
+
+// WorkerPool executes the submitted jobs with the fixed number of workers. The failed jobs, including the recovered
+// panics wrapped into the PanicError, are emitted with the EventJobFailed and counted in its Stats.
+type WorkerPool struct {
+	size int
+	jobs chan Job
+	// lock guards the closed flag, so that no Submit is registered in the submits once the pool is closed.
+	lock      sync.RWMutex
+	closed    bool
+	closing   chan struct{}
+	closeOnce sync.Once
+	submits   sync.WaitGroup
+	wg        sync.WaitGroup
+	cancel    context.CancelFunc
+	emit      func(Event)
+
+	queued, running, completed, failed, panicked atomic.Int64
+}
+
+// WorkerStats are the metrics of the WorkerPool.
+type WorkerStats struct {
+	Size int
+	// Queued is the number of the submitted jobs waiting for the workers.
+	Queued int64
+	// Running is the number of the jobs being executed.
+	Running int64
+	// Completed is the number of the executed jobs, including the failed ones.
+	Completed int64
+	// Failed is the number of the jobs which returned an error or panicked.
+	Failed int64
+	// Panicked is the number of the jobs which panicked.
+	Panicked int64
+}
+
+// NewWorkerPool creates the WorkerPool appending its lifecycle hook, which starts the workers and drains the pool,
+// waiting for the submitted jobs until the stop context is done.
+func NewWorkerPool(cfg *WorkersConfig, lc *Lifecycle) *WorkerPool {
+	size := cfg.Size
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	queue := cfg.Queue
+	if queue < 0 {
+		queue = 0
+	}
+	p := &WorkerPool{size: size, jobs: make(chan Job, queue), closing: make(chan struct{}), emit: lc.emit}
+	lc.Append(Hook{Name: "worker pool", OnStart: p.start, OnStop: p.drain})
+	return p
+}
+
+// Submit queues the job for the execution by the workers, blocking until there is a room in the queue or
+// the context is done. It fails with the ErrPoolClosed once the pool is being drained.
+func (p *WorkerPool) Submit(ctx context.Context, job Job) error {
+	p.lock.RLock()
+	if p.closed {
+		p.lock.RUnlock()
+		return ErrPoolClosed
+	}
+	p.submits.Add(1)
+	p.lock.RUnlock()
+	defer p.submits.Done()
+
+	p.queued.Add(1)
+	select {
+	case p.jobs <- job:
+		return nil
+	case <-p.closing:
+		p.queued.Add(-1)
+		return ErrPoolClosed
+	case <-ctx.Done():
+		p.queued.Add(-1)
+		return ctx.Err()
+	}
+}
+
+// Stats returns the current metrics of the pool.
+func (p *WorkerPool) Stats() WorkerStats {
+	return WorkerStats{
+		Size:      p.size,
+		Queued:    p.queued.Load(),
+		Running:   p.running.Load(),
+		Completed: p.completed.Load(),
+		Failed:    p.failed.Load(),
+		Panicked:  p.panicked.Load(),
+	}
+}
+
+// start starts the workers, with the context of the jobs outliving the start context.
+func (p *WorkerPool) start(ctx context.Context) error {
+	var jobCtx context.Context
+	jobCtx, p.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	for j := 0; j < p.size; j++ {
+		p.wg.Add(1)
+		go p.work(jobCtx)
+	}
+	return nil
+}
+
+// drain stops accepting the jobs and waits until the workers execute the queued ones. Once the context is done,
+// the context of the running jobs is canceled and the context error is returned.
+func (p *WorkerPool) drain(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		p.lock.Lock()
+		p.closed = true
+		p.lock.Unlock()
+		close(p.closing)
+		// The blocked submits return on the closing, so that the jobs might be closed without waiting for the lock.
+		go func() {
+			p.submits.Wait()
+			close(p.jobs)
+		}()
+	})
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return fmt.Errorf("draining worker pool with: %d jobs running failed: %w", p.running.Load(), ctx.Err())
+	}
+}
+
+func (p *WorkerPool) work(ctx context.Context) {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		p.queued.Add(-1)
+		p.running.Add(1)
+		err := p.run(ctx, job)
+		p.running.Add(-1)
+		p.completed.Add(1)
+		if err == nil {
+			continue
+		}
+		p.failed.Add(1)
+		if p.emit != nil {
+			p.emit(Event{Kind: EventJobFailed, Type: reflect.TypeOf(job), Provider: "worker pool", Err: err})
+		}
+	}
+}
+
+// run executes the job, recovering its panic into the PanicError.
+func (p *WorkerPool) run(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.panicked.Add(1)
+			err = &PanicError{Provider: "worker pool job", Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return job(ctx)
+}
@@ -0,0 +1,150 @@
+package wireless
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool(t *testing.T) {
+	start := func(t *testing.T, cfg *WorkersConfig, events chan<- Event) (*Injector, *WorkerPool) {
+		i := New(WithEventHandler(func(e Event) {
+			if e.Kind == EventJobFailed {
+				events <- e
+			}
+		}))
+		i.Provide(Value(cfg), Workers)
+		if err := i.Resolve(); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		var p *WorkerPool
+		if err := i.InjectAs(&p); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		if err := i.Start(context.Background()); err != nil {
+			t.Fatal("Expected no error, got", err)
+		}
+		return i, p
+	}
+
+	t.Run("drain", func(t *testing.T) {
+		events := make(chan Event, 2)
+		i, p := start(t, &WorkersConfig{Size: 2, Queue: 10}, events)
+		var executed atomic.Int32
+		jobErr := errors.New("job failed")
+		jobs := []Job{
+			func(ctx context.Context) error { executed.Add(1); return nil },
+			func(ctx context.Context) error { executed.Add(1); return jobErr },
+			func(ctx context.Context) error { executed.Add(1); panic("boom") },
+			func(ctx context.Context) error { executed.Add(1); return nil },
+		}
+		for _, job := range jobs {
+			if err := p.Submit(context.Background(), job); err != nil {
+				t.Error("Expected no error, got", err)
+			}
+		}
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if executed.Load() != 4 {
+			t.Errorf("Expected %v, got %v", 4, executed.Load())
+		}
+		expected := WorkerStats{Size: 2, Completed: 4, Failed: 2, Panicked: 1}
+		if stats := p.Stats(); stats != expected {
+			t.Errorf("Expected %+v, got %+v", expected, stats)
+		}
+		var panicErr *PanicError
+		for _, e := range []Event{<-events, <-events} {
+			if !errors.Is(e.Err, jobErr) && !errors.As(e.Err, &panicErr) {
+				t.Errorf("Expected the job error or panic, got %v", e.Err)
+			}
+		}
+		if err := p.Submit(context.Background(), jobs[0]); !errors.Is(err, ErrPoolClosed) {
+			t.Errorf("Expected %v, got %v", ErrPoolClosed, err)
+		}
+	})
+
+	t.Run("drain timeout", func(t *testing.T) {
+		i, p := start(t, &WorkersConfig{Size: 1}, make(chan Event, 1))
+		var wg sync.WaitGroup
+		wg.Add(1)
+		started := make(chan struct{})
+		err := p.Submit(context.Background(), func(ctx context.Context) error {
+			defer wg.Done()
+			close(started)
+			<-ctx.Done()
+			return nil
+		})
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err = i.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+		}
+		wg.Wait()
+	})
+
+	t.Run("drain pending submit", func(t *testing.T) {
+		i, p := start(t, &WorkersConfig{Size: 1}, make(chan Event, 1))
+		started, canceled := make(chan struct{}), make(chan struct{})
+		err := p.Submit(context.Background(), func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+			return nil
+		})
+		if err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		<-started
+		submitted := make(chan error, 1)
+		go func() {
+			submitted <- p.Submit(context.Background(), func(ctx context.Context) error { return nil })
+		}()
+		for p.Stats().Queued == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		stopped := make(chan error, 1)
+		go func() { stopped <- i.Stop(ctx) }()
+		select {
+		case err = <-stopped:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected the stop to respect its context")
+		}
+		<-canceled
+		if err = <-submitted; !errors.Is(err, ErrPoolClosed) {
+			t.Errorf("Expected %v, got %v", ErrPoolClosed, err)
+		}
+	})
+
+	t.Run("submit canceled", func(t *testing.T) {
+		i, p := start(t, &WorkersConfig{Size: 1}, make(chan Event, 1))
+		block := make(chan struct{})
+		if err := p.Submit(context.Background(), func(ctx context.Context) error { <-block; return nil }); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := p.Submit(ctx, func(ctx context.Context) error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+		}
+		close(block)
+		if err := i.Stop(context.Background()); err != nil {
+			t.Error("Expected no error, got", err)
+		}
+		if stats := p.Stats(); stats.Queued != 0 || stats.Completed != 1 {
+			t.Errorf("Expected one completed job, got %+v", stats)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+// Package zapwireless logs the wireless injector events with the go.uber.org/zap logger.
+package zapwireless
+
+import (
+	"time"
+
+	"github.com/routercore/wireless"
+	"go.uber.org/zap"
+)
+
+// EventHandler creates the handler of the injector events logging them with the logger, with the same fields
+// as wireless.SlogEventHandler, see wireless.Event.LogFields. The failures are logged as warnings, while the other
+// events are logged as info.
+// Example:
+//
+//	i := wireless.New(wireless.WithEventHandler(zapwireless.EventHandler(logger)))
+func EventHandler(l *zap.Logger) func(wireless.Event) {
+	return func(e wireless.Event) {
+		level := zap.InfoLevel
+		if e.Failed() {
+			level = zap.WarnLevel
+		}
+		ce := l.Check(level, e.String())
+		if ce == nil {
+			return
+		}
+		fields := e.LogFields()
+		zf := make([]zap.Field, len(fields))
+		for j, f := range fields {
+			switch v := f.Value.(type) {
+			case string:
+				zf[j] = zap.String(f.Key, v)
+			case int:
+				zf[j] = zap.Int(f.Key, v)
+			case time.Duration:
+				zf[j] = zap.Duration(f.Key, v)
+			case error:
+				zf[j] = zap.NamedError(f.Key, v)
+			default:
+				zf[j] = zap.Any(f.Key, v)
+			}
+		}
+		ce.Write(zf...)
+	}
+}
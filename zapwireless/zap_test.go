@@ -0,0 +1,42 @@
+package zapwireless
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/routercore/wireless"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type service struct{}
+
+func TestEventHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	h := EventHandler(zap.New(core))
+	h(wireless.Event{Kind: wireless.EventRetry, Scope: wireless.ScopeRequest, Type: reflect.TypeOf(&service{}),
+		Provider: "NewService", Attempt: 2, Err: errors.New("connection refused")})
+	h(wireless.Event{Kind: wireless.EventHookStopped, Provider: "http server", Duration: time.Second})
+
+	entries := logs.AllUntimed()
+	if len(entries) != 2 {
+		t.Fatalf("Expected %v, got %v", 2, len(entries))
+	}
+	if entries[0].Level != zapcore.WarnLevel || entries[1].Level != zapcore.InfoLevel {
+		t.Errorf("Expected the warning and the info, got %v and %v", entries[0].Level, entries[1].Level)
+	}
+	expected := map[string]interface{}{
+		"event": "retry", "scope": "request", "type": "*zapwireless.service", "provider": "NewService",
+		"attempt": int64(2), "error": "connection refused",
+	}
+	if fields := entries[0].ContextMap(); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+	expected = map[string]interface{}{"event": "hook stopped", "scope": wireless.ScopeRoot, "provider": "http server", "duration": time.Second}
+	if fields := entries[1].ContextMap(); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+}
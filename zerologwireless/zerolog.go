@@ -0,0 +1,42 @@
+// Package zerologwireless logs the wireless injector events with the github.com/rs/zerolog logger.
+package zerologwireless
+
+import (
+	"time"
+
+	"github.com/routercore/wireless"
+	"github.com/rs/zerolog"
+)
+
+// EventHandler creates the handler of the injector events logging them with the logger, with the same fields
+// as wireless.SlogEventHandler, see wireless.Event.LogFields. The failures are logged as warnings, while the other
+// events are logged as info.
+// Example:
+//
+//	i := wireless.New(wireless.WithEventHandler(zerologwireless.EventHandler(log.Logger)))
+func EventHandler(l zerolog.Logger) func(wireless.Event) {
+	return func(e wireless.Event) {
+		ev := l.Info()
+		if e.Failed() {
+			ev = l.Warn()
+		}
+		if ev == nil {
+			return
+		}
+		for _, f := range e.LogFields() {
+			switch v := f.Value.(type) {
+			case string:
+				ev = ev.Str(f.Key, v)
+			case int:
+				ev = ev.Int(f.Key, v)
+			case time.Duration:
+				ev = ev.Dur(f.Key, v)
+			case error:
+				ev = ev.AnErr(f.Key, v)
+			default:
+				ev = ev.Interface(f.Key, v)
+			}
+		}
+		ev.Msg(e.String())
+	}
+}
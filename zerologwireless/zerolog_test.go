@@ -0,0 +1,44 @@
+package zerologwireless
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/routercore/wireless"
+	"github.com/rs/zerolog"
+)
+
+type service struct{}
+
+func TestEventHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := EventHandler(zerolog.New(&buf))
+	h(wireless.Event{Kind: wireless.EventRetry, Scope: wireless.ScopeRequest, Type: reflect.TypeOf(&service{}),
+		Provider: "NewService", Attempt: 2, Err: errors.New("connection refused")})
+	h(wireless.Event{Kind: wireless.EventHookStopped, Provider: "http server", Duration: time.Second})
+
+	dec := json.NewDecoder(&buf)
+	var retry, stopped map[string]interface{}
+	if err := dec.Decode(&retry); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	if err := dec.Decode(&stopped); err != nil {
+		t.Fatal("Expected no error, got", err)
+	}
+	expected := map[string]interface{}{
+		"level": "warn", "event": "retry", "scope": "request", "type": "*zerologwireless.service", "provider": "NewService",
+		"attempt": float64(2), "error": "connection refused",
+	}
+	for k, v := range expected {
+		if retry[k] != v {
+			t.Errorf("Expected %v: %v, got %v", k, v, retry[k])
+		}
+	}
+	if stopped["level"] != "info" || stopped["scope"] != wireless.ScopeRoot || stopped["duration"] != float64(1000) {
+		t.Errorf("Expected the info with the root scope and duration, got %v", stopped)
+	}
+}